@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/auth"
+)
+
+// authn은 API 키 인증/사용량 관리자 (nil이면 인증과 한도를 적용하지 않음)
+var authn *auth.Authenticator
+
+// SetAuthenticator는 키별 사용량 한도에 사용할 Authenticator를 설정
+// 인증 자체는 main에서 authn.Middleware로 라우터 전체를 감싸서 적용합니다.
+func SetAuthenticator(a *auth.Authenticator) {
+	authn = a
+}
+
+// ownerOf는 요청을 보낸 API 키 이름을 반환 (인증이 꺼져 있으면 빈 문자열)
+func ownerOf(r *http.Request) string {
+	if key := auth.FromContext(r.Context()); key != nil {
+		return key.Name
+	}
+	return ""
+}
+
+// beginRun은 요청한 키의 동시 실행 수와 일일 요청 예산(cost)을 확인하고 차지
+// 한도를 넘으면 429로 응답하고 false를 반환합니다. 반환된 release는 실행이 끝나면 호출해야 합니다.
+func beginRun(w http.ResponseWriter, r *http.Request, cost int) (release func(), ok bool) {
+	key := auth.FromContext(r.Context())
+	if authn == nil || key == nil {
+		return func() {}, true
+	}
+	release, err := authn.Begin(key, cost)
+	if err != nil {
+		http.Error(w, err.Error(), quotaStatus(err))
+		return nil, false
+	}
+	return release, true
+}
+
+// chargeRun은 실행 중에 정해진 추가 요청 예산을 사용 (자동 테스트의 권장 테스트 등)
+func chargeRun(r *http.Request, cost int) error {
+	key := auth.FromContext(r.Context())
+	if authn == nil || key == nil {
+		return nil
+	}
+	return authn.Charge(key, cost)
+}
+
+// quotaStatus는 한도 초과 오류의 응답 코드를 반환
+func quotaStatus(err error) int {
+	var q *auth.QuotaError
+	if errors.As(err, &q) {
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
+}
+
+// HandleUsage는 요청한 API 키의 현재 사용량을 반환하는 핸들러
+// GET /auth/usage
+func HandleUsage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+	key := auth.FromContext(r.Context())
+	if authn == nil || key == nil {
+		http.Error(w, "API 키 인증이 비활성화되어 있습니다", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, authn.Usage(key))
+}
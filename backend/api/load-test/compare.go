@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/compare"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+	"github.com/Mr-Muji/LoadTest/libs/logger"
+)
+
+// autoCompare가 true면 실행 기록 저장 시 같은 대상의 직전 실행과 자동 비교
+var autoCompare bool
+
+// autoCompareTolerances는 자동 비교에 사용할 허용치
+var autoCompareTolerances = compare.DefaultTolerances()
+
+// SetAutoCompare는 실행 완료 시 직전 실행과의 자동 비교 여부와 허용치를 설정
+func SetAutoCompare(enabled bool, tol compare.Tolerances) {
+	autoCompare = enabled
+	autoCompareTolerances = tol
+}
+
+// HandleCompareTests는 두 실행 결과를 비교하는 핸들러
+// GET /tests/compare?base=ID&candidate=ID&latencyPct=10&latencyMinMs=5&errorRatePts=1&throughputPct=10&force=true
+// base를 생략하면 candidate와 같은 대상의 직전 실행(같은 종류, 같은 부하 설정)을 기준으로 사용합니다.
+// 종류나 부하 설정이 다른 두 실행은 force=true 일 때만 비교합니다.
+// 인증이 켜져 있으면 요청한 키가 실행한 기록끼리만 비교합니다.
+func HandleCompareTests(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+	if store == nil {
+		http.Error(w, "저장소가 설정되지 않았습니다", http.StatusServiceUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+	candidateID := query.Get("candidate")
+	if candidateID == "" {
+		http.Error(w, "candidate 파라미터가 필요합니다", http.StatusBadRequest)
+		return
+	}
+
+	// 허용치 파싱 (지정하지 않은 값은 기본값 사용)
+	tol := compare.DefaultTolerances()
+	for name, target := range map[string]*float64{
+		"latencyPct":    &tol.LatencyPct,
+		"latencyMinMs":  &tol.LatencyMinMs,
+		"errorRatePts":  &tol.ErrorRatePts,
+		"throughputPct": &tol.ThroughputPct,
+	} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 {
+			http.Error(w, fmt.Sprintf("잘못된 %s 값", name), http.StatusBadRequest)
+			return
+		}
+		*target = parsed
+	}
+
+	result, err := CompareRuns(ownerOf(r), query.Get("base"), candidateID, tol, query.Get("force") == "true")
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, errOtherOwner) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// CompareRuns는 저장된 두 실행 결과를 비교
+// baseID가 비어 있으면 candidate와 같은 대상의 직전 실행을 기준으로 사용합니다.
+// force가 false면 종류나 부하 설정이 다른 두 실행은 비교하지 않고 오류를 반환합니다.
+// owner가 있으면 그 키가 실행한 기록만 사용합니다 (CLI는 빈 값).
+func CompareRuns(owner, baseID, candidateID string, tol compare.Tolerances, force bool) (compare.Result, error) {
+	if store == nil {
+		return compare.Result{}, fmt.Errorf("저장소가 설정되지 않았습니다")
+	}
+
+	candidate, err := getOwnedRun(owner, candidateID)
+	if err != nil {
+		return compare.Result{}, fmt.Errorf("비교 실행 %s 조회 실패: %w", candidateID, err)
+	}
+	if candidate.Result == nil {
+		return compare.Result{}, fmt.Errorf("비교 실행 %s 에 부하 테스트 결과가 없습니다", candidateID)
+	}
+
+	var base *storage.TestRun
+	if baseID == "" {
+		base, err = storage.Previous(store, candidate)
+		if err != nil {
+			return compare.Result{}, fmt.Errorf("%s 의 직전 실행 조회 실패: %w", candidate.Target, err)
+		}
+	} else {
+		base, err = getOwnedRun(owner, baseID)
+		if err != nil {
+			return compare.Result{}, fmt.Errorf("기준 실행 %s 조회 실패: %w", baseID, err)
+		}
+		if base.Result == nil {
+			return compare.Result{}, fmt.Errorf("기준 실행 %s 에 부하 테스트 결과가 없습니다", baseID)
+		}
+		if err := storage.Comparable(base, candidate); err != nil && !force {
+			return compare.Result{}, fmt.Errorf("비교할 수 없는 실행입니다: %v (그래도 비교하려면 force=true)", err)
+		}
+	}
+
+	result := compare.Compare(*base.Result, *candidate.Result, tol)
+	result.BaseID = base.ID
+	result.CandidateID = candidate.ID
+	return result, nil
+}
+
+// attachComparison은 자동 비교가 켜져 있으면 직전 실행과 비교한 결과를 기록에 첨부
+func attachComparison(ctx context.Context, run *storage.TestRun) {
+	if !autoCompare {
+		return
+	}
+	result := comparePrevious(run)
+	if result != nil && result.Regressed {
+		logger.FromContext(ctx).Warnw("직전 실행 대비 성능 회귀 감지",
+			"target", run.Target,
+			"base", result.BaseID,
+			"candidate", run.ID,
+			"regressions", result.Regressions,
+		)
+	}
+}
+
+// comparePrevious는 직전 실행과 비교한 결과를 기록에 첨부하고 반환 (비교 대상이 없으면 nil)
+func comparePrevious(run *storage.TestRun) *compare.Result {
+	if store == nil || run.Result == nil {
+		return nil
+	}
+
+	prev, err := storage.Previous(store, run)
+	if err != nil {
+		// 첫 실행이면 비교 대상이 없음
+		return nil
+	}
+
+	result := compare.Compare(*prev.Result, *run.Result, autoCompareTolerances)
+	result.BaseID = prev.ID
+	result.CandidateID = run.ID
+	run.Comparison = &result
+	return &result
+}
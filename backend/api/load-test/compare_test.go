@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/compare"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+func TestCompareRunsRejectsDifferentRuns(t *testing.T) {
+	s := useTestStore(t)
+	now := time.Now()
+	save := func(id, kind string, rps int, p95 float64, at time.Duration) {
+		t.Helper()
+		err := s.Save(&storage.TestRun{
+			ID:        id,
+			Kind:      kind,
+			Target:    "http://example.com",
+			StartedAt: now.Add(at),
+			Request:   config.TestRequest{Target: "http://example.com", Method: "GET", RPS: rps, Duration: 30},
+			Result:    &config.TestResult{P95LatencyMs: p95},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	save("basic", storage.KindBasic, 10, 100, 0)
+	save("scheduled", storage.KindScheduled, 10, 100, time.Second)
+	save("heavier", storage.KindBasic, 50, 100, 2*time.Second)
+	save("candidate", storage.KindBasic, 10, 200, 3*time.Second)
+	tol := compare.DefaultTolerances()
+
+	for base, reason := range map[string]string{"scheduled": "테스트 종류", "heavier": "RPS"} {
+		if _, err := CompareRuns("", base, "candidate", tol, false); err == nil || !strings.Contains(err.Error(), reason) {
+			t.Errorf("CompareRuns(%s, candidate) = %v, want %s mismatch", base, err, reason)
+		}
+		if _, err := CompareRuns("", base, "candidate", tol, true); err != nil {
+			t.Errorf("CompareRuns(%s, candidate, force) = %v", base, err)
+		}
+	}
+
+	// 기준을 생략하면 비교할 수 있는 직전 실행을 사용
+	result, err := CompareRuns("", "", "candidate", tol, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.BaseID != "basic" || result.CandidateID != "candidate" || !result.Regressed {
+		t.Errorf("CompareRuns(previous) = base %s, candidate %s, regressed %v", result.BaseID, result.CandidateID, result.Regressed)
+	}
+
+	get := func(query string) int {
+		rec := httptest.NewRecorder()
+		HandleCompareTests(rec, httptest.NewRequest(http.MethodGet, "/tests/compare?"+query, nil))
+		return rec.Code
+	}
+	if code := get("base=scheduled&candidate=candidate"); code != http.StatusBadRequest {
+		t.Errorf("compare different kinds = %d, want 400", code)
+	}
+	if code := get("base=scheduled&candidate=candidate&force=true"); code != http.StatusOK {
+		t.Errorf("compare different kinds with force = %d, want 200", code)
+	}
+	if code := get("base=basic&candidate=missing"); code != http.StatusNotFound {
+		t.Errorf("compare missing run = %d, want 404", code)
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+)
+
+// CORS 응답 헤더 값
+const (
+	corsAllowMethods  = "GET, POST, PUT, DELETE, OPTIONS"
+	corsAllowHeaders  = "Authorization, X-API-Key, Content-Type"
+	corsExposeHeaders = "X-Test-ID"
+	corsMaxAge        = "600"
+)
+
+// WithCORS는 허용한 Origin의 브라우저 요청에 CORS 헤더를 붙이는 미들웨어
+// 사전 요청(OPTIONS)은 인증 없이 바로 응답하므로 인증 미들웨어 바깥에 둡니다.
+// origins가 비어 있으면 next를 그대로 반환합니다.
+func WithCORS(origins []string, next http.Handler) http.Handler {
+	if len(origins) == 0 {
+		return next
+	}
+	allowAll := false
+	allowed := make(map[string]bool, len(origins))
+	for _, o := range origins {
+		if o == "*" {
+			allowAll = true
+		}
+		allowed[strings.TrimRight(o, "/")] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || (!allowAll && !allowed[origin]) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Add("Vary", "Origin")
+		h.Set("Access-Control-Allow-Origin", origin)
+		h.Set("Access-Control-Expose-Headers", corsExposeHeaders)
+
+		// 사전 요청
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			h.Set("Access-Control-Allow-Methods", corsAllowMethods)
+			h.Set("Access-Control-Allow-Headers", corsAllowHeaders)
+			h.Set("Access-Control-Max-Age", corsMaxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
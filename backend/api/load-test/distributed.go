@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/auth"
+	"github.com/Mr-Muji/LoadTest/backend/modules/distributed"
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// coordinator는 분산 실행 코디네이터 (nil이면 분산 실행 비활성화)
+var coordinator *distributed.Coordinator
+
+// SetCoordinator는 분산 실행에 사용할 코디네이터를 설정
+func SetCoordinator(c *distributed.Coordinator) {
+	coordinator = c
+}
+
+// HandleAgents는 에이전트 등록(POST)과 목록 조회(GET)를 처리하는 핸들러
+// POST /agents {"id": "agent-1", "url": "http://10.0.0.5:9091"} (X-Agent-Token 헤더에 공유 토큰 필요)
+func HandleAgents(w http.ResponseWriter, r *http.Request) {
+	if coordinator == nil {
+		http.Error(w, "분산 실행이 비활성화되어 있습니다", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, coordinator.Agents())
+	case http.MethodPost:
+		// 등록된 주소로 실행 명령을 보내므로 공유 토큰을 가진 에이전트만 등록 가능
+		if coordinator.Token == "" {
+			http.Error(w, "AGENT_TOKEN이 설정되지 않아 에이전트를 등록할 수 없습니다", http.StatusForbidden)
+			return
+		}
+		if !distributed.ValidToken(r.Header.Get(distributed.TokenHeader), coordinator.Token) {
+			http.Error(w, "에이전트 토큰이 올바르지 않습니다", http.StatusForbidden)
+			return
+		}
+		var info distributed.AgentInfo
+		if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+			http.Error(w, "잘못된 요청 형식", http.StatusBadRequest)
+			return
+		}
+		if err := coordinator.Register(info); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleDistributedTest는 등록된 에이전트들로 부하를 나누어 실행하는 핸들러
+// POST /distributed-test (본문은 TestRequest 형식)
+func HandleDistributedTest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+	if coordinator == nil {
+		http.Error(w, "분산 실행이 비활성화되어 있습니다", http.StatusServiceUnavailable)
+		return
+	}
+
+	var testReq config.TestRequest
+	if err := json.NewDecoder(r.Body).Decode(&testReq); err != nil {
+		http.Error(w, "잘못된 요청 형식", http.StatusBadRequest)
+		return
+	}
+	if testReq.Target == "" {
+		http.Error(w, "target이 필요합니다", http.StatusBadRequest)
+		return
+	}
+
+	// 기본값 설정 (/test 와 동일)
+	if testReq.Method == "" {
+		testReq.Method = "GET"
+	}
+	// 재생 모드(schedule)는 기록된 시점대로 보내므로 rps/duration 기본값을 쓰지 않음
+	if testReq.RPS <= 0 && len(testReq.Schedule) == 0 {
+		testReq.RPS = defaultRPS
+	}
+	if testReq.Duration <= 0 && len(testReq.Schedule) == 0 {
+		testReq.Duration = defaultDuration
+	}
+	if len(testReq.PathList) == 0 {
+		testReq.PathList = []string{"/"}
+	}
+
+	if !checkSafety(w, r, testReq) {
+		return
+	}
+	release, ok := beginRun(w, r, auth.Cost(testReq))
+	if !ok {
+		return
+	}
+	run := &storage.TestRun{
+		ID:        storage.NewID(),
+		Kind:      storage.KindDistributed,
+		Owner:     ownerOf(r),
+		Target:    testReq.Target,
+		StartedAt: time.Now(),
+	}
+	testReq.ID = run.ID
+	testReq.Owner = run.Owner
+	run.Request = testReq
+
+	startRun(w, r, run.ID, release, func(ctx context.Context, w http.ResponseWriter) {
+		runDistributed(ctx, w, run, testReq)
+	})
+}
+
+// runDistributed는 실행 대기열을 거쳐 에이전트들에 분산 테스트를 실행시키고 결과를 응답
+func runDistributed(ctx context.Context, w http.ResponseWriter, run *storage.TestRun, testReq config.TestRequest) {
+	// 에이전트가 부하를 만들더라도 서버 실행 대기열(동시 실행 수, 전체 RPS 한도)을 똑같이 거침
+	w.Header().Set("X-Test-ID", run.ID)
+	admitted, err := loadtest.Admit(ctx, testReq)
+	if err != nil {
+		run.FinishedAt = time.Now()
+		run.Error = err.Error()
+		saveRun(ctx, run)
+		http.Error(w, fmt.Sprintf("분산 테스트 실행 중 오류: %v", err), runErrorStatus(err))
+		return
+	}
+	result, agents, err := coordinator.Run(ctx, testReq)
+	admitted()
+	run.FinishedAt = time.Now()
+
+	// 에이전트별 실패 사유는 전체 오류가 있어도 함께 남김
+	var agentErrors []string
+	for _, a := range agents {
+		if a.Error != "" {
+			agentErrors = append(agentErrors, fmt.Sprintf("[%s] %s", a.Agent, a.Error))
+		}
+	}
+	run.Error = strings.Join(agentErrors, "; ")
+	if err != nil {
+		if run.Error != "" {
+			run.Error = err.Error() + ": " + run.Error
+		} else {
+			run.Error = err.Error()
+		}
+		saveRun(ctx, run)
+		http.Error(w, fmt.Sprintf("분산 테스트 실행 중 오류: %s", run.Error), http.StatusInternalServerError)
+		return
+	}
+	run.Result = &result
+	saveRun(ctx, run)
+
+	// 에이전트별 결과는 요약만 반환
+	summaries := make([]map[string]interface{}, 0, len(agents))
+	for _, a := range agents {
+		summary := map[string]interface{}{"agent": a.Agent, "rps": a.RPS}
+		if a.Result != nil {
+			summary["totalRequests"] = a.Result.TotalRequests
+			summary["p95LatencyMs"] = a.Result.P95LatencyMs
+		}
+		if a.Error != "" {
+			summary["error"] = a.Error
+		}
+		summaries = append(summaries, summary)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":     run.ID,
+		"result": result,
+		"agents": summaries,
+	})
+}
@@ -1,21 +1,65 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/Mr-Muji/LoadTest/backend/config"
 	"github.com/Mr-Muji/LoadTest/backend/modules/ai"
+	"github.com/Mr-Muji/LoadTest/backend/modules/auth"
 	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
 	"github.com/Mr-Muji/LoadTest/backend/modules/orchestrator"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
 	"github.com/Mr-Muji/LoadTest/libs/logger"
+	"go.uber.org/zap"
 )
 
-// log 변수 선언
-var log = logger.Logger
+// WithLogger는 요청 컨텍스트에 로거를 담는 미들웨어
+// 핸들러와 핸들러가 실행하는 부하 테스트, 자동 테스트는 이 로거(logger.FromContext)로 로그를 남깁니다.
+func WithLogger(l *zap.SugaredLogger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(logger.WithContext(r.Context(), l)))
+	})
+}
+
+// /test 로 실행하는 기본 부하 테스트의 초당 요청 수와 실행 시간(초)
+var (
+	defaultRPS      = 10
+	defaultDuration = 30
+)
+
+// SetTestDefaults는 /test 의 초당 요청 수와 실행 시간(초)을 설정
+func SetTestDefaults(rps, duration int) {
+	defaultRPS = rps
+	defaultDuration = duration
+}
+
+// store는 테스트 실행 기록 저장소 (nil이면 저장하지 않음)
+var store storage.Store
+
+// SetStore는 핸들러가 사용할 실행 기록 저장소를 설정
+func SetStore(s storage.Store) {
+	store = s
+}
+
+// saveRun은 실행 기록을 저장소에 저장하고 웹훅으로 알림 (실패해도 응답에는 영향 없음)
+func saveRun(ctx context.Context, run *storage.TestRun) {
+	defer notifyRun(run)
+	if store == nil {
+		return
+	}
+	attachComparison(ctx, run)
+	storeSamples(ctx, run)
+	if err := store.Save(run); err != nil {
+		logger.FromContext(ctx).Warnw("실행 기록 저장 실패", "id", run.ID, "error", err)
+	}
+}
 
 // HandleStartTest는 기본 부하 테스트를 시작하는 핸들러
+// ?format=junit|csv|csv-samples|prometheus 를 지정하면 결과를 해당 형식으로 반환
 func HandleStartTest(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
@@ -24,19 +68,21 @@ func HandleStartTest(w http.ResponseWriter, r *http.Request) {
 
 	// URL만 포함된 단순 요청 구조체
 	var req struct {
-		URL string `json:"url"`
+		URL           string                `json:"url"`
+		RecordSamples bool                  `json:"recordSamples,omitempty"` // 요청별 원시 샘플 기록 여부
+		Tracing       *config.TracingConfig `json:"tracing,omitempty"`       // traceparent 전파 설정
 	}
 
 	// 요청 파싱
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Errorw("잘못된 요청 형식", "error", err)
+		logger.FromContext(r.Context()).Errorw("잘못된 요청 형식", "error", err)
 		http.Error(w, "잘못된 요청 형식", http.StatusBadRequest)
 		return
 	}
 
 	// URL 검증
 	if req.URL == "" {
-		log.Errorw("URL이 필요합니다")
+		logger.FromContext(r.Context()).Errorw("URL이 필요합니다")
 		http.Error(w, "URL이 필요합니다", http.StatusBadRequest)
 		return
 	}
@@ -45,23 +91,56 @@ func HandleStartTest(w http.ResponseWriter, r *http.Request) {
 	testReq := config.TestRequest{
 		Target:   req.URL,
 		Method:   "GET",
-		RPS:      10,
-		Duration: 30,
+		RPS:      defaultRPS,
+		Duration: defaultDuration,
 		PathList: []string{"/"},
 		Silent:   false,
-	}
 
-	// 부하 테스트 실행
-	result, err := loadtest.RunLoadTest(testReq)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("테스트 실행 중 오류: %v", err), http.StatusInternalServerError)
+		RecordSamples: req.RecordSamples,
+		Tracing:       req.Tracing,
+	}
+	if !checkSafety(w, r, testReq) {
+		return
+	}
+	release, ok := beginRun(w, r, auth.Cost(testReq))
+	if !ok {
 		return
 	}
 
-	// 결과 반환
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(result)
+	run := &storage.TestRun{
+		ID:        storage.NewID(),
+		Kind:      storage.KindBasic,
+		Owner:     ownerOf(r),
+		Target:    req.URL,
+		StartedAt: time.Now(),
+	}
+	testReq.ID = run.ID
+	testReq.Owner = run.Owner
+	run.Request = testReq
+
+	startRun(w, r, run.ID, release, func(ctx context.Context, w http.ResponseWriter) {
+		// 부하 테스트 실행
+		result, err := loadtest.RunLoadTestContext(ctx, testReq)
+		run.FinishedAt = time.Now()
+		w.Header().Set("X-Test-ID", run.ID)
+		if err != nil {
+			run.Error = err.Error()
+			saveRun(ctx, run)
+			http.Error(w, fmt.Sprintf("테스트 실행 중 오류: %v", err), runErrorStatus(err))
+			return
+		}
+		run.Result = &result
+		saveRun(ctx, run)
+
+		// 결과 반환 (저장된 실행 ID는 헤더로 전달)
+		if format := r.URL.Query().Get("format"); format != "" {
+			writeExport(w, format, run)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(result)
+	})
 }
 
 // HandleAdvancedAutoTest는 URL만 입력받아 전체 과정을 자동화하는 핸들러
@@ -71,59 +150,125 @@ func HandleAdvancedAutoTest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// URL만 포함된 단순 요청 구조체
+	// URL과 선택적인 탐색 소스 (없으면 서버 기본 크롤러)
 	var req struct {
-		URL string `json:"url"`
+		URL    string                    `json:"url"`
+		Source orchestrator.SourceConfig `json:"source"`
+		Scope  *config.Scope             `json:"scope,omitempty"` // 허용 호스트, 포함/제외 경로
 	}
 
 	// 요청 파싱
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Errorw("잘못된 요청 형식", "error", err)
+		logger.FromContext(r.Context()).Errorw("잘못된 요청 형식", "error", err)
 		http.Error(w, "잘못된 요청 형식", http.StatusBadRequest)
 		return
 	}
 
 	// URL 검증
 	if req.URL == "" {
-		log.Errorw("URL이 필요합니다")
+		logger.FromContext(r.Context()).Errorw("URL이 필요합니다")
 		http.Error(w, "URL이 필요합니다", http.StatusBadRequest)
 		return
 	}
 
+	// 탐색도 대상에 요청을 보내므로 허용된 대상인지 먼저 확인
+	if !checkTargetSafety(w, r, req.URL) {
+		return
+	}
+	source, err := orchestrator.NewSource(req.Source)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 테스트 강도는 분석 후에 정해지므로 동시 실행 수만 먼저 차지하고 예산은 각 부하 테스트 실행 직전에 사용
+	release, ok := beginRun(w, r, 0)
+	if !ok {
+		return
+	}
+
+	run := &storage.TestRun{
+		ID:        storage.NewID(),
+		Kind:      storage.KindAdvanced,
+		Owner:     ownerOf(r),
+		Target:    req.URL,
+		StartedAt: time.Now(),
+	}
+
+	startRun(w, r, run.ID, release, func(ctx context.Context, w http.ResponseWriter) {
+		runAdvancedAutoTest(ctx, w, r, run, req.URL, source, req.Scope)
+	})
+}
+
+// runAdvancedAutoTest는 고급 자동 테스트의 탐색, 분석, 권장 테스트 실행을 차례로 수행하고 결과를 응답
+func runAdvancedAutoTest(ctx context.Context, w http.ResponseWriter, r *http.Request, run *storage.TestRun, targetURL string, source orchestrator.EndpointSource, sc *config.Scope) {
+	w.Header().Set("X-Test-ID", run.ID)
+
 	// 1. 기본 테스트 실행하여 경로 추출 (orchestrator 모듈 사용)
-	autoTest, err := orchestrator.RunFullTest(req.URL)
+	// 1단계 부하 테스트(추천 경로)도 강도가 정해지면 실행 전에 요청 예산을 사용
+	autoTest, err := orchestrator.RunFullTestWithSource(ctx, targetURL, source, orchestrator.FullTestOptions{
+		Scope: sc,
+		Owner: run.Owner,
+		BeforeLoad: func(testReq config.TestRequest) error {
+			return chargeRun(r, auth.Cost(testReq))
+		},
+	})
 	if err != nil {
-		http.Error(w, fmt.Sprintf("테스트 실행 중 오류: %v", err), http.StatusInternalServerError)
+		run.FinishedAt = time.Now()
+		run.Error = err.Error()
+		saveRun(ctx, run)
+		http.Error(w, fmt.Sprintf("테스트 실행 중 오류: %v", err), runErrorStatus(err))
 		return
 	}
 
 	// 2. 웹사이트 분석 - 통합된 함수 사용 (ai 모듈 사용)
-	analysisResult, err := ai.AnalyzeWebsite(req.URL, autoTest.ExtractedPaths)
+	run.ExtractedPaths = autoTest.ExtractedPaths
+	run.Endpoints = autoTest.Endpoints
+	run.OutOfScope = autoTest.OutOfScope
+	analysisResult, err := ai.AnalyzeWebsite(ctx, targetURL, autoTest.ExtractedPaths)
 	if err != nil {
+		run.FinishedAt = time.Now()
+		run.Error = err.Error()
+		saveRun(ctx, run)
 		http.Error(w, fmt.Sprintf("웹사이트 분석 중 오류: %v", err), http.StatusInternalServerError)
 		return
 	}
 
 	// 3. 첫 번째 권장 테스트 실행 (1차 테스트) (orchestrator 모듈 사용)
-	var firstTestResult interface{}
+	run.Analysis = analysisResult
+	var firstTestResult *config.TestResult
 	if len(analysisResult.RecommendedTests) > 0 {
-		firstTestResult, err = orchestrator.RunRecommendedLoadTest(
-			req.URL,
-			analysisResult.RecommendedTests[0],
-		)
+		run.Request = orchestrator.BuildRecommendedRequest(targetURL, analysisResult.RecommendedTests[0], autoTest.Endpoints)
+		run.Request.ID = run.ID
+		run.Request.Owner = run.Owner
+		run.Request.Scope = sc
+		var testResult config.TestResult
+		err := chargeRun(r, auth.Cost(run.Request))
+		if err == nil {
+			testResult, err = loadtest.RunLoadTestContext(ctx, run.Request)
+		}
 		if err != nil {
-			log.Warnw("권장 테스트 실행 중 오류", "error", err)
+			logger.FromContext(ctx).Warnw("권장 테스트 실행 중 오류", "error", err)
+			run.Error = err.Error()
+		} else {
+			firstTestResult = &testResult
+			run.Result = firstTestResult
 		}
 	}
+	run.FinishedAt = time.Now()
+	saveRun(ctx, run)
 
 	// 4. 결과 반환
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
 	result := map[string]interface{}{
-		"url":             req.URL,
+		"id":              run.ID,
+		"url":             targetURL,
 		"analysis":        analysisResult.Analysis,
 		"extractedPaths":  autoTest.ExtractedPaths,
+		"endpoints":       autoTest.Endpoints,
+		"outOfScope":      autoTest.OutOfScope,
 		"recommendations": analysisResult.RecommendedTests,
 	}
 
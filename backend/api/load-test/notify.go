@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/notify"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// notifier는 실행 종료 웹훅 알림 (nil이면 알리지 않음)
+var notifier *notify.Notifier
+
+// SetNotifier는 실행이 끝날 때 사용할 웹훅 알림을 설정
+func SetNotifier(n *notify.Notifier) {
+	notifier = n
+}
+
+// notifyRun은 실행 종료를 웹훅으로 비동기 전송
+func notifyRun(run *storage.TestRun) {
+	if notifier == nil {
+		return
+	}
+	notifier.Notify(run)
+}
+
+// HandleWebhookTest는 예시 이벤트를 설정된 모든 웹훅에 한 번씩 보내고 결과를 반환하는 핸들러
+// POST /webhooks/test
+func HandleWebhookTest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+	if notifier == nil || len(notifier.Targets) == 0 {
+		http.Error(w, "웹훅이 설정되지 않았습니다 (WEBHOOK_URLS)", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, notifier.Test(r.Context()))
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/auth"
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/modules/openapi"
+	"github.com/Mr-Muji/LoadTest/backend/modules/orchestrator"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// HandleOpenAPITest는 OpenAPI/Swagger 명세로 부하 테스트 구성을 만들어 실행하는 핸들러
+// POST /openapi-test ("dryRun": true 면 실행하지 않고 생성된 구성만 반환)
+func HandleOpenAPITest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		URL               string                    `json:"url"`               // 테스트 대상 URL
+		Spec              orchestrator.SourceConfig `json:"spec"`              // 명세 위치 또는 내용
+		RPS               int                       `json:"rps"`               // 초당 요청 수 (기본 10)
+		Duration          int                       `json:"duration"`          // 테스트 시간(초, 기본 30)
+		Methods           []string                  `json:"methods"`           // 포함할 메서드 (비어 있으면 전체)
+		Headers           map[string]string         `json:"headers"`           // 모든 요청에 추가할 헤더
+		IncludeDeprecated bool                      `json:"includeDeprecated"` // deprecated Operation 포함 여부
+		Thresholds        []config.Threshold        `json:"thresholds"`        // 합격 기준
+		DryRun            bool                      `json:"dryRun"`            // true면 구성만 반환
+		Scope             *config.Scope             `json:"scope"`             // 요청 범위 (허용 호스트, 포함/제외 경로)
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "잘못된 요청 형식", http.StatusBadRequest)
+		return
+	}
+	if req.URL == "" {
+		http.Error(w, "URL이 필요합니다", http.StatusBadRequest)
+		return
+	}
+	if req.RPS <= 0 {
+		req.RPS = 10
+	}
+	if req.Duration <= 0 {
+		req.Duration = 30
+	}
+
+	// 명세를 내려받기 전에 대상이 허용된 대상인지 확인 (dryRun도 동일, 명세 URL은 내려받을 때 따로 검사)
+	if !checkTargetSafety(w, r, req.URL) {
+		return
+	}
+
+	base := config.TestRequest{
+		Target:     req.URL,
+		Method:     "GET",
+		RPS:        req.RPS,
+		Duration:   req.Duration,
+		Silent:     true,
+		Thresholds: req.Thresholds,
+		Scope:      req.Scope,
+	}
+	plan, err := orchestrator.BuildOpenAPIPlan(r.Context(), req.Spec, base, openapi.PlanOptions{
+		IncludeDeprecated: req.IncludeDeprecated,
+		Methods:           req.Methods,
+		Headers:           req.Headers,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("명세 처리 중 오류: %v", err), planErrorStatus(err))
+		return
+	}
+	if req.DryRun {
+		writeJSON(w, http.StatusOK, plan)
+		return
+	}
+
+	if !checkSafety(w, r, plan.Request) {
+		return
+	}
+	release, ok := beginRun(w, r, auth.Cost(plan.Request))
+	if !ok {
+		return
+	}
+	run := &storage.TestRun{
+		ID:        storage.NewID(),
+		Kind:      storage.KindOpenAPI,
+		Owner:     ownerOf(r),
+		Target:    req.URL,
+		StartedAt: time.Now(),
+	}
+	plan.Request.ID = run.ID
+	plan.Request.Owner = run.Owner
+	run.Request = plan.Request
+
+	startRun(w, r, run.ID, release, func(ctx context.Context, w http.ResponseWriter) {
+		result, err := loadtest.RunLoadTestContext(ctx, plan.Request)
+		run.FinishedAt = time.Now()
+		w.Header().Set("X-Test-ID", run.ID)
+		if err != nil {
+			run.Error = err.Error()
+			saveRun(ctx, run)
+			http.Error(w, fmt.Sprintf("테스트 실행 중 오류: %v", err), runErrorStatus(err))
+			return
+		}
+		run.Result = &result
+		saveRun(ctx, run)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":      run.ID,
+			"title":   plan.Title,
+			"skipped": plan.Skipped,
+			"result":  result,
+		})
+	})
+}
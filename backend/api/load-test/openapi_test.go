@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/orchestrator"
+	"github.com/Mr-Muji/LoadTest/backend/modules/safety"
+)
+
+func TestOpenAPITestChecksSafetyBeforeFetchingSpec(t *testing.T) {
+	var specHits int
+	specServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		specHits++
+		w.Write([]byte(`{"openapi": "3.0.0", "paths": {"/a": {"get": {}}}}`))
+	}))
+	defer specServer.Close()
+
+	p := &safety.Policy{AllowedHosts: []string{"allowed.example"}}
+	SetSafetyPolicy(p)
+	orchestrator.SetFetchGuard(p.CheckTarget)
+	defer func() {
+		SetSafetyPolicy(nil)
+		orchestrator.SetFetchGuard(nil)
+	}()
+
+	for name, body := range map[string]string{
+		"target not allowed":   `{"url": "http://blocked.example", "spec": {"location": "` + specServer.URL + `/openapi.json"}, "dryRun": true}`,
+		"spec URL not allowed": `{"url": "http://allowed.example", "spec": {"location": "` + specServer.URL + `/openapi.json"}, "dryRun": true}`,
+	} {
+		rec := httptest.NewRecorder()
+		HandleOpenAPITest(rec, httptest.NewRequest(http.MethodPost, "/openapi-test", strings.NewReader(body)))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s: status = %d, want 403 (%s)", name, rec.Code, rec.Body.String())
+		}
+	}
+	if specHits != 0 {
+		t.Errorf("spec server was requested %d times, want 0", specHits)
+	}
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/auth"
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/modules/queue"
+	"github.com/Mr-Muji/LoadTest/backend/modules/safety"
+)
+
+// runQueue는 서버 실행 대기열 (nil이면 대기 없이 바로 실행)
+var runQueue *queue.Queue
+
+// SetQueue는 상태 조회에 사용할 실행 대기열을 설정
+// 실제 대기는 loadtest.SetAdmission으로 모든 테스트 실행에 적용됩니다.
+func SetQueue(q *queue.Queue) {
+	runQueue = q
+}
+
+// background는 비동기(?async=true)로 시작해 응답 후에도 실행 중인 테스트
+var background sync.WaitGroup
+
+// Wait는 비동기로 시작한 테스트가 모두 끝날 때까지 기다림 (서버 종료 시 사용)
+func Wait() {
+	background.Wait()
+}
+
+// startRun은 실행 ID가 정해진 테스트를 exec로 실행하고 끝나면 release를 호출
+// ?async=true 요청이면 대기열에 들어가기 전에 실행 ID와 조회 경로를 202로 바로 반환하고 exec는 백그라운드에서 실행합니다.
+// 이때 exec가 쓰는 응답은 버려지며, 대기 상태는 /queue/{id}, 결과는 실행이 끝난 뒤 /tests/{id}로 확인합니다.
+// 동기 요청은 클라이언트 연결이 끊기면 ctx가 취소되어 대기 중인 테스트가 대기열에서 빠집니다.
+func startRun(w http.ResponseWriter, r *http.Request, id string, release func(), exec func(ctx context.Context, w http.ResponseWriter)) {
+	if r.URL.Query().Get("async") != "true" {
+		defer release()
+		exec(r.Context(), w)
+		return
+	}
+
+	// 응답 후에도 계속 실행하므로 요청 취소와 분리 (인증된 키 등 컨텍스트 값은 유지)
+	ctx := context.WithoutCancel(r.Context())
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		defer release()
+		exec(ctx, discardResponse{header: make(http.Header)})
+	}()
+
+	w.Header().Set("X-Test-ID", id)
+	writeJSON(w, http.StatusAccepted, map[string]string{
+		"id":     id,
+		"queue":  "/queue/" + id,
+		"result": "/tests/" + id,
+	})
+}
+
+// discardResponse는 비동기 실행의 응답을 버리는 ResponseWriter
+type discardResponse struct {
+	header http.Header
+}
+
+func (d discardResponse) Header() http.Header         { return d.header }
+func (d discardResponse) Write(b []byte) (int, error) { return len(b), nil }
+func (d discardResponse) WriteHeader(int)             {}
+
+// statusClientClosed는 클라이언트가 실행 대기 중에 연결을 끊은 경우의 응답 코드 (nginx 관례, 로그용)
+const statusClientClosed = 499
+
+// runErrorStatus는 테스트 실행 오류의 응답 코드를 반환
+// 안전 정책 위반은 403, 대기열이 가득 찼거나 키의 사용량 한도를 넘은 경우는 429, 서버 한도를 넘는 요청은 400, 서버 종료 중이면 503입니다.
+func runErrorStatus(err error) int {
+	var violation *safety.Violation
+	var busy *queue.BusyError
+	var limit *queue.LimitError
+	var quota *auth.QuotaError
+	switch {
+	case errors.As(err, &violation):
+		return http.StatusForbidden
+	case errors.As(err, &busy), errors.As(err, &quota):
+		return http.StatusTooManyRequests
+	case errors.As(err, &limit):
+		return http.StatusBadRequest
+	case errors.Is(err, loadtest.ErrShuttingDown), errors.Is(err, queue.ErrClosed):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.Canceled):
+		return statusClientClosed
+	}
+	return http.StatusInternalServerError
+}
+
+// HandleQueue는 실행 중인 테스트와 대기 중인 테스트(대기 순서 포함)를 반환하는 핸들러
+// GET /queue?owner=team-a (owner를 지정하면 해당 키의 테스트만, 대기 순서는 전체 기준)
+// 인증이 켜져 있으면 owner와 관계없이 요청한 키의 테스트만 반환합니다.
+func HandleQueue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+	if runQueue == nil {
+		http.Error(w, "실행 대기열이 비활성화되어 있습니다", http.StatusNotFound)
+		return
+	}
+	status := runQueue.Status()
+	owner := r.URL.Query().Get("owner")
+	if o := ownerOf(r); o != "" {
+		owner = o
+	}
+	if owner != "" {
+		status.Running = filterJobs(status.Running, owner)
+		status.Waiting = filterJobs(status.Waiting, owner)
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// filterJobs는 owner가 시작한 작업만 남김
+func filterJobs(jobs []queue.Job, owner string) []queue.Job {
+	filtered := make([]queue.Job, 0, len(jobs))
+	for _, job := range jobs {
+		if job.Owner == owner {
+			filtered = append(filtered, job)
+		}
+	}
+	return filtered
+}
+
+// HandleQueueJob은 실행 ID로 대기 상태와 순서를 반환하는 핸들러
+// GET /queue/{id}
+func HandleQueueJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+	if runQueue == nil {
+		http.Error(w, "실행 대기열이 비활성화되어 있습니다", http.StatusNotFound)
+		return
+	}
+	job, ok := runQueue.Find(r.PathValue("id"))
+	if !ok {
+		http.Error(w, "대기 중이거나 실행 중인 테스트가 아닙니다", http.StatusNotFound)
+		return
+	}
+	if owner := ownerOf(r); owner != "" && job.Owner != owner {
+		http.Error(w, errOtherOwner.Error(), http.StatusForbidden)
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/queue"
+)
+
+func TestStartRunAsyncRespondsBeforeRunning(t *testing.T) {
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	released := make(chan struct{})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/start-test?async=true", nil)
+	ctx, cancel := context.WithCancel(req.Context())
+	startRun(rec, req.WithContext(ctx), "run-1", func() { close(released) }, func(ctx context.Context, w http.ResponseWriter) {
+		close(started)
+		<-finish
+		if ctx.Err() != nil {
+			t.Error("async run was canceled with the request")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// 실행이 끝나기 전에 실행 ID가 202로 반환됨
+	if rec.Code != http.StatusAccepted || rec.Header().Get("X-Test-ID") != "run-1" {
+		t.Fatalf("status = %d, X-Test-ID = %q", rec.Code, rec.Header().Get("X-Test-ID"))
+	}
+	var body map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body["id"] != "run-1" || body["queue"] != "/queue/run-1" || body["result"] != "/tests/run-1" {
+		t.Errorf("body = %v", body)
+	}
+
+	// 응답 후 요청이 끝나도 실행은 계속되고, 끝나면 release 호출
+	cancel()
+	<-started
+	close(finish)
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("release was not called after the async run")
+	}
+	Wait()
+}
+
+func TestStartRunSyncUsesRequestContext(t *testing.T) {
+	var released bool
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/start-test", nil)
+	ctx, cancel := context.WithCancel(req.Context())
+	cancel()
+	startRun(rec, req.WithContext(ctx), "run-1", func() { released = true }, func(ctx context.Context, w http.ResponseWriter) {
+		// 클라이언트 연결이 끊기면 대기 중인 실행도 취소됨
+		if ctx.Err() == nil {
+			t.Error("sync run context was not canceled with the request")
+		}
+		http.Error(w, ctx.Err().Error(), runErrorStatus(ctx.Err()))
+	})
+	if !released {
+		t.Error("release was not called")
+	}
+	if rec.Code != statusClientClosed {
+		t.Errorf("status = %d, want %d", rec.Code, statusClientClosed)
+	}
+}
+
+func TestQueueIsScopedToOwner(t *testing.T) {
+	q := queue.New(queue.Options{MaxConcurrent: 1})
+	SetQueue(q)
+	defer SetQueue(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	release, err := q.Acquire(ctx, queue.Job{ID: "run-a", Owner: "team-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	go q.Acquire(ctx, queue.Job{ID: "run-b", Owner: "team-b"})
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		if _, ok := q.Find("run-b"); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("run-b did not enter the queue")
+		}
+	}
+
+	// owner 파라미터로 다른 키를 지정해도 자기 테스트만 반환
+	rec := httptest.NewRecorder()
+	HandleQueue(rec, asKey(httptest.NewRequest(http.MethodGet, "/queue?owner=team-b", nil), "team-a"))
+	var status queue.Status
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Running) != 1 || status.Running[0].ID != "run-a" || len(status.Waiting) != 0 {
+		t.Errorf("team-a queue = %+v", status)
+	}
+
+	req := asKey(httptest.NewRequest(http.MethodGet, "/queue/run-b", nil), "team-a")
+	req.SetPathValue("id", "run-b")
+	rec = httptest.NewRecorder()
+	HandleQueueJob(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("other owner's job: status = %d, want 403", rec.Code)
+	}
+
+	req = asKey(httptest.NewRequest(http.MethodGet, "/queue/run-b", nil), "team-b")
+	req.SetPathValue("id", "run-b")
+	rec = httptest.NewRecorder()
+	HandleQueueJob(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("own job: status = %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/auth"
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/modules/orchestrator"
+	"github.com/Mr-Muji/LoadTest/backend/modules/replay"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// HandleReplayTest는 HAR 또는 access log의 실제 트래픽을 재생하는 핸들러
+// POST /replay-test ("dryRun": true 면 실행하지 않고 생성된 구성만 반환)
+func HandleReplayTest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		URL           string                    `json:"url"`           // 테스트 대상 URL
+		Source        orchestrator.SourceConfig `json:"source"`        // type: har 또는 accesslog
+		Mode          string                    `json:"mode"`          // timed(기본) 또는 mix
+		Speed         float64                   `json:"speed"`         // timed 재생 속도 배율 (기본 1)
+		RPS           int                       `json:"rps"`           // mix 모드 초당 요청 수 (기본 10)
+		Duration      int                       `json:"duration"`      // 테스트 시간(초, timed는 0이면 기록 끝까지)
+		IncludeStatic bool                      `json:"includeStatic"` // 정적 리소스 요청 포함 여부
+		MaxEvents     int                       `json:"maxEvents"`     // timed 재생의 최대 요청 수, mix의 최대 요청 종류 수
+		Thresholds    []config.Threshold        `json:"thresholds"`    // 합격 기준
+		DryRun        bool                      `json:"dryRun"`        // true면 구성만 반환
+		Scope         *config.Scope             `json:"scope"`         // 요청 범위 (허용 호스트, 포함/제외 경로)
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "잘못된 요청 형식", http.StatusBadRequest)
+		return
+	}
+	if req.URL == "" {
+		http.Error(w, "URL이 필요합니다", http.StatusBadRequest)
+		return
+	}
+	if req.Mode == orchestrator.ReplayMix {
+		if req.RPS <= 0 {
+			req.RPS = 10
+		}
+		if req.Duration <= 0 {
+			req.Duration = 30
+		}
+	}
+
+	// 기록을 내려받기 전에 대상이 허용된 대상인지 확인 (dryRun도 동일, 기록 URL은 내려받을 때 따로 검사)
+	if !checkTargetSafety(w, r, req.URL) {
+		return
+	}
+
+	base := config.TestRequest{
+		Target:     req.URL,
+		Method:     "GET",
+		RPS:        req.RPS,
+		Duration:   req.Duration,
+		Speed:      req.Speed,
+		Silent:     true,
+		Thresholds: req.Thresholds,
+		Scope:      req.Scope,
+	}
+	plan, err := orchestrator.BuildReplayPlan(r.Context(), req.Source, req.Mode, base, replay.Options{
+		IncludeStatic: req.IncludeStatic,
+		MaxEvents:     req.MaxEvents,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("기록 처리 중 오류: %v", err), planErrorStatus(err))
+		return
+	}
+	if req.DryRun {
+		writeJSON(w, http.StatusOK, plan)
+		return
+	}
+
+	if !checkSafety(w, r, plan.Request) {
+		return
+	}
+	release, ok := beginRun(w, r, auth.Cost(plan.Request))
+	if !ok {
+		return
+	}
+	run := &storage.TestRun{
+		ID:        storage.NewID(),
+		Kind:      storage.KindReplay,
+		Owner:     ownerOf(r),
+		Target:    req.URL,
+		StartedAt: time.Now(),
+	}
+	plan.Request.ID = run.ID
+	plan.Request.Owner = run.Owner
+	run.Request = plan.Request
+
+	startRun(w, r, run.ID, release, func(ctx context.Context, w http.ResponseWriter) {
+		result, err := loadtest.RunLoadTestContext(ctx, plan.Request)
+		run.FinishedAt = time.Now()
+		w.Header().Set("X-Test-ID", run.ID)
+		if err != nil {
+			run.Error = err.Error()
+			saveRun(ctx, run)
+			http.Error(w, fmt.Sprintf("테스트 실행 중 오류: %v", err), runErrorStatus(err))
+			return
+		}
+		run.Result = &result
+		saveRun(ctx, run)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":           run.ID,
+			"events":       plan.Events,
+			"skippedLines": plan.SkippedLines,
+			"result":       result,
+		})
+	})
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/orchestrator"
+	"github.com/Mr-Muji/LoadTest/backend/modules/safety"
+)
+
+func TestReplayTestChecksSafetyBeforeFetchingSource(t *testing.T) {
+	var logHits int
+	logServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logHits++
+		w.Write([]byte(`127.0.0.1 - - [10/Oct/2024:13:55:36 +0000] "GET /a HTTP/1.1" 200 12 "-" "curl"` + "\n"))
+	}))
+	defer logServer.Close()
+
+	p := &safety.Policy{AllowedHosts: []string{"allowed.example"}}
+	SetSafetyPolicy(p)
+	orchestrator.SetFetchGuard(p.CheckTarget)
+	defer func() {
+		SetSafetyPolicy(nil)
+		orchestrator.SetFetchGuard(nil)
+	}()
+
+	for name, body := range map[string]string{
+		"target not allowed":     `{"url": "http://blocked.example", "source": {"type": "accesslog", "location": "` + logServer.URL + `/access.log"}, "dryRun": true}`,
+		"source URL not allowed": `{"url": "http://allowed.example", "source": {"type": "accesslog", "location": "` + logServer.URL + `/access.log"}, "dryRun": true}`,
+	} {
+		rec := httptest.NewRecorder()
+		HandleReplayTest(rec, httptest.NewRequest(http.MethodPost, "/replay-test", strings.NewReader(body)))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s: status = %d, want 403 (%s)", name, rec.Code, rec.Body.String())
+		}
+	}
+	if logHits != 0 {
+		t.Errorf("log server was requested %d times, want 0", logHits)
+	}
+}
+
+func TestReplayTestRejectsServerFileWithoutSourceDir(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "access.log")
+	os.WriteFile(secret, []byte(`127.0.0.1 - - [10/Oct/2024:13:55:36 +0000] "GET /secret-path HTTP/1.1" 200 12 "-" "curl"`+"\n"), 0644)
+	orchestrator.SetSourceDir("")
+
+	body := `{"url": "http://example.com", "source": {"type": "accesslog", "location": "` + secret + `"}, "dryRun": true}`
+	rec := httptest.NewRecorder()
+	HandleReplayTest(rec, httptest.NewRequest(http.MethodPost, "/replay-test", strings.NewReader(body)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "secret-path") {
+		t.Errorf("response leaked file content: %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/safety"
+)
+
+// policy는 부하 테스트 안전 정책 (nil이면 검사하지 않음)
+var policy *safety.Policy
+
+// SetSafetyPolicy는 요청 검사에 사용할 안전 정책을 설정
+func SetSafetyPolicy(p *safety.Policy) {
+	policy = p
+}
+
+// checkSafety는 테스트 요청이 안전 정책을 만족하는지 검사하고, 위반이면 403으로 응답
+func checkSafety(w http.ResponseWriter, r *http.Request, req config.TestRequest) bool {
+	if policy == nil {
+		return true
+	}
+	if err := policy.Check(r.Context(), req); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// checkTargetSafety는 대상 URL이 허용 목록 안인지 검사하고, 위반이면 403으로 응답
+// 크롤링 등 탐색 단계도 허용된 대상에만 요청을 보내도록 탐색 전에 사용합니다.
+func checkTargetSafety(w http.ResponseWriter, r *http.Request, targetURL string) bool {
+	if policy == nil {
+		return true
+	}
+	if err := policy.CheckTarget(r.Context(), targetURL); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// planErrorStatus는 명세/기록으로 테스트 구성을 만들다 난 오류의 응답 코드를 반환
+// 문서 URL이 안전 정책에 막힌 경우는 403, 그 외(잘못된 문서 등)는 400입니다.
+func planErrorStatus(err error) int {
+	var violation *safety.Violation
+	if errors.As(err, &violation) {
+		return http.StatusForbidden
+	}
+	return http.StatusBadRequest
+}
+
+// HandleVerification은 대상 소유 확인 방법(토큰, DNS TXT 레코드, /.well-known 파일)을 반환하는 핸들러
+// GET /safety/verification?host=example.com
+func HandleVerification(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+	if policy == nil || policy.VerifyAboveRPS <= 0 {
+		http.Error(w, "소유 확인이 비활성화되어 있습니다", http.StatusNotFound)
+		return
+	}
+	host := r.URL.Query().Get("host")
+	if host == "" {
+		http.Error(w, "host가 필요합니다", http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusOK, policy.InstructionsFor(host))
+}
@@ -0,0 +1,264 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/export"
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/modules/samples"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+	"github.com/Mr-Muji/LoadTest/libs/logger"
+)
+
+// sampleStore는 실행별 원시 샘플 파일 저장소 (nil이면 결과에 담긴 샘플만 사용)
+var sampleStore *samples.Store
+
+// SetSampleStore는 원시 샘플 파일 저장소를 설정
+func SetSampleStore(s *samples.Store) {
+	sampleStore = s
+}
+
+// storeSamples는 결과에 담겨 온 원시 샘플(분산 실행 등)을 샘플 파일로 옮김
+// 실행 기록 JSON이 샘플 때문에 커지지 않도록 저장 전에 호출합니다.
+func storeSamples(ctx context.Context, run *storage.TestRun) {
+	if sampleStore == nil || run.Result == nil || len(run.Result.Samples) == 0 {
+		return
+	}
+	header := samples.Header{RunID: run.ID, Target: run.Target, StartedAt: run.StartedAt}
+	if err := sampleStore.WriteAll(header, run.Result.Samples); err != nil {
+		logger.FromContext(ctx).Warnw("원시 샘플 파일 저장 실패, 실행 기록에 남깁니다", "id", run.ID, "error", err)
+		return
+	}
+	run.Result.RecordedSamples += len(run.Result.Samples)
+	run.Result.Samples = nil
+}
+
+// sampleSource는 실행 하나의 원시 샘플 (샘플 파일 또는 실행 기록에 담긴 샘플)
+type sampleSource struct {
+	header samples.Header
+	reader *samples.Reader // 샘플 파일 (nil이면 list 사용)
+	list   []config.Sample // 실행 기록에 담긴 샘플
+}
+
+// openSamples는 실행의 원시 샘플을 엶 (샘플 파일이 우선, 둘 다 없으면 samples.ErrNotFound)
+func openSamples(run *storage.TestRun) (*sampleSource, error) {
+	if sampleStore != nil {
+		r, err := sampleStore.Open(run.ID)
+		if err == nil {
+			return &sampleSource{header: r.Header(), reader: r}, nil
+		}
+		if !errors.Is(err, samples.ErrNotFound) {
+			return nil, err
+		}
+	}
+	if run.Result == nil || len(run.Result.Samples) == 0 {
+		return nil, samples.ErrNotFound
+	}
+	header := samples.Header{Version: samples.Version, RunID: run.ID, Target: run.Target, StartedAt: run.StartedAt}
+	return &sampleSource{header: header, list: run.Result.Samples}, nil
+}
+
+// each는 filter를 만족하는 샘플마다 fn을 호출 (fn이 오류를 반환하면 중단)
+func (src *sampleSource) each(filter samples.Filter, fn func(config.Sample) error) error {
+	if src.reader != nil {
+		return src.reader.Each(filter, fn)
+	}
+	for _, s := range src.list {
+		if !filter.Match(src.header.StartedAt, s) {
+			continue
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close는 샘플 파일을 닫음
+func (src *sampleSource) Close() {
+	if src.reader != nil {
+		src.reader.Close()
+	}
+}
+
+// WithSamples는 샘플 파일의 원시 샘플을 채운 실행 기록 복사본을 반환 (csv-samples 내보내기용)
+func WithSamples(run *storage.TestRun) (*storage.TestRun, error) {
+	if run.Result == nil || len(run.Result.Samples) > 0 {
+		return run, nil
+	}
+	src, err := openSamples(run)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	var list []config.Sample
+	if err := src.each(samples.Filter{}, func(s config.Sample) error {
+		list = append(list, s)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	copied := *run
+	result := *run.Result
+	result.Samples = list
+	copied.Result = &result
+	return &copied, nil
+}
+
+// RecomputeRun은 원시 샘플로 실행 결과를 다시 계산한 실행 기록 복사본을 반환
+// filter로 엔드포인트, 시간 구간, 응답 코드 등을 좁히면 그 부분만의 지표와 합격 여부를 계산합니다.
+func RecomputeRun(run *storage.TestRun, filter samples.Filter) (*storage.TestRun, error) {
+	src, err := openSamples(run)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	agg := loadtest.NewAggregator(src.header.StartedAt)
+	count := 0
+	if err := src.each(filter, func(s config.Sample) error {
+		agg.Add(s)
+		count++
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	elapsed := 0.0
+	if run.Result != nil {
+		elapsed = run.Result.ElapsedSec
+	}
+	result := agg.Result(filter.Window(elapsed), run.Request.Thresholds)
+	result.RecordedSamples = count
+	if run.Result != nil {
+		result.Interrupted = run.Result.Interrupted
+	}
+
+	// 조건이 바뀐 결과라 직전 실행과의 비교는 의미가 없으므로 제외
+	copied := *run
+	copied.Result = &result
+	copied.Comparison = nil
+	return &copied, nil
+}
+
+// HandleTestSamples는 실행의 원시 샘플을 조건에 맞게 내려주는 핸들러
+// GET /tests/{id}/samples?format=jsonl|csv&endpoint=GET /api&status=500&errorClass=timeout&failed=true&minLatencyMs=1000&from=10&to=20&limit=100
+func HandleTestSamples(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+	run, ok := loadRun(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	filter, err := samples.ParseFilter(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit := 0
+	if v := query.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			http.Error(w, "잘못된 limit 값", http.StatusBadRequest)
+			return
+		}
+	}
+
+	src, err := openSamples(run)
+	if errors.Is(err, samples.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("원시 샘플 읽기 실패: %v", err), http.StatusInternalServerError)
+		return
+	}
+	defer src.Close()
+
+	// 샘플이 많을 수 있어 버퍼링하지 않고 바로 흘려보냄
+	out := bufio.NewWriter(w)
+	var write func(config.Sample) error
+	var flush func() error
+	switch format := query.Get("format"); format {
+	case "", "jsonl":
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		enc := json.NewEncoder(out)
+		write = func(s config.Sample) error { return enc.Encode(s) }
+		flush = out.Flush
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		sw := export.NewSamplesCSV(out)
+		write = sw.Write
+		flush = func() error {
+			if err := sw.Flush(); err != nil {
+				return err
+			}
+			return out.Flush()
+		}
+	default:
+		http.Error(w, fmt.Sprintf("지원하지 않는 형식: %s (지원: jsonl, csv)", format), http.StatusBadRequest)
+		return
+	}
+
+	// limit에 도달하면 errLimit으로 읽기를 멈춤
+	count := 0
+	errLimit := errors.New("limit")
+	err = src.each(filter, func(s config.Sample) error {
+		if limit > 0 && count >= limit {
+			return errLimit
+		}
+		count++
+		return write(s)
+	})
+	if err != nil && !errors.Is(err, errLimit) {
+		// 이미 일부를 보냈으므로 상태 코드를 바꿀 수 없어 로그만 남김
+		logger.FromContext(r.Context()).Warnw("원시 샘플 읽기 실패", "id", run.ID, "error", err)
+	}
+	flush()
+}
+
+// HandleRecomputeTest는 원시 샘플로 지표를 다시 계산해 반환하는 핸들러
+// GET /tests/{id}/recompute?endpoint=...&from=10&to=20&format=json|junit|csv|prometheus|html
+func HandleRecomputeTest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+	run, ok := loadRun(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	filter, err := samples.ParseFilter(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	recomputed, err := RecomputeRun(run, filter)
+	if errors.Is(err, samples.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("재집계 실패: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if format := query.Get("format"); format == "html" {
+		writeReport(w, recomputed)
+	} else {
+		writeExport(w, format, recomputed)
+	}
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/auth"
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/modules/schedule"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// scheduler는 반복 테스트 스케줄러 (nil이면 스케줄 API 비활성화)
+var scheduler *schedule.Scheduler
+
+// SetScheduler는 스케줄 API가 사용할 스케줄러를 설정
+func SetScheduler(s *schedule.Scheduler) {
+	scheduler = s
+}
+
+// RunScheduled는 스케줄러가 예정 시각에 호출하는 실행 함수
+// 스케줄을 등록한 키의 사용량 한도를 적용하고, 결과는 같은 스케줄의 직전 실행과 항상 비교해 저장합니다.
+func RunScheduled(ctx context.Context, sc *schedule.Schedule) (string, error) {
+	req := sc.Request
+
+	release := func() {}
+	if authn != nil && sc.Owner != "" {
+		key, ok := authn.Lookup(sc.Owner)
+		if !ok {
+			return "", fmt.Errorf("스케줄을 등록한 API 키 %s 이(가) 더 이상 등록되어 있지 않습니다", sc.Owner)
+		}
+		var err error
+		if release, err = authn.Begin(key, auth.Cost(req)); err != nil {
+			return "", err
+		}
+	}
+	defer release()
+
+	run := &storage.TestRun{
+		ID:         storage.NewID(),
+		Kind:       storage.KindScheduled,
+		Owner:      sc.Owner,
+		ScheduleID: sc.ID,
+		Target:     req.Target,
+		StartedAt:  time.Now(),
+	}
+	req.ID = run.ID
+	req.Owner = run.Owner
+	run.Request = req
+
+	result, err := loadtest.RunLoadTestContext(ctx, req)
+	run.FinishedAt = time.Now()
+	if err != nil {
+		run.Error = err.Error()
+	} else {
+		run.Result = &result
+		// 자동 비교 설정과 관계없이 예약 실행은 항상 직전 회차와 비교
+		comparePrevious(run)
+	}
+	if store != nil {
+		if saveErr := store.Save(run); saveErr != nil && err == nil {
+			err = fmt.Errorf("실행 기록 저장 실패: %v", saveErr)
+		}
+	}
+	notifyRun(run)
+	return run.ID, err
+}
+
+// HandleSchedules는 스케줄 목록 조회(GET)와 등록(POST)을 처리하는 핸들러
+// GET /schedules?owner=team-a
+// POST /schedules {"name":"nightly-soak","cron":"0 3 * * *","timezone":"Asia/Seoul","jitterSec":600,"request":{...}}
+func HandleSchedules(w http.ResponseWriter, r *http.Request) {
+	if scheduler == nil {
+		http.Error(w, "스케줄 기능이 비활성화되어 있습니다 (저장소 필요)", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		schedules, err := scheduler.List()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("스케줄 조회 중 오류: %v", err), http.StatusInternalServerError)
+			return
+		}
+		// 인증이 켜져 있으면 owner와 관계없이 요청한 키의 스케줄만 반환
+		owner := r.URL.Query().Get("owner")
+		if o := ownerOf(r); o != "" {
+			owner = o
+		}
+		if owner != "" {
+			filtered := make([]*schedule.Schedule, 0, len(schedules))
+			for _, sc := range schedules {
+				if sc.Owner == owner {
+					filtered = append(filtered, sc)
+				}
+			}
+			schedules = filtered
+		}
+		writeJSON(w, http.StatusOK, schedules)
+
+	case http.MethodPost:
+		sc, ok := decodeSchedule(w, r)
+		if !ok {
+			return
+		}
+		sc.Owner = ownerOf(r)
+		if err := scheduler.Create(sc); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusCreated, sc)
+
+	default:
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleSchedule은 스케줄 하나의 조회(GET), 수정(PUT), 삭제(DELETE)를 처리하는 핸들러
+// GET|PUT|DELETE /schedules/{id}
+func HandleSchedule(w http.ResponseWriter, r *http.Request) {
+	if scheduler == nil {
+		http.Error(w, "스케줄 기능이 비활성화되어 있습니다 (저장소 필요)", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPut && r.Method != http.MethodDelete {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sc, ok := loadSchedule(w, r)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, sc)
+
+	case http.MethodPut:
+		updated, ok := decodeSchedule(w, r)
+		if !ok {
+			return
+		}
+		if err := scheduler.Update(sc.ID, updated); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusOK, updated)
+
+	case http.MethodDelete:
+		if err := scheduler.Delete(sc.ID); err != nil {
+			http.Error(w, fmt.Sprintf("스케줄 삭제 중 오류: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// HandleRunSchedule은 스케줄을 예정 시각과 관계없이 즉시 한 번 실행하는 핸들러
+// POST /schedules/{id}/run (실행은 비동기, 결과는 GET /tests?schedule={id} 로 확인)
+func HandleRunSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+	if scheduler == nil {
+		http.Error(w, "스케줄 기능이 비활성화되어 있습니다 (저장소 필요)", http.StatusServiceUnavailable)
+		return
+	}
+
+	sc, ok := loadSchedule(w, r)
+	if !ok {
+		return
+	}
+	if err := scheduler.Trigger(sc.ID); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]string{"id": sc.ID, "status": "started"})
+}
+
+// loadSchedule은 경로의 ID로 스케줄을 조회하고, 요청한 키의 스케줄이 아니면 403으로 응답
+func loadSchedule(w http.ResponseWriter, r *http.Request) (*schedule.Schedule, bool) {
+	sc, err := scheduler.Get(r.PathValue("id"))
+	if errors.Is(err, schedule.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("스케줄 조회 중 오류: %v", err), http.StatusInternalServerError)
+		return nil, false
+	}
+	if owner := ownerOf(r); owner != "" && sc.Owner != owner {
+		http.Error(w, "다른 API 키가 등록한 스케줄입니다", http.StatusForbidden)
+		return nil, false
+	}
+	return sc, true
+}
+
+// decodeSchedule은 요청 본문의 스케줄 설정을 파싱하고 안전 정책을 검사
+// 정책을 통과하지 못한 스케줄은 등록해도 실행 시점에 거부되므로 미리 막습니다.
+func decodeSchedule(w http.ResponseWriter, r *http.Request) (*schedule.Schedule, bool) {
+	var sc schedule.Schedule
+	if err := json.NewDecoder(r.Body).Decode(&sc); err != nil {
+		http.Error(w, "잘못된 요청 형식", http.StatusBadRequest)
+		return nil, false
+	}
+	if err := sc.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	if !checkSafety(w, r, sc.Request) {
+		return nil, false
+	}
+	return &sc, true
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/schedule"
+)
+
+func TestSchedulesAreScopedToOwner(t *testing.T) {
+	st, err := schedule.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := schedule.New(st, func(*schedule.Schedule) (string, error) { return "", nil })
+	SetScheduler(s)
+	defer SetScheduler(nil)
+
+	for _, owner := range []string{"team-a", "team-b"} {
+		err := s.Create(&schedule.Schedule{
+			Name:    owner + "-nightly",
+			Cron:    "@daily",
+			Owner:   owner,
+			Request: config.TestRequest{Target: "https://example.com", RPS: 1, Duration: 1},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list := func(r *http.Request) []schedule.Schedule {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		HandleSchedules(rec, r)
+		var schedules []schedule.Schedule
+		if err := json.NewDecoder(rec.Body).Decode(&schedules); err != nil {
+			t.Fatal(err)
+		}
+		return schedules
+	}
+
+	// owner를 생략하거나 다른 키를 지정해도 자기 스케줄만 반환
+	for _, path := range []string{"/schedules", "/schedules?owner=team-b"} {
+		got := list(asKey(httptest.NewRequest(http.MethodGet, path, nil), "team-a"))
+		if len(got) != 1 || got[0].Owner != "team-a" {
+			t.Errorf("GET %s as team-a = %+v", path, got)
+		}
+	}
+
+	// 인증이 꺼져 있으면 owner로 거르거나 전체 반환
+	if got := list(httptest.NewRequest(http.MethodGet, "/schedules", nil)); len(got) != 2 {
+		t.Errorf("GET /schedules without auth = %d schedules, want 2", len(got))
+	}
+	if got := list(httptest.NewRequest(http.MethodGet, "/schedules?owner=team-b", nil)); len(got) != 1 || got[0].Owner != "team-b" {
+		t.Errorf("GET /schedules?owner=team-b without auth = %+v", got)
+	}
+}
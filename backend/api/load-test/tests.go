@@ -0,0 +1,202 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/export"
+	"github.com/Mr-Muji/LoadTest/backend/modules/report"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// HandleListTests는 저장된 테스트 실행 기록 목록을 반환하는 핸들러
+// GET /tests?target=https://example.com&schedule=sch-...&from=2025-04-01&to=2025-04-08&limit=50
+// 인증이 켜져 있으면 요청한 키가 실행한 기록만 반환합니다 (owner 파라미터는 인증이 꺼져 있을 때만 사용).
+func HandleListTests(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+	if store == nil {
+		http.Error(w, "저장소가 설정되지 않았습니다", http.StatusServiceUnavailable)
+		return
+	}
+
+	// 조회 조건 파싱
+	query := r.URL.Query()
+	filter := storage.Filter{Target: query.Get("target"), Owner: query.Get("owner"), Schedule: query.Get("schedule")}
+	if owner := ownerOf(r); owner != "" {
+		filter.Owner = owner
+	}
+
+	var err error
+	if filter.From, err = parseTime(query.Get("from"), false); err != nil {
+		http.Error(w, fmt.Sprintf("잘못된 from 값: %v", err), http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = parseTime(query.Get("to"), true); err != nil {
+		http.Error(w, fmt.Sprintf("잘못된 to 값: %v", err), http.StatusBadRequest)
+		return
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 0 {
+			http.Error(w, "잘못된 limit 값", http.StatusBadRequest)
+			return
+		}
+	}
+
+	runs, err := store.List(filter)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("실행 기록 조회 중 오류: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, runs)
+}
+
+// HandleGetTest는 ID로 하나의 테스트 실행 기록을 반환하는 핸들러
+// GET /tests/{id}?format=json|junit|csv|csv-samples|prometheus
+func HandleGetTest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+
+	run, ok := loadRun(w, r)
+	if !ok {
+		return
+	}
+
+	writeExport(w, r.URL.Query().Get("format"), run)
+}
+
+// writeExport는 실행 기록을 요청한 형식으로 응답에 작성
+func writeExport(w http.ResponseWriter, format string, run *storage.TestRun) {
+	// 원시 샘플이 샘플 파일에 있으면 읽어서 채움
+	if format == export.FormatCSVSamples {
+		withFile, err := WithSamples(run)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		run = withFile
+	}
+
+	// 변환 오류 시 일부만 전송되지 않도록 버퍼에 먼저 작성
+	var buf bytes.Buffer
+	if err := export.Write(&buf, format, run); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// GetRun은 저장소에서 실행 기록 하나를 조회 (CLI용)
+func GetRun(id string) (*storage.TestRun, error) {
+	if store == nil {
+		return nil, fmt.Errorf("저장소가 설정되지 않았습니다")
+	}
+	return store.Get(id)
+}
+
+// errOtherOwner는 다른 API 키가 실행한 기록에 접근한 경우의 오류 (403)
+var errOtherOwner = errors.New("다른 API 키가 실행한 기록입니다")
+
+// loadRun은 경로의 {id} 실행 기록을 읽고, 실패 시 오류 응답을 작성
+// 인증이 켜져 있으면 요청한 키가 실행한 기록만 반환합니다.
+func loadRun(w http.ResponseWriter, r *http.Request) (*storage.TestRun, bool) {
+	if store == nil {
+		http.Error(w, "저장소가 설정되지 않았습니다", http.StatusServiceUnavailable)
+		return nil, false
+	}
+
+	run, err := getOwnedRun(ownerOf(r), r.PathValue("id"))
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(w, "테스트 기록을 찾을 수 없습니다", http.StatusNotFound)
+		return nil, false
+	}
+	if errors.Is(err, errOtherOwner) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return nil, false
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("실행 기록 조회 중 오류: %v", err), http.StatusInternalServerError)
+		return nil, false
+	}
+	return run, true
+}
+
+// getOwnedRun은 실행 기록을 읽고 owner가 있으면 그 키가 실행한 기록인지 확인
+func getOwnedRun(owner, id string) (*storage.TestRun, error) {
+	run, err := store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if owner != "" && run.Owner != owner {
+		return nil, errOtherOwner
+	}
+	return run, nil
+}
+
+// parseTime은 RFC3339 또는 날짜(2006-01-02) 형식의 시각을 파싱
+// 날짜만 주어진 경우 endOfDay가 true면 그날의 끝으로 맞춤
+func parseTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("RFC3339 또는 YYYY-MM-DD 형식이어야 합니다")
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
+// writeJSON은 JSON 응답을 작성
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// HandleTestReport는 실행 기록을 단일 HTML 보고서로 반환하는 핸들러
+// GET /tests/{id}/report
+func HandleTestReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+
+	run, ok := loadRun(w, r)
+	if !ok {
+		return
+	}
+	writeReport(w, run)
+}
+
+// writeReport는 실행 기록을 HTML 보고서로 응답에 작성
+func writeReport(w http.ResponseWriter, run *storage.TestRun) {
+	// 렌더링 오류 시 일부만 전송되지 않도록 버퍼에 먼저 작성
+	var buf bytes.Buffer
+	if err := report.Render(&buf, run); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/auth"
+	"github.com/Mr-Muji/LoadTest/backend/modules/compare"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// useTestStore는 테스트 동안 임시 디렉토리의 파일 저장소를 사용
+func useTestStore(t *testing.T) storage.Store {
+	t.Helper()
+	s, err := storage.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := store
+	store = s
+	t.Cleanup(func() { store = prev })
+	return s
+}
+
+// asKey는 인증 미들웨어를 거친 것처럼 키를 담은 요청을 만듦
+func asKey(r *http.Request, name string) *http.Request {
+	return r.WithContext(auth.WithKey(r.Context(), &auth.Key{Name: name}))
+}
+
+func TestTestsAreScopedToOwner(t *testing.T) {
+	s := useTestStore(t)
+	now := time.Now()
+	for i, owner := range []string{"team-a", "team-b", "team-a"} {
+		err := s.Save(&storage.TestRun{
+			ID:        fmt.Sprintf("run-%d", i+1),
+			Owner:     owner,
+			Target:    "http://example.com",
+			StartedAt: now.Add(time.Duration(i) * time.Second),
+			Result:    &config.TestResult{},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// owner 파라미터로 다른 키의 기록을 요청해도 자기 기록만 반환
+	rec := httptest.NewRecorder()
+	HandleListTests(rec, asKey(httptest.NewRequest(http.MethodGet, "/tests?owner=team-b", nil), "team-a"))
+	var runs []storage.TestRun
+	if err := json.NewDecoder(rec.Body).Decode(&runs); err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("team-a listed %d runs, want 2", len(runs))
+	}
+	for _, run := range runs {
+		if run.Owner != "team-a" {
+			t.Errorf("team-a listed run %s owned by %s", run.ID, run.Owner)
+		}
+	}
+
+	get := func(id, owner string) int {
+		req := httptest.NewRequest(http.MethodGet, "/tests/"+id, nil)
+		req.SetPathValue("id", id)
+		if owner != "" {
+			req = asKey(req, owner)
+		}
+		rec := httptest.NewRecorder()
+		HandleGetTest(rec, req)
+		return rec.Code
+	}
+	if code := get("run-1", "team-a"); code != http.StatusOK {
+		t.Errorf("owner GET = %d, want 200", code)
+	}
+	if code := get("run-2", "team-a"); code != http.StatusForbidden {
+		t.Errorf("other key GET = %d, want 403", code)
+	}
+	if code := get("run-2", ""); code != http.StatusOK {
+		t.Errorf("GET without auth = %d, want 200", code)
+	}
+
+	if _, err := CompareRuns("team-a", "run-2", "run-3", compare.DefaultTolerances(), true); err == nil {
+		t.Error("CompareRuns with another key's base run succeeded")
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	api "github.com/Mr-Muji/LoadTest/backend/api/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/modules/compare"
+	"github.com/Mr-Muji/LoadTest/backend/modules/distributed"
+	"github.com/Mr-Muji/LoadTest/backend/modules/export"
+	"github.com/Mr-Muji/LoadTest/backend/modules/samples"
+)
+
+// runCLI는 서버 대신 실행할 CLI 명령을 처리하고 종료 코드를 반환
+func runCLI(args []string) int {
+	switch args[0] {
+	case "compare":
+		return runCompare(args[1:])
+	case "export":
+		return runExport(args[1:])
+	case "agent":
+		return runAgent(args[1:])
+	case "help", "-h", "--help":
+		printUsage()
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "알 수 없는 명령: %s\n\n", args[0])
+		printUsage()
+		return 2
+	}
+}
+
+// printUsage는 CLI 사용법을 출력
+func printUsage() {
+	fmt.Fprintln(os.Stderr, `사용법:
+  go run . [서버 옵션]     서버 실행 (-listen :9090, -config config.json 등)
+  go run . compare [옵션]  저장된 두 실행 결과 비교 (회귀가 있으면 종료 코드 1)
+  go run . export [옵션]   저장된 실행 결과를 JUnit/CSV/Prometheus 형식으로 출력
+  go run . agent [옵션]    분산 실행 에이전트로 동작 (코디네이터에 자동 등록)
+
+서버 옵션은 위 목록을, 각 명령의 옵션은 go run . <명령> -h 로 확인하세요.`)
+}
+
+// runCompare는 두 실행 결과를 비교하여 출력
+// 회귀가 감지되면 종료 코드 1을 반환하므로 CI에서 그대로 사용할 수 있습니다.
+func runCompare(args []string) int {
+	defaults := compare.DefaultTolerances()
+
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	baseID := fs.String("base", "", "기준 실행 ID (생략 시 같은 대상의 직전 실행)")
+	candidateID := fs.String("candidate", "", "비교할 실행 ID (필수)")
+	latencyPct := fs.Float64("latency-pct", defaults.LatencyPct, "응답 시간 허용 증가율 (%)")
+	latencyMinMs := fs.Float64("latency-min-ms", defaults.LatencyMinMs, "응답 시간 허용 증가량 (ms)")
+	errorRatePts := fs.Float64("error-rate-pts", defaults.ErrorRatePts, "오류율 허용 증가폭 (%p)")
+	throughputPct := fs.Float64("throughput-pct", defaults.ThroughputPct, "처리량 허용 감소율 (%)")
+	force := fs.Bool("force", false, "종류나 부하 설정이 다른 실행도 비교")
+	asJSON := fs.Bool("json", false, "JSON 형식으로 출력")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *candidateID == "" {
+		fmt.Fprintln(os.Stderr, "-candidate 옵션이 필요합니다")
+		fs.Usage()
+		return 2
+	}
+
+	result, err := api.CompareRuns("", *baseID, *candidateID, compare.Tolerances{
+		LatencyPct:    *latencyPct,
+		LatencyMinMs:  *latencyMinMs,
+		ErrorRatePts:  *errorRatePts,
+		ThroughputPct: *throughputPct,
+	}, *force)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "비교 실패: %v\n", err)
+		return 2
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(result)
+	} else {
+		result.WriteText(os.Stdout)
+	}
+
+	if result.Regressed {
+		return 1
+	}
+	return 0
+}
+
+// runExport는 저장된 실행 결과를 지정한 형식으로 출력
+// JUnit 형식에서 합격 기준을 통과하지 못하면 종료 코드 1을 반환합니다.
+func runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	id := fs.String("id", "", "실행 ID (필수)")
+	format := fs.String("output", export.FormatJSON, "출력 형식 ("+strings.Join(export.Formats, ", ")+")")
+	file := fs.String("file", "", "출력 파일 경로 (생략 시 표준 출력)")
+	recompute := fs.Bool("recompute", false, "저장된 원시 샘플로 지표를 다시 계산해 출력")
+	endpoint := fs.String("endpoint", "", "재계산할 엔드포인트 (예: \"GET /api/users\", -recompute와 함께)")
+	from := fs.Float64("from", 0, "재계산할 구간 시작 (측정 시작 후 초, -recompute와 함께)")
+	to := fs.Float64("to", 0, "재계산할 구간 끝 (측정 시작 후 초, 0이면 끝까지, -recompute와 함께)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *id == "" {
+		fmt.Fprintln(os.Stderr, "-id 옵션이 필요합니다")
+		fs.Usage()
+		return 2
+	}
+
+	run, err := api.GetRun(*id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "실행 기록 조회 실패: %v\n", err)
+		return 2
+	}
+	if *recompute {
+		run, err = api.RecomputeRun(run, samples.Filter{Endpoint: *endpoint, FromSec: *from, ToSec: *to})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "재계산 실패: %v\n", err)
+			return 2
+		}
+	}
+	if *format == export.FormatCSVSamples {
+		if run, err = api.WithSamples(run); err != nil {
+			fmt.Fprintf(os.Stderr, "내보내기 실패: %v\n", err)
+			return 2
+		}
+	}
+
+	out := os.Stdout
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "출력 파일 생성 실패: %v\n", err)
+			return 2
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := export.Write(out, *format, run); err != nil {
+		fmt.Fprintf(os.Stderr, "내보내기 실패: %v\n", err)
+		return 2
+	}
+
+	// 실행 오류로 결과가 없는 기록도 CI에서 실패로 처리
+	if *format == export.FormatJUnit && !export.Passed(run) {
+		return 1
+	}
+	return 0
+}
+
+// runAgent는 분산 실행 에이전트 서버를 실행
+func runAgent(args []string) int {
+	hostname, _ := os.Hostname()
+
+	fs := flag.NewFlagSet("agent", flag.ContinueOnError)
+	listen := fs.String("listen", ":9091", "에이전트 수신 주소")
+	advertise := fs.String("advertise", "", "코디네이터가 접근할 에이전트 주소 (기본값 http://localhost<listen>)")
+	coordinatorURL := fs.String("coordinator", "http://localhost:8080", "코디네이터 주소")
+	id := fs.String("id", "", "에이전트 ID (기본값 호스트명-포트)")
+	apiKey := fs.String("api-key", os.Getenv("AGENT_API_KEY"), "코디네이터 등록에 사용할 API 키 (기본값 AGENT_API_KEY)")
+	token := fs.String("token", os.Getenv("AGENT_TOKEN"), "코디네이터와 공유하는 에이전트 토큰 (기본값 AGENT_TOKEN, 필수)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	// 토큰 없이 실행하면 주소를 아는 누구나 부하 생성기로 쓸 수 있으므로 거부
+	if *token == "" {
+		fmt.Fprintln(os.Stderr, "-token 옵션 또는 AGENT_TOKEN 환경변수가 필요합니다 (코디네이터의 AGENT_TOKEN과 같은 값)")
+		return 2
+	}
+
+	_, port, err := net.SplitHostPort(*listen)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "잘못된 listen 주소: %v\n", err)
+		return 2
+	}
+	if *advertise == "" {
+		*advertise = "http://localhost:" + port
+	}
+	if *id == "" {
+		*id = hostname + "-" + port
+	}
+
+	agent := &distributed.Agent{
+		ID:             *id,
+		AdvertiseURL:   *advertise,
+		CoordinatorURL: strings.TrimRight(*coordinatorURL, "/"),
+		APIKey:         *apiKey,
+		Token:          *token,
+		Log:            log,
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go agent.Heartbeat(stop)
+
+	log.Infow("에이전트 실행 중", "id", agent.ID, "listen", *listen, "advertise", agent.AdvertiseURL)
+	if err := serve(&http.Server{Addr: *listen, Handler: agent.Handler()}, "", ""); err != nil {
+		log.Errorw("에이전트 실행 실패", "error", err)
+		return 1
+	}
+	return 0
+}
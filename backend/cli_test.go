@@ -0,0 +1,41 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	api "github.com/Mr-Muji/LoadTest/backend/api/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+func TestExportJUnitExitCode(t *testing.T) {
+	s, err := storage.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	api.SetStore(s)
+	defer api.SetStore(nil)
+
+	passing := config.TestResult{Thresholds: []config.ThresholdVerdict{{Threshold: config.Threshold{Metric: "p95", Operator: "<", Value: 500}, Actual: 100, Passed: true}}}
+	failing := config.TestResult{Thresholds: []config.ThresholdVerdict{{Threshold: config.Threshold{Metric: "p95", Operator: "<", Value: 500}, Actual: 900}}}
+	for _, run := range []*storage.TestRun{
+		{ID: "passed", Result: &passing},
+		{ID: "failed", Result: &failing},
+		// 실행 중 오류로 결과 없이 저장된 기록
+		{ID: "errored", Error: "대상에 연결할 수 없습니다"},
+		{ID: "errored-with-result", Error: "중간에 중단됨", Result: &passing},
+	} {
+		run.Kind, run.Target = storage.KindBasic, "https://example.com"
+		if err := s.Save(run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for id, want := range map[string]int{"passed": 0, "failed": 1, "errored": 1, "errored-with-result": 1, "missing": 2} {
+		file := filepath.Join(t.TempDir(), "report.xml")
+		if got := runExport([]string{"-id", id, "-output", "junit", "-file", file}); got != want {
+			t.Errorf("export -id %s -output junit = %d, want %d", id, got, want)
+		}
+	}
+}
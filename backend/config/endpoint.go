@@ -0,0 +1,23 @@
+package config
+
+// Endpoint는 탐색 과정에서 발견한 요청 대상 하나
+type Endpoint struct {
+	Method              string              `json:"method"`                        // HTTP 메서드
+	URL                 string              `json:"url"`                           // 쿼리를 제외한 전체 URL (예: https://example.com/api/users)
+	Path                string              `json:"path"`                          // URL 경로 또는 템플릿 (예: /api/users/{id})
+	PathParams          []map[string]string `json:"pathParams,omitempty"`          // 경로 하나에서 함께 관찰된 템플릿 파라미터 값 묶음 (예: [{id: 123, id2: 7}, {id: 456, id2: 9}])
+	Variants            int                 `json:"variants,omitempty"`            // 템플릿으로 묶인 실제 경로 수
+	QueryParams         []string            `json:"queryParams,omitempty"`         // 관찰된 쿼리 파라미터 이름
+	FormFields          []string            `json:"formFields,omitempty"`          // 폼으로 전송되는 필드 이름
+	Headers             map[string]string   `json:"headers,omitempty"`             // 관찰된 요청 헤더 (쿠키/인증 헤더 제외)
+	RequestContentType  string              `json:"requestContentType,omitempty"`  // 요청 본문 Content-Type
+	ResponseContentType string              `json:"responseContentType,omitempty"` // 응답 Content-Type
+	SampleBodies        []string            `json:"sampleBodies,omitempty"`        // 관찰된 요청 본문 예시
+	Source              string              `json:"source,omitempty"`              // 발견 경로 (link, form, script, fetch, xhr 등)
+	Page                string              `json:"page,omitempty"`                // 발견된 페이지 URL
+}
+
+// Key는 중복 제거에 사용하는 "METHOD URL" 키
+func (e Endpoint) Key() string {
+	return e.Method + " " + e.URL
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"math"
+	"sort"
+)
+
+// 히스토그램 버킷 설정
+// 1ms부터 10%씩 증가하는 로그 스케일 버킷을 사용하므로 분위수 오차는 최대 약 10%입니다.
+// 버킷 경계가 고정되어 있어 여러 결과의 히스토그램을 그대로 합칠 수 있습니다.
+const (
+	histogramMinMs  = 1.0
+	histogramGrowth = 1.1
+)
+
+// HistogramBucket은 상한값(UpperMs) 이하의 응답 개수를 담는 버킷
+type HistogramBucket struct {
+	UpperMs float64 `json:"upperMs"` // 버킷 상한 (ms)
+	Count   int     `json:"count"`   // 해당 버킷에 속한 요청 수
+}
+
+// Histogram은 응답 시간 분포를 저장하는 구조체 (비어 있지 않은 버킷만 저장)
+type Histogram struct {
+	Buckets []HistogramBucket `json:"buckets"`         // 상한 오름차순 버킷
+	Count   int               `json:"count"`           // 전체 샘플 수
+	MinMs   float64           `json:"minMs,omitempty"` // 기록된 최소 응답 시간 (분위수 범위 제한)
+	MaxMs   float64           `json:"maxMs,omitempty"` // 기록된 최대 응답 시간
+}
+
+// bucketIndex는 응답 시간이 속하는 버킷 번호를 계산
+func bucketIndex(latencyMs float64) int {
+	if latencyMs <= histogramMinMs {
+		return 0
+	}
+	return int(math.Ceil(math.Log(latencyMs/histogramMinMs) / math.Log(histogramGrowth)))
+}
+
+// bucketUpper는 버킷 번호의 상한값을 계산
+func bucketUpper(index int) float64 {
+	// 부동소수점 오차를 줄이기 위해 마이크로초 단위로 반올림
+	return math.Round(histogramMinMs*math.Pow(histogramGrowth, float64(index))*1000) / 1000
+}
+
+// bucketLower는 상한값이 upper인 버킷의 하한 (이전 버킷의 상한, 첫 버킷은 0)
+func bucketLower(upper float64) float64 {
+	if upper <= histogramMinMs {
+		return 0
+	}
+	// 상한은 반올림된 값이므로 로그를 반올림해 버킷 번호를 되찾음
+	index := int(math.Round(math.Log(upper/histogramMinMs) / math.Log(histogramGrowth)))
+	return bucketUpper(index - 1)
+}
+
+// Add는 응답 시간 하나를 히스토그램에 추가
+func (h *Histogram) Add(latencyMs float64) {
+	h.observe(latencyMs, latencyMs)
+	h.addCount(bucketUpper(bucketIndex(latencyMs)), 1)
+}
+
+// Merge는 다른 히스토그램의 버킷을 더함
+func (h *Histogram) Merge(other Histogram) {
+	if other.Count > 0 && other.MaxMs > 0 {
+		h.observe(other.MinMs, other.MaxMs)
+	}
+	for _, b := range other.Buckets {
+		h.addCount(b.UpperMs, b.Count)
+	}
+}
+
+// observe는 기록된 최소/최대 응답 시간을 갱신
+func (h *Histogram) observe(minMs, maxMs float64) {
+	if h.Count == 0 || minMs < h.MinMs {
+		h.MinMs = minMs
+	}
+	if maxMs > h.MaxMs {
+		h.MaxMs = maxMs
+	}
+}
+
+// addCount는 상한값이 upper인 버킷에 count를 더함
+func (h *Histogram) addCount(upper float64, count int) {
+	h.Count += count
+	i := sort.Search(len(h.Buckets), func(i int) bool { return h.Buckets[i].UpperMs >= upper })
+	if i < len(h.Buckets) && h.Buckets[i].UpperMs == upper {
+		h.Buckets[i].Count += count
+		return
+	}
+	h.Buckets = append(h.Buckets, HistogramBucket{})
+	copy(h.Buckets[i+1:], h.Buckets[i:])
+	h.Buckets[i] = HistogramBucket{UpperMs: upper, Count: count}
+}
+
+// Quantile은 q(0~1) 분위수에 해당하는 응답 시간을 버킷 내 선형 보간으로 추정
+// 보간은 버킷의 실제 하한~상한 사이에서 하고, 결과는 기록된 최소~최대 범위로 제한합니다.
+func (h *Histogram) Quantile(q float64) float64 {
+	if h.Count == 0 || len(h.Buckets) == 0 {
+		return 0
+	}
+	rank := q * float64(h.Count)
+	cumulative := 0
+	value := h.Buckets[len(h.Buckets)-1].UpperMs
+	for _, b := range h.Buckets {
+		if float64(cumulative+b.Count) >= rank {
+			lower := bucketLower(b.UpperMs)
+			fraction := (rank - float64(cumulative)) / float64(b.Count)
+			value = lower + (b.UpperMs-lower)*fraction
+			break
+		}
+		cumulative += b.Count
+	}
+	return h.clamp(value)
+}
+
+// clamp는 값을 기록된 최소~최대 범위로 제한 (범위를 기록하지 않은 예전 결과는 그대로)
+func (h *Histogram) clamp(v float64) float64 {
+	if h.MaxMs <= 0 {
+		return v
+	}
+	if v < h.MinMs {
+		return h.MinMs
+	}
+	if v > h.MaxMs {
+		return h.MaxMs
+	}
+	return v
+}
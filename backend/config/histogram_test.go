@@ -0,0 +1,89 @@
+package config
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHistogramQuantileConstant(t *testing.T) {
+	var h Histogram
+	for i := 0; i < 1000; i++ {
+		h.Add(100)
+	}
+	for _, q := range []float64{0.01, 0.5, 0.9, 0.99, 1} {
+		if got := h.Quantile(q); got != 100 {
+			t.Errorf("Quantile(%v) = %v, want 100", q, got)
+		}
+	}
+}
+
+func TestHistogramQuantileWithinRange(t *testing.T) {
+	var h Histogram
+	for i := 0; i < 1000; i++ {
+		h.Add(200 + float64(i%10))
+	}
+	for _, q := range []float64{0.5, 0.95, 0.99} {
+		got := h.Quantile(q)
+		if got < 200 || got > 209 {
+			t.Errorf("Quantile(%v) = %v, want within [200, 209]", q, got)
+		}
+	}
+}
+
+func TestHistogramQuantileError(t *testing.T) {
+	// 1~1000ms 균등 분포: 분위수 오차는 버킷 폭(10%) 이내여야 함
+	var h Histogram
+	for i := 1; i <= 1000; i++ {
+		h.Add(float64(i))
+	}
+	for _, tc := range []struct {
+		q    float64
+		want float64
+	}{
+		{0.5, 500},
+		{0.9, 900},
+		{0.99, 990},
+	} {
+		got := h.Quantile(tc.q)
+		if math.Abs(got-tc.want)/tc.want > 0.1 {
+			t.Errorf("Quantile(%v) = %v, want %v ±10%%", tc.q, got, tc.want)
+		}
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	var a, b, all Histogram
+	for i := 1; i <= 500; i++ {
+		a.Add(float64(i))
+		all.Add(float64(i))
+	}
+	for i := 501; i <= 1000; i++ {
+		b.Add(float64(i))
+		all.Add(float64(i))
+	}
+	a.Merge(b)
+	if a.Count != all.Count || a.MinMs != 1 || a.MaxMs != 1000 {
+		t.Fatalf("merged = count %d min %v max %v, want count %d min 1 max 1000", a.Count, a.MinMs, a.MaxMs, all.Count)
+	}
+	for _, q := range []float64{0.5, 0.95, 0.99} {
+		if got, want := a.Quantile(q), all.Quantile(q); got != want {
+			t.Errorf("merged Quantile(%v) = %v, want %v", q, got, want)
+		}
+	}
+}
+
+func TestHistogramQuantileWithoutRange(t *testing.T) {
+	// 최소/최대를 기록하지 않은 예전 결과도 버킷 안에서 보간
+	h := Histogram{Buckets: []HistogramBucket{{UpperMs: bucketUpper(bucketIndex(100)), Count: 10}}, Count: 10}
+	got := h.Quantile(0.5)
+	if lower := bucketLower(h.Buckets[0].UpperMs); got < lower || got > h.Buckets[0].UpperMs {
+		t.Errorf("Quantile(0.5) = %v, want within bucket [%v, %v]", got, lower, h.Buckets[0].UpperMs)
+	}
+}
+
+func TestHistogramEmpty(t *testing.T) {
+	var h Histogram
+	if got := h.Quantile(0.5); got != 0 {
+		t.Errorf("empty Quantile = %v, want 0", got)
+	}
+}
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// setting은 ServerConfig 필드 하나와 그 필드를 바꾸는 플래그/환경변수 이름
+type setting struct {
+	flag  string      // 명령줄 플래그 이름 (-listen)
+	env   string      // 환경변수 이름 (LISTEN_ADDR)
+	usage string      // 설명
+	value interface{} // *string, *int, *bool, *[]string
+}
+
+// settings는 ServerConfig의 모든 설정 항목 (플래그/환경변수/도움말을 한곳에서 관리)
+func (c *ServerConfig) settings() []setting {
+	return []setting{
+		{"listen", "LISTEN_ADDR", "수신 주소", &c.Listen},
+		{"public-url", "PUBLIC_URL", "외부에서 접근하는 서버 주소 (알림 링크)", &c.PublicURL},
+		{"tls-cert", "TLS_CERT_FILE", "TLS 인증서 파일", &c.TLSCertFile},
+		{"tls-key", "TLS_KEY_FILE", "TLS 개인 키 파일", &c.TLSKeyFile},
+		{"cors-origins", "CORS_ORIGINS", "허용할 Origin (쉼표 구분, * 는 전체)", &c.CORSOrigins},
+		{"read-header-timeout", "READ_HEADER_TIMEOUT", "요청 헤더 읽기 제한(초)", &c.ReadHeaderTimeout},
+		{"idle-timeout", "IDLE_TIMEOUT", "유휴 연결 유지 시간(초)", &c.IdleTimeout},
+		{"shutdown-timeout", "SHUTDOWN_TIMEOUT", "종료 시 테스트를 기다리는 시간(초)", &c.ShutdownTimeout},
+		{"storage-dir", "STORAGE_DIR", "실행 기록 저장 디렉토리", &c.StorageDir},
+		{"log-dir", "LOG_DIR", "로그 디렉토리 (기본: 실행 파일 옆 logs)", &c.LogDir},
+		{"log-level", "LOG_LEVEL", "최소 로그 레벨 (debug, info, warn, error)", &c.LogLevel},
+		{"log-format", "LOG_FORMAT", "로그 형식 (console, json)", &c.LogFormat},
+		{"log-max-size", "LOG_MAX_SIZE_MB", "로그 파일 교체 크기(MB, 0이면 날짜로만 교체)", &c.LogMaxSizeMB},
+		{"log-max-age", "LOG_MAX_AGE_DAYS", "교체된 로그 파일 보관 기간(일, 0이면 지우지 않음)", &c.LogMaxAgeDays},
+		{"log-max-backups", "LOG_MAX_BACKUPS", "보관할 교체 로그 파일 수 (0이면 제한 없음)", &c.LogMaxBackups},
+		{"max-concurrent-runs", "MAX_CONCURRENT_RUNS", "동시에 실행할 최대 테스트 수", &c.MaxConcurrentRuns},
+		{"max-total-rps", "MAX_TOTAL_RPS", "실행 중인 테스트 RPS 합계 한도", &c.MaxTotalRPS},
+		{"max-queue-length", "MAX_QUEUE_LENGTH", "대기열 최대 길이", &c.MaxQueueLength},
+		{"queue-reject", "QUEUE_REJECT", "대기하지 않고 바로 거부", &c.QueueReject},
+		{"scraper", "SCRAPER", "기본 탐색 방식 (crawler, node)", &c.Scraper},
+		{"scraper-command", "SCRAPER_COMMAND", "스크래퍼 실행 명령", &c.ScraperCommand},
+		{"scraper-script", "SCRAPER_SCRIPT", "Node.js 스크래퍼 스크립트 경로", &c.ScraperScript},
+		{"scraper-timeout", "SCRAPER_TIMEOUT", "스크래퍼 실행 제한 시간(초)", &c.ScraperTimeout},
+		{"crawl-max-depth", "CRAWL_MAX_DEPTH", "내장 크롤러 최대 깊이", &c.CrawlMaxDepth},
+		{"crawl-max-pages", "CRAWL_MAX_PAGES", "내장 크롤러 최대 페이지 수", &c.CrawlMaxPages},
+		{"crawl-ignore-robots", "CRAWL_IGNORE_ROBOTS", "robots.txt 무시", &c.CrawlIgnoreRobots},
+		{"source-dir", "SOURCE_DIR", "탐색/재생 소스 파일을 읽을 디렉토리 (빈 값이면 서버 파일 읽기 금지)", &c.SourceDir},
+		{"default-rps", "DEFAULT_RPS", "/test 의 초당 요청 수", &c.DefaultRPS},
+		{"default-duration", "DEFAULT_DURATION", "/test 의 실행 시간(초)", &c.DefaultDuration},
+		{"auto-test-rps", "AUTO_TEST_RPS", "자동 테스트 1단계의 초당 요청 수", &c.AutoTestRPS},
+		{"auto-test-duration", "AUTO_TEST_DURATION", "자동 테스트 1단계의 실행 시간(초)", &c.AutoTestDuration},
+		{"request-timeout", "REQUEST_TIMEOUT", "요청 하나의 기본 제한 시간(초)", &c.RequestTimeout},
+		{"ai-provider", "AI_PROVIDER", "경로 분석 제공자 (openai, azure, mock)", &c.AIProvider},
+		{"ai-base-url", "AI_BASE_URL", "OpenAI 호환 API 주소 또는 Azure 리소스 주소", &c.AIBaseURL},
+		{"ai-api-version", "AI_API_VERSION", "Azure OpenAI API 버전", &c.AIAPIVersion},
+		{"openai-model", "OPENAI_MODEL", "경로 분석에 사용할 모델 (Azure는 배포 이름)", &c.OpenAIModel},
+		{"ai-response-format", "AI_RESPONSE_FORMAT", "경로 분석 응답 형식 (json_schema, json_object)", &c.AIResponseFormat},
+		{"ai-max-attempts", "AI_MAX_ATTEMPTS", "분석 결과가 유효하지 않을 때 다시 요청하는 것을 포함한 최대 호출 횟수", &c.AIMaxAttempts},
+		{"agent-token", "AGENT_TOKEN", "분산 실행 에이전트와 공유하는 토큰", &c.AgentToken},
+		{"api-keys", "API_KEYS", "API 키 목록 (이름:키[:최대동시실행[:일일예산[:최대우선순위]]], 쉼표 구분)", &c.APIKeys},
+		{"api-key-max-concurrent", "API_KEY_MAX_CONCURRENT", "키에 적지 않은 경우의 최대 동시 실행 수", &c.APIKeyMaxConcurrent},
+		{"api-key-daily-budget", "API_KEY_DAILY_BUDGET", "키에 적지 않은 경우의 일일 요청 예산 (RPS × 초)", &c.APIKeyDailyBudget},
+		{"api-key-max-priority", "API_KEY_MAX_PRIORITY", "키에 적지 않은 경우의 최대 대기열 우선순위", &c.APIKeyMaxPriority},
+		{"metrics-public", "METRICS_PUBLIC", "API 키 인증을 켜도 /metrics 는 키 없이 허용", &c.MetricsPublic},
+		{"target-allowlist", "TARGET_ALLOWLIST", "허용 대상 (호스트, *.도메인, IP, CIDR, 쉼표 구분)", &c.TargetAllowlist},
+		{"target-max-rps", "TARGET_MAX_RPS", "테스트 하나의 최대 RPS", &c.TargetMaxRPS},
+		{"target-rps-limits", "TARGET_RPS_LIMITS", "대상별 최대 RPS (호스트=RPS, 쉼표 구분)", &c.TargetRPSLimits},
+		{"verify-above-rps", "VERIFY_ABOVE_RPS", "대상 소유 확인이 필요한 RPS 기준", &c.VerifyAboveRPS},
+		{"verify-secret", "VERIFY_SECRET", "소유 확인 토큰 서명 비밀값", &c.VerifySecret},
+		{"webhook-urls", "WEBHOOK_URLS", "실행 종료 웹훅 (형식=URL, 쉼표 구분)", &c.WebhookURLs},
+		{"webhook-secret", "WEBHOOK_SECRET", "웹훅 서명 비밀값", &c.WebhookSecret},
+		{"webhook-only-failures", "WEBHOOK_ONLY_FAILURES", "실패/회귀한 실행만 알림", &c.WebhookOnlyFailures},
+		{"webhook-max-attempts", "WEBHOOK_MAX_ATTEMPTS", "웹훅 최대 시도 횟수 (0이면 5)", &c.WebhookMaxAttempts},
+		{"otlp-endpoint", "OTEL_EXPORTER_OTLP_ENDPOINT", "OTLP/HTTP trace 수집기 주소", &c.OTLPEndpoint},
+		{"otlp-headers", "OTEL_EXPORTER_OTLP_HEADERS", "수집기 요청 헤더 (키=값, 쉼표 구분)", &c.OTLPHeaders},
+		{"otel-service-name", "OTEL_SERVICE_NAME", "span의 service.name", &c.OTELServiceName},
+		{"auto-compare", "AUTO_COMPARE", "실행 완료 시 직전 실행과 자동 비교", &c.AutoCompare},
+	}
+}
+
+// String은 설정 항목의 현재 값을 문자열로 반환 (플래그 도움말의 기본값 표시)
+func (s setting) String() string {
+	switch v := s.value.(type) {
+	case *string:
+		return *v
+	case *int:
+		return strconv.Itoa(*v)
+	case *bool:
+		return strconv.FormatBool(*v)
+	case *[]string:
+		return strings.Join(*v, ",")
+	}
+	return ""
+}
+
+// rawFlag는 플래그 값을 바로 적용하지 않고 문자열로 보관 (환경변수보다 나중에 적용하기 위해)
+type rawFlag struct {
+	raw    string
+	isBool bool
+}
+
+func (f *rawFlag) String() string     { return f.raw }
+func (f *rawFlag) Set(v string) error { f.raw = v; return nil }
+func (f *rawFlag) IsBoolFlag() bool   { return f.isBool }
+
+// set은 문자열 값을 설정 항목의 타입으로 변환해 저장
+func (s setting) set(raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch v := s.value.(type) {
+	case *string:
+		*v = raw
+	case *int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("정수가 아닙니다: %q", raw)
+		}
+		*v = n
+	case *bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("true 또는 false 여야 합니다: %q", raw)
+		}
+		*v = b
+	case *[]string:
+		*v = nil
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*v = append(*v, item)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadServerConfig는 기본값 위에 설정 파일, 환경변수, 명령줄 플래그를 차례로 적용하고 검사
+// 설정 파일은 -config 플래그 또는 CONFIG_FILE 환경변수로 지정합니다 (JSON, 필드 이름은 ServerConfig의 json 태그).
+// args에 -h 가 있으면 사용법을 출력하고 flag.ErrHelp를 반환합니다.
+func LoadServerConfig(args []string, getenv func(string) string) (ServerConfig, error) {
+	cfg := DefaultServerConfig()
+	items := cfg.settings()
+
+	// 플래그는 값을 바로 쓰지 않고 모아 두었다가 마지막에 적용 (우선순위 유지)
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "서버 옵션 (우선순위: 플래그 > 환경변수 > 설정 파일 > 기본값):")
+		fs.PrintDefaults()
+	}
+	configFile := fs.String("config", getenv("CONFIG_FILE"), "설정 파일(JSON) 경로 (환경변수 CONFIG_FILE)")
+	flagValues := make(map[string]*rawFlag, len(items))
+	for _, item := range items {
+		_, isBool := item.value.(*bool)
+		flagValues[item.flag] = &rawFlag{raw: item.String(), isBool: isBool}
+		fs.Var(flagValues[item.flag], item.flag, fmt.Sprintf("%s (환경변수 %s)", item.usage, item.env))
+	}
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	if fs.NArg() > 0 {
+		return cfg, fmt.Errorf("알 수 없는 인자: %s", strings.Join(fs.Args(), " "))
+	}
+
+	if *configFile != "" {
+		if err := readConfigFile(*configFile, &cfg); err != nil {
+			return cfg, err
+		}
+	}
+
+	var errs []error
+	for _, item := range items {
+		if raw := getenv(item.env); raw != "" {
+			if err := item.set(raw); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %v", item.env, err))
+			}
+		}
+	}
+	fs.Visit(func(f *flag.Flag) {
+		for _, item := range items {
+			if item.flag == f.Name {
+				if err := item.set(flagValues[f.Name].raw); err != nil {
+					errs = append(errs, fmt.Errorf("-%s: %v", f.Name, err))
+				}
+			}
+		}
+	})
+	if len(errs) > 0 {
+		return cfg, errors.Join(errs...)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("서버 설정 오류:\n%w", err)
+	}
+	return cfg, nil
+}
+
+// readConfigFile은 JSON 설정 파일을 cfg 위에 덮어씀 (알 수 없는 필드는 오타로 보고 오류)
+func readConfigFile(path string, cfg *ServerConfig) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("설정 파일 읽기 실패: %v", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cfg); err != nil && err != io.EOF {
+		return fmt.Errorf("설정 파일 %s 해석 실패: %v", path, err)
+	}
+	return nil
+}
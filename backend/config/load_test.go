@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// envMap은 맵에서 환경변수를 읽는 getenv
+func envMap(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func TestLoadServerConfigPrecedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.json")
+	err := os.WriteFile(file, []byte(`{"apiKeys": "team-a:file-key", "targetMaxRps": 100, "webhookMaxAttempts": 2}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadServerConfig(
+		[]string{"-config", file, "-webhook-max-attempts", "7"},
+		envMap(map[string]string{"TARGET_MAX_RPS": "200", "WEBHOOK_ONLY_FAILURES": "true"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.APIKeys != "team-a:file-key" {
+		t.Errorf("APIKeys = %q, want value from the config file", cfg.APIKeys)
+	}
+	if cfg.TargetMaxRPS != 200 {
+		t.Errorf("TargetMaxRPS = %d, want env to override the file", cfg.TargetMaxRPS)
+	}
+	if cfg.WebhookMaxAttempts != 7 {
+		t.Errorf("WebhookMaxAttempts = %d, want flag to override the file", cfg.WebhookMaxAttempts)
+	}
+	if !cfg.WebhookOnlyFailures {
+		t.Error("WebhookOnlyFailures = false, want true from env")
+	}
+}
+
+func TestLoadServerConfigRejectsBadValues(t *testing.T) {
+	cases := []struct {
+		env  map[string]string
+		want string
+	}{
+		{map[string]string{"API_KEY_MAX_CONCURRENT": "abc"}, "API_KEY_MAX_CONCURRENT"},
+		{map[string]string{"API_KEY_DAILY_BUDGET": "1e6"}, "API_KEY_DAILY_BUDGET"},
+		{map[string]string{"WEBHOOK_ONLY_FAILURES": "yes"}, "WEBHOOK_ONLY_FAILURES"},
+		{map[string]string{"TARGET_MAX_RPS": "-1"}, "targetMaxRps"},
+		{map[string]string{"VERIFY_ABOVE_RPS": "-5"}, "verifyAboveRps"},
+		{map[string]string{"WEBHOOK_MAX_ATTEMPTS": "-1"}, "webhookMaxAttempts"},
+		{map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "localhost:4318"}, "otlpEndpoint"},
+		{map[string]string{"OTEL_EXPORTER_OTLP_HEADERS": "api-key=1,broken"}, "otlpHeaders"},
+	}
+	for _, c := range cases {
+		_, err := LoadServerConfig(nil, envMap(c.env))
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("LoadServerConfig(%v) = %v, want error mentioning %s", c.env, err, c.want)
+		}
+	}
+}
+
+func TestValidateReportsEveryError(t *testing.T) {
+	cfg := DefaultServerConfig()
+	cfg.APIKeyMaxConcurrent = -1
+	cfg.OTLPEndpoint = "ftp://collector"
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate succeeded, want errors")
+	}
+	for _, want := range []string{"apiKeyMaxConcurrent", "otlpEndpoint"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate = %v, want error mentioning %s", err, want)
+		}
+	}
+}
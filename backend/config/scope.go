@@ -0,0 +1,20 @@
+package config
+
+// Scope는 탐색과 부하 테스트가 다룰 수 있는 요청 범위
+// 대상(Target)의 호스트는 항상 범위에 포함됩니다.
+type Scope struct {
+	// 추가로 허용할 호스트 (예: api.example.com, *.example.com, localhost:8080)
+	// 포트를 적으면 포트까지 일치해야 하며, *.은 하위 도메인에만 일치합니다.
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+
+	// 포함할 경로 glob (비어 있으면 전체, 예: /api/**)
+	// *는 / 를 제외한 문자열, **는 / 를 포함한 모든 문자열에 일치합니다.
+	Include []string `json:"include,omitempty"`
+
+	// 제외할 경로 glob (Include보다 우선, 예: /api/admin/**, /logout)
+	Exclude []string `json:"exclude,omitempty"`
+
+	// true면 분석/광고/CDN 등 알려진 외부 서비스 호스트도 AllowedHosts 와일드카드로 허용
+	// 기본값(false)에서는 AllowedHosts에 정확히 적은 경우에만 허용합니다.
+	AllowThirdParty bool `json:"allowThirdParty,omitempty"`
+}
@@ -0,0 +1,299 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ServerConfig는 서버 실행 설정
+// 우선순위는 명령줄 플래그 > 환경변수 > 설정 파일(JSON) > 기본값입니다.
+// 시간 값은 모두 초 단위입니다.
+type ServerConfig struct {
+	// 수신
+	Listen      string   `json:"listen"`      // 수신 주소 (기본 :8080)
+	PublicURL   string   `json:"publicUrl"`   // 외부에서 접근하는 서버 주소 (알림 링크, 빈 값이면 Listen으로 추정)
+	TLSCertFile string   `json:"tlsCertFile"` // TLS 인증서 파일 (키와 함께 지정하면 HTTPS)
+	TLSKeyFile  string   `json:"tlsKeyFile"`  // TLS 개인 키 파일
+	CORSOrigins []string `json:"corsOrigins"` // 브라우저에서 호출을 허용할 Origin (* 는 전체)
+
+	// 시간 제한 (테스트가 응답 안에서 실행되므로 쓰기 시간 제한은 두지 않음)
+	ReadHeaderTimeout int `json:"readHeaderTimeout"` // 요청 헤더 읽기 제한
+	IdleTimeout       int `json:"idleTimeout"`       // keep-alive 유휴 연결 유지 시간
+	ShutdownTimeout   int `json:"shutdownTimeout"`   // 종료 시 실행 중인 테스트를 기다리는 시간 (0이면 바로 중단)
+
+	// 경로
+	StorageDir string `json:"storageDir"` // 실행 기록 저장 디렉토리
+	LogDir     string `json:"logDir"`     // 로그 디렉토리 (빈 값이면 실행 파일 옆 logs)
+
+	// 로그
+	LogLevel      string `json:"logLevel"`      // 최소 로그 레벨 (debug, info, warn, error)
+	LogFormat     string `json:"logFormat"`     // 출력 형식 (console, json)
+	LogMaxSizeMB  int    `json:"logMaxSizeMb"`  // 로그 파일 교체 크기(MB), 날짜가 바뀌어도 교체 (0이면 날짜로만 교체)
+	LogMaxAgeDays int    `json:"logMaxAgeDays"` // 교체된 로그 파일 보관 기간(일) (0이면 지우지 않음)
+	LogMaxBackups int    `json:"logMaxBackups"` // 보관할 교체 파일 수 (0이면 개수 제한 없음)
+
+	// 실행 한도 (0이면 제한 없음)
+	MaxConcurrentRuns int  `json:"maxConcurrentRuns"` // 동시에 실행할 최대 테스트 수
+	MaxTotalRPS       int  `json:"maxTotalRps"`       // 실행 중인 테스트 RPS 합계 한도
+	MaxQueueLength    int  `json:"maxQueueLength"`    // 대기열 최대 길이
+	QueueReject       bool `json:"queueReject"`       // true면 대기하지 않고 바로 거부
+
+	// 경로 탐색
+	Scraper           string `json:"scraper"`           // 기본 탐색 방식 (crawler 또는 node)
+	ScraperCommand    string `json:"scraperCommand"`    // 스크래퍼 실행 명령 (예: node, xvfb-run node)
+	ScraperScript     string `json:"scraperScript"`     // Node.js 스크래퍼 스크립트 경로
+	ScraperTimeout    int    `json:"scraperTimeout"`    // 스크래퍼 실행 제한 시간
+	CrawlMaxDepth     int    `json:"crawlMaxDepth"`     // 내장 크롤러 최대 깊이 (0이면 크롤러 기본값)
+	CrawlMaxPages     int    `json:"crawlMaxPages"`     // 내장 크롤러 최대 페이지 수 (0이면 크롤러 기본값)
+	CrawlIgnoreRobots bool   `json:"crawlIgnoreRobots"` // true면 robots.txt 무시
+	SourceDir         string `json:"sourceDir"`         // 탐색/재생 소스의 location으로 읽을 수 있는 디렉토리 (빈 값이면 서버 파일 읽기 금지)
+
+	// 테스트 기본값
+	DefaultRPS       int `json:"defaultRps"`       // /test 의 초당 요청 수
+	DefaultDuration  int `json:"defaultDuration"`  // /test 의 실행 시간
+	AutoTestRPS      int `json:"autoTestRps"`      // 자동 테스트 1단계(GPT 추천 경로)의 초당 요청 수
+	AutoTestDuration int `json:"autoTestDuration"` // 자동 테스트 1단계의 실행 시간
+	RequestTimeout   int `json:"requestTimeout"`   // 요청 하나의 제한 시간 (요청에 timeout이 없을 때)
+
+	// 경로 분석 (API 키는 OPENAI_API_KEY 환경변수로만 지정)
+	AIProvider   string `json:"aiProvider"`   // 분석 제공자 (openai, azure, mock)
+	AIBaseURL    string `json:"aiBaseUrl"`    // OpenAI 호환 API 주소 (Ollama, vLLM 등) 또는 Azure 리소스 주소
+	AIAPIVersion string `json:"aiApiVersion"` // Azure OpenAI API 버전 (빈 값이면 기본값)
+	OpenAIModel  string `json:"openaiModel"`  // 경로 분석에 사용할 모델 (Azure는 배포 이름)
+	// 응답 형식 (json_schema: 결과 스키마 강제, json_object: 스키마를 지원하지 않는 서버용 JSON 모드)
+	AIResponseFormat string `json:"aiResponseFormat"`
+	AIMaxAttempts    int    `json:"aiMaxAttempts"` // 분석 결과가 유효하지 않을 때 다시 요청하는 것을 포함한 최대 호출 횟수
+
+	// 분산 실행
+	AgentToken string `json:"agentToken"` // 에이전트와 공유하는 토큰 (비어 있으면 에이전트를 등록할 수 없음)
+
+	// API 키 인증 (APIKeys가 비어 있으면 인증 없이 실행, 키에 적지 않은 한도는 아래 기본값)
+	APIKeys             string `json:"apiKeys"`             // "이름:키[:최대동시실행[:일일예산[:최대우선순위]]]" 쉼표 목록
+	APIKeyMaxConcurrent int    `json:"apiKeyMaxConcurrent"` // 키별 최대 동시 실행 수 (0이면 제한 없음)
+	APIKeyDailyBudget   int    `json:"apiKeyDailyBudget"`   // 키별 일일 요청 예산 (RPS × 초, 0이면 제한 없음)
+	APIKeyMaxPriority   int    `json:"apiKeyMaxPriority"`   // 키별 최대 대기열 우선순위
+	MetricsPublic       bool   `json:"metricsPublic"`       // true면 API 키 인증을 켜도 /metrics 는 키 없이 허용
+
+	// 안전 정책 (모두 비어 있으면 적용하지 않음)
+	TargetAllowlist string `json:"targetAllowlist"` // 허용 대상 호스트, *.도메인, IP, CIDR 쉼표 목록
+	TargetMaxRPS    int    `json:"targetMaxRps"`    // 대상별 한도가 없을 때의 테스트 하나의 최대 RPS (0이면 제한 없음)
+	TargetRPSLimits string `json:"targetRpsLimits"` // 대상별 최대 RPS ("호스트=RPS" 쉼표 목록)
+	VerifyAboveRPS  int    `json:"verifyAboveRps"`  // 이 RPS를 넘는 테스트는 대상 소유 확인 필요 (0이면 확인하지 않음)
+	VerifySecret    string `json:"verifySecret"`    // 소유 확인 토큰 서명 비밀값 (빈 값이면 실행마다 임시 값)
+
+	// 실행 종료 웹훅 알림 (WebhookURLs가 비어 있으면 알리지 않음)
+	WebhookURLs         string `json:"webhookUrls"`         // "형식=URL" 쉼표 목록 (형식은 json, slack, teams)
+	WebhookSecret       string `json:"webhookSecret"`       // 서명 비밀값 (빈 값이면 서명하지 않음)
+	WebhookOnlyFailures bool   `json:"webhookOnlyFailures"` // true면 통과한 실행은 알리지 않음
+	WebhookMaxAttempts  int    `json:"webhookMaxAttempts"`  // 최초 전송 포함 최대 시도 횟수 (0이면 5)
+
+	// 분산 추적 (OTLPEndpoint가 비어 있으면 span을 보내지 않음)
+	OTLPEndpoint    string `json:"otlpEndpoint"`    // OTLP/HTTP 수집기 주소
+	OTLPHeaders     string `json:"otlpHeaders"`     // 수집기 요청 헤더 ("키=값" 쉼표 목록)
+	OTELServiceName string `json:"otelServiceName"` // span의 service.name (빈 값이면 loadtest)
+
+	// 기타
+	AutoCompare bool `json:"autoCompare"` // 실행 완료 시 직전 실행과 자동 비교
+}
+
+// DefaultServerConfig는 설정하지 않은 항목에 사용하는 기본값
+func DefaultServerConfig() ServerConfig {
+	return ServerConfig{
+		Listen:            ":8080",
+		ReadHeaderTimeout: 10,
+		IdleTimeout:       120,
+		ShutdownTimeout:   25,
+		StorageDir:        "./data",
+		LogLevel:          "info",
+		LogFormat:         "console",
+		LogMaxSizeMB:      100,
+		LogMaxAgeDays:     14,
+		Scraper:           "crawler",
+		ScraperCommand:    "node",
+		ScraperScript:     "../workers/scrappers/api-extractor.js",
+		ScraperTimeout:    120,
+		DefaultRPS:        10,
+		DefaultDuration:   30,
+		AutoTestRPS:       10,
+		AutoTestDuration:  10,
+		RequestTimeout:    10,
+		AIProvider:        "openai",
+		OpenAIModel:       "gpt-4o",
+		AIResponseFormat:  "json_schema",
+		AIMaxAttempts:     3,
+	}
+}
+
+// TLS는 HTTPS로 실행하는지 반환
+func (c ServerConfig) TLS() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// BaseURL은 알림 링크 등에 사용할 서버 주소를 반환
+// PublicURL이 없으면 Listen 주소로 localhost 주소를 만듭니다.
+func (c ServerConfig) BaseURL() string {
+	if c.PublicURL != "" {
+		return strings.TrimRight(c.PublicURL, "/")
+	}
+	scheme := "http"
+	if c.TLS() {
+		scheme = "https"
+	}
+	host, port, err := net.SplitHostPort(c.Listen)
+	if err != nil {
+		return scheme + "://localhost:8080"
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return scheme + "://" + net.JoinHostPort(host, port)
+}
+
+// Validate는 설정 값을 검사하고 모든 오류를 모아 반환
+func (c ServerConfig) Validate() error {
+	var errs []error
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if _, port, err := net.SplitHostPort(c.Listen); err != nil || port == "" {
+		add("listen: 잘못된 수신 주소 %q (예: :8080, 127.0.0.1:8080)", c.Listen)
+	}
+	if c.PublicURL != "" {
+		if u, err := url.Parse(c.PublicURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("publicUrl: 잘못된 주소 %q", c.PublicURL)
+		}
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		add("tlsCertFile과 tlsKeyFile은 함께 지정해야 합니다")
+	}
+	for _, f := range []string{c.TLSCertFile, c.TLSKeyFile} {
+		if f == "" {
+			continue
+		}
+		if _, err := os.Stat(f); err != nil {
+			add("TLS 파일을 읽을 수 없습니다: %v", err)
+		}
+	}
+	for _, origin := range c.CORSOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			add("corsOrigins: 잘못된 Origin %q (예: https://app.example.com)", origin)
+		}
+	}
+
+	type intField struct {
+		name  string
+		value int
+	}
+	for _, f := range []intField{
+		{"readHeaderTimeout", c.ReadHeaderTimeout},
+		{"idleTimeout", c.IdleTimeout},
+		{"shutdownTimeout", c.ShutdownTimeout},
+		{"logMaxSizeMb", c.LogMaxSizeMB},
+		{"logMaxAgeDays", c.LogMaxAgeDays},
+		{"logMaxBackups", c.LogMaxBackups},
+		{"maxConcurrentRuns", c.MaxConcurrentRuns},
+		{"maxTotalRps", c.MaxTotalRPS},
+		{"maxQueueLength", c.MaxQueueLength},
+		{"crawlMaxDepth", c.CrawlMaxDepth},
+		{"crawlMaxPages", c.CrawlMaxPages},
+		{"apiKeyMaxConcurrent", c.APIKeyMaxConcurrent},
+		{"apiKeyDailyBudget", c.APIKeyDailyBudget},
+		{"targetMaxRps", c.TargetMaxRPS},
+		{"verifyAboveRps", c.VerifyAboveRPS},
+		{"webhookMaxAttempts", c.WebhookMaxAttempts},
+	} {
+		if f.value < 0 {
+			add("%s: 0 이상이어야 합니다 (%d)", f.name, f.value)
+		}
+	}
+	for _, f := range []intField{
+		{"scraperTimeout", c.ScraperTimeout},
+		{"defaultRps", c.DefaultRPS},
+		{"defaultDuration", c.DefaultDuration},
+		{"autoTestRps", c.AutoTestRPS},
+		{"autoTestDuration", c.AutoTestDuration},
+		{"requestTimeout", c.RequestTimeout},
+		{"aiMaxAttempts", c.AIMaxAttempts},
+	} {
+		if f.value <= 0 {
+			add("%s: 0보다 커야 합니다 (%d)", f.name, f.value)
+		}
+	}
+
+	switch c.Scraper {
+	case "crawler":
+	case "node":
+		if strings.TrimSpace(c.ScraperCommand) == "" {
+			add("scraperCommand: node 스크래퍼를 사용하려면 실행 명령이 필요합니다")
+		}
+		if c.ScraperScript == "" {
+			add("scraperScript: node 스크래퍼를 사용하려면 스크립트 경로가 필요합니다")
+		}
+	default:
+		add("scraper: crawler 또는 node 여야 합니다 (%q)", c.Scraper)
+	}
+	switch strings.ToLower(c.LogLevel) {
+	case "debug", "info", "warn", "error":
+	default:
+		add("logLevel: debug, info, warn, error 중 하나여야 합니다 (%q)", c.LogLevel)
+	}
+	switch strings.ToLower(c.LogFormat) {
+	case "console", "json":
+	default:
+		add("logFormat: console 또는 json 이어야 합니다 (%q)", c.LogFormat)
+	}
+	if c.StorageDir == "" {
+		add("storageDir: 저장 디렉토리가 필요합니다")
+	}
+	if c.SourceDir != "" {
+		if info, err := os.Stat(c.SourceDir); err != nil || !info.IsDir() {
+			add("sourceDir: 디렉토리가 아닙니다 (%q)", c.SourceDir)
+		}
+	}
+	switch c.AIProvider {
+	case "openai", "mock":
+	case "azure":
+		if c.AIBaseURL == "" {
+			add("aiBaseUrl: Azure OpenAI는 리소스 주소가 필요합니다 (예: https://my-resource.openai.azure.com)")
+		}
+	default:
+		add("aiProvider: openai, azure, mock 중 하나여야 합니다 (%q)", c.AIProvider)
+	}
+	if c.AIBaseURL != "" {
+		if u, err := url.Parse(c.AIBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("aiBaseUrl: 잘못된 주소 %q", c.AIBaseURL)
+		}
+	}
+	switch c.AIResponseFormat {
+	case "json_schema", "json_object":
+	default:
+		add("aiResponseFormat: json_schema 또는 json_object 여야 합니다 (%q)", c.AIResponseFormat)
+	}
+	if c.OpenAIModel == "" {
+		add("openaiModel: 모델 이름이 필요합니다")
+	}
+	if c.OTLPEndpoint != "" {
+		if u, err := url.Parse(c.OTLPEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("otlpEndpoint: 잘못된 주소 %q (예: http://localhost:4318)", c.OTLPEndpoint)
+		}
+	}
+	for _, pair := range strings.Split(c.OTLPHeaders, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		if key, _, ok := strings.Cut(pair, "="); !ok || strings.TrimSpace(key) == "" {
+			add("otlpHeaders: \"키=값\" 형식이어야 합니다 (%q)", strings.TrimSpace(pair))
+		}
+	}
+
+	return errors.Join(errs...)
+}
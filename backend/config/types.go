@@ -1,7 +1,11 @@
 package config
 
+import "time"
+
 // TestRequest는 /start-test API로부터 받은 테스트 설정을 담는 구조체
 type TestRequest struct {
+	ID       string              `json:"id,omitempty"`       // 실행 ID (저장소/지표 라벨에 사용)
+	Owner    string              `json:"owner,omitempty"`    // 실행을 시작한 API 키 이름 (대기열 표시용)
 	Target   string              `json:"target"`             // 테스트 대상 도메인 (예: https://example.com)
 	RPS      int                 `json:"rps"`                // 초당 요청 수 (Requests Per Second)
 	Duration int                 `json:"duration"`           // 테스트 시간 (초)
@@ -11,16 +15,172 @@ type TestRequest struct {
 	Body     string              `json:"body"`               // 요청 본문 (POST 요청에만 사용)
 	Timeout  int                 `json:"timeout,omitempty"`  // 요청별 타임아웃(초)
 	Silent   bool                `json:"silent,omitempty"`   // true면 요청별 로깅 비활성화
+
+	// true면 요청별 원시 샘플을 기록 (서버는 실행별 샘플 파일, 그 외에는 TestResult.Samples)
+	RecordSamples bool `json:"recordSamples,omitempty"`
+
+	// 합격 기준 (비어 있으면 DefaultThresholds 사용)
+	Thresholds []Threshold `json:"thresholds,omitempty"`
+
+	// 분산 추적 설정 (nil이면 traceparent 헤더를 붙이지 않음)
+	Tracing *TracingConfig `json:"tracing,omitempty"`
+
+	// 엔드포인트별 요청 목록 (비어 있지 않으면 Method/PathList/Body 대신 가중치에 따라 선택)
+	Requests []RequestSpec `json:"requests,omitempty"`
+
+	// 재생 모드: 기록된 시점(OffsetMs)에 맞춰 순서대로 보낼 요청 (비어 있지 않으면 RPS 대신 사용)
+	// Duration이 0이면 모든 요청을 보낼 때까지 실행합니다.
+	Schedule []ScheduledRequest `json:"schedule,omitempty"`
+	Speed    float64            `json:"speed,omitempty"` // 재생 속도 배율 (2면 두 배 빠르게, 기본 1)
+
+	// 서버 실행 대기열 우선순위 (클수록 먼저 실행, 기본 0)
+	Priority int `json:"priority,omitempty"`
+
+	// 요청 범위 (nil이면 대상 호스트만 허용)
+	// 범위 밖의 요청과 리다이렉트는 보내지 않습니다.
+	Scope *Scope `json:"scope,omitempty"`
+}
+
+// ScheduledRequest는 재생 모드에서 시작 시각 기준 정해진 시점에 보낼 요청
+type ScheduledRequest struct {
+	OffsetMs float64 `json:"offsetMs"` // 첫 요청으로부터의 시간(ms)
+	RequestSpec
+}
+
+// RequestSpec은 부하 테스트에서 보낼 요청 하나의 정의
+type RequestSpec struct {
+	Name           string              `json:"name,omitempty"`           // 통계에 사용할 엔드포인트 이름 (빈 값이면 "METHOD /path")
+	Method         string              `json:"method"`                   // 요청 메서드
+	Path           string              `json:"path"`                     // 쿼리를 포함한 요청 경로 (예: /users/1?expand=orders, /users/{id})
+	PathParams     []map[string]string `json:"pathParams,omitempty"`     // 경로 템플릿 파라미터 값 묶음 목록 (요청마다 순서대로 돌아가며 사용)
+	Headers        map[string]string   `json:"headers,omitempty"`        // 요청 헤더
+	Body           string              `json:"body,omitempty"`           // 요청 본문
+	ExpectedStatus []int               `json:"expectedStatus,omitempty"` // 성공으로 판정할 응답 코드 (빈 값이면 200)
+	Weight         int                 `json:"weight,omitempty"`         // 선택 가중치 (0 이하면 1)
+}
+
+// Expects는 응답 코드가 기대한 코드인지 판정
+func (s RequestSpec) Expects(status int) bool {
+	if len(s.ExpectedStatus) == 0 {
+		return status == 200
+	}
+	for _, code := range s.ExpectedStatus {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
+
+// TracingConfig는 요청별 W3C trace 전파 설정
+type TracingConfig struct {
+	Enabled     bool     `json:"enabled"`               // traceparent 헤더 주입 여부
+	SampleRatio *float64 `json:"sampleRatio,omitempty"` // span 샘플링 비율 (0~1, 미지정 시 1)
+}
+
+// Ratio는 샘플링 비율을 반환 (미지정 시 1)
+func (t *TracingConfig) Ratio() float64 {
+	if t == nil || t.SampleRatio == nil {
+		return 1
+	}
+	return *t.SampleRatio
+}
+
+// Threshold는 테스트 결과의 합격 기준 하나 (예: p95 < 500)
+type Threshold struct {
+	Metric   string  `json:"metric"`   // 지표 이름 (avg, max, p50, p90, p95, p99, errorRate, throughput)
+	Operator string  `json:"operator"` // 비교 연산자 (<, <=, >, >=)
+	Value    float64 `json:"value"`    // 기준값 (응답 시간은 ms, errorRate는 0~1, throughput은 초당 요청 수)
+}
+
+// ThresholdVerdict는 합격 기준 하나에 대한 판정 결과
+type ThresholdVerdict struct {
+	Threshold
+	Actual float64 `json:"actual"` // 실제 측정값
+	Passed bool    `json:"passed"` // 기준 충족 여부
+}
+
+// DefaultThresholds는 합격 기준을 지정하지 않았을 때 사용하는 기본값
+// 느린 응답 기준(500ms)과 5% 오류율을 사용합니다.
+func DefaultThresholds() []Threshold {
+	return []Threshold{
+		{Metric: "p95", Operator: "<", Value: 500},
+		{Metric: "errorRate", Operator: "<", Value: 0.05},
+	}
 }
 
 // TestResult는 트래픽 실행 후 응답 상태를 요약한 결과 구조체(백이 프론트한테 보냄)
 type TestResult struct {
-	TotalRequests    int         `json:"totalRequests"`    // 총 요청 수
-	SuccessCount     int         `json:"successCount"`     // 200 응답 수
-	FailCount        int         `json:"failCount"`        // 200 외 응답 수 (403, 429 등)
-	TimeoutCount     int         `json:"timeoutCount"`     // 타임아웃 발생 수
-	StatusMap        map[int]int `json:"statusMap"`        // 응답 코드별 개수 (예: 200:123, 429:4)
-	AvgLatencyMs     float64     `json:"avgLatencyMs"`     // 평균 응답 시간
-	MaxLatencyMs     float64     `json:"maxLatencyMs"`     // 최대 응답 시간
-	SlowCountOver500 int         `json:"slowCountOver500"` // 500ms 초과한 요청 개수
+	TotalRequests    int                       `json:"totalRequests"`             // 총 요청 수
+	SuccessCount     int                       `json:"successCount"`              // 200 응답 수
+	FailCount        int                       `json:"failCount"`                 // 200 외 응답 수 (403, 429 등)
+	TimeoutCount     int                       `json:"timeoutCount"`              // 타임아웃 발생 수
+	StatusMap        map[int]int               `json:"statusMap"`                 // 응답 코드별 개수 (예: 200:123, 429:4)
+	AvgLatencyMs     float64                   `json:"avgLatencyMs"`              // 평균 응답 시간
+	MaxLatencyMs     float64                   `json:"maxLatencyMs"`              // 최대 응답 시간
+	SlowCountOver500 int                       `json:"slowCountOver500"`          // 500ms 초과한 요청 개수
+	P50LatencyMs     float64                   `json:"p50LatencyMs"`              // 응답 시간 중앙값
+	P90LatencyMs     float64                   `json:"p90LatencyMs"`              // 응답 시간 90 분위수
+	P95LatencyMs     float64                   `json:"p95LatencyMs"`              // 응답 시간 95 분위수
+	P99LatencyMs     float64                   `json:"p99LatencyMs"`              // 응답 시간 99 분위수
+	ErrorRate        float64                   `json:"errorRate"`                 // 실패 비율 (0~1)
+	ThroughputRPS    float64                   `json:"throughputRps"`             // 실제 처리량 (초당 완료 요청 수)
+	ElapsedSec       float64                   `json:"elapsedSec"`                // 실제 측정 시간(초)
+	Latency          Histogram                 `json:"latencyHistogram"`          // 응답 시간 분포
+	Endpoints        map[string]*EndpointStats `json:"endpoints,omitempty"`       // 엔드포인트("METHOD /path")별 통계
+	TimeSeries       []TimePoint               `json:"timeSeries,omitempty"`      // 초 단위 시계열
+	Thresholds       []ThresholdVerdict        `json:"thresholds,omitempty"`      // 합격 기준 판정 결과
+	Passed           bool                      `json:"passed"`                    // 모든 합격 기준 충족 여부
+	OutOfScope       int                       `json:"outOfScope,omitempty"`      // 범위 밖이라 보내지 않은 요청 수
+	Interrupted      bool                      `json:"interrupted,omitempty"`     // 서버 종료로 중간에 멈춘 부분 결과
+	Samples          []Sample                  `json:"samples,omitempty"`         // 요청별 원시 샘플 (RecordSamples이고 샘플 파일에 기록하지 않을 때만)
+	RecordedSamples  int                       `json:"recordedSamples,omitempty"` // 샘플 파일에 기록한 원시 샘플 수
+}
+
+// Sample은 요청 하나의 원시 측정값
+// 서버에서는 결과에 넣지 않고 실행별 샘플 파일(STORAGE_DIR/samples)에 기록합니다.
+type Sample struct {
+	Time       time.Time `json:"time"`                 // 요청 시작 시각
+	Endpoint   string    `json:"endpoint"`             // "METHOD /path"
+	Status     int       `json:"status"`               // 응답 코드 (응답이 없으면 0, 타임아웃은 -1)
+	LatencyMs  float64   `json:"latencyMs"`            // 응답 시간 (응답이 없으면 0)
+	Failed     bool      `json:"failed,omitempty"`     // 실패 여부 (기대하지 않은 응답 코드 포함)
+	ErrorClass string    `json:"errorClass,omitempty"` // 실패 분류 (timeout, dns, refused, reset, tls, eof, status, other)
+	Error      string    `json:"error,omitempty"`      // 오류 메시지
+
+	// 구간별 시간 (ms, 연결을 재사용하면 DNS/연결/TLS는 0)
+	DNSMs         float64 `json:"dnsMs,omitempty"`         // DNS 조회
+	ConnectMs     float64 `json:"connectMs,omitempty"`     // TCP 연결
+	TLSMs         float64 `json:"tlsMs,omitempty"`         // TLS 핸드셰이크
+	TTFBMs        float64 `json:"ttfbMs,omitempty"`        // 요청 시작부터 첫 응답 바이트까지
+	DownloadMs    float64 `json:"downloadMs,omitempty"`    // 응답 본문을 끝까지 읽은 시간
+	FailedAfterMs float64 `json:"failedAfterMs,omitempty"` // 응답 없이 실패하기까지 걸린 시간
+
+	BytesSent     int64 `json:"bytesSent,omitempty"`     // 요청 본문 크기
+	BytesReceived int64 `json:"bytesReceived,omitempty"` // 응답 본문 크기
+}
+
+// TimePoint는 테스트 시작 후 특정 1초 구간의 집계
+type TimePoint struct {
+	Second       int     `json:"second"`       // 테스트 시작 후 경과 초
+	Requests     int     `json:"requests"`     // 이 구간에 완료된 요청 수
+	Errors       int     `json:"errors"`       // 이 구간의 실패 수 (타임아웃 포함)
+	Responses    int     `json:"responses"`    // 응답을 받은 요청 수 (평균 계산 기준)
+	AvgLatencyMs float64 `json:"avgLatencyMs"` // 평균 응답 시간
+	MaxLatencyMs float64 `json:"maxLatencyMs"` // 최대 응답 시간
+}
+
+// EndpointStats는 엔드포인트 하나에 대한 통계
+type EndpointStats struct {
+	TotalRequests int       `json:"totalRequests"`    // 총 요청 수
+	SuccessCount  int       `json:"successCount"`     // 200 응답 수
+	FailCount     int       `json:"failCount"`        // 실패 수 (타임아웃 포함)
+	TimeoutCount  int       `json:"timeoutCount"`     // 타임아웃 발생 수
+	AvgLatencyMs  float64   `json:"avgLatencyMs"`     // 평균 응답 시간
+	MaxLatencyMs  float64   `json:"maxLatencyMs"`     // 최대 응답 시간
+	P50LatencyMs  float64   `json:"p50LatencyMs"`     // 응답 시간 중앙값
+	P95LatencyMs  float64   `json:"p95LatencyMs"`     // 응답 시간 95 분위수
+	P99LatencyMs  float64   `json:"p99LatencyMs"`     // 응답 시간 99 분위수
+	ErrorRate     float64   `json:"errorRate"`        // 실패 비율 (0~1)
+	Latency       Histogram `json:"latencyHistogram"` // 응답 시간 분포
 }
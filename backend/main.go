@@ -4,16 +4,37 @@ package main
 
 import (
 	// Go에서는 필요한 기능을 패키지로 가져와 사용합니다
-	"fmt"           // 콘솔에 문자열 출력할 때 사용 (printf, println 등)
+	"context"       // 실행 대기 취소
+	"crypto/rand"   // 소유 확인 임시 비밀값 생성
+	"encoding/hex"  // 비밀값 문자열 변환
+	"errors"        // 오류 비교
+	"flag"          // 서버 설정 플래그 (-h 처리)
 	stdlog "log"    // 표준 log 패키지를 stdlog로 별칭
 	"net/http"      // HTTP 서버/클라이언트 기능 제공 (웹 서버 만들 때 필요)
 	"os"            // 파일 시스템 접근용
 	"path/filepath" // 경로 처리용
+	"strings"       // 명령줄 인자 구분
+	"testing"       // go test 실행 여부 확인
 	"time"          // 날짜 포맷팅용
 
 	// 패키지 경로 수정 (service-test/ 제거)
 	api "github.com/Mr-Muji/LoadTest/backend/api/load-test"          // 로드 테스트 API 핸들러
+	"github.com/Mr-Muji/LoadTest/backend/config"                     // 공용 타입
+	"github.com/Mr-Muji/LoadTest/backend/modules/ai"                 // GPT 경로 분석
+	"github.com/Mr-Muji/LoadTest/backend/modules/auth"               // API 키 인증
+	"github.com/Mr-Muji/LoadTest/backend/modules/compare"            // 실행 결과 비교
+	"github.com/Mr-Muji/LoadTest/backend/modules/crawler"            // 경로 탐색 크롤러
+	"github.com/Mr-Muji/LoadTest/backend/modules/distributed"        // 분산 실행
 	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test" // 로드 테스트 모듈
+	"github.com/Mr-Muji/LoadTest/backend/modules/metrics"            // 실시간 지표
+	"github.com/Mr-Muji/LoadTest/backend/modules/notify"             // 실행 종료 웹훅 알림
+	"github.com/Mr-Muji/LoadTest/backend/modules/orchestrator"       // 자동 테스트 오케스트레이터
+	"github.com/Mr-Muji/LoadTest/backend/modules/queue"              // 실행 대기열
+	"github.com/Mr-Muji/LoadTest/backend/modules/safety"             // 안전 정책
+	"github.com/Mr-Muji/LoadTest/backend/modules/samples"            // 원시 샘플 파일
+	"github.com/Mr-Muji/LoadTest/backend/modules/schedule"           // 반복 테스트 스케줄
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"            // 실행 기록 저장소
+	"github.com/Mr-Muji/LoadTest/backend/modules/tracing"            // 분산 추적
 	"github.com/Mr-Muji/LoadTest/libs/logger"                        // 로깅 모듈
 	"github.com/joho/godotenv"
 	"go.uber.org/zap" // zap 로거 패키지
@@ -22,6 +43,25 @@ import (
 // 짧은 별칭 변수 선언 - logger.Logger 대신 log 사용
 var log *zap.SugaredLogger
 
+// authn은 API 키 인증 (API_KEYS가 없으면 nil, 인증 없이 실행)
+var authn *auth.Authenticator
+
+// scheduler는 반복 테스트 스케줄러 (저장소가 없으면 nil, 서버 모드에서만 시작)
+var scheduler *schedule.Scheduler
+
+// runQueue는 실행 대기열 (제한이 없으면 nil), 종료 시 대기 중인 테스트를 정리
+var runQueue *queue.Queue
+
+// notifier는 실행 종료 웹훅 알림 (WEBHOOK_URLS가 없으면 nil), 종료 시 남은 전송을 기다림
+var notifier *notify.Notifier
+
+// sampleStore는 실행별 원시 샘플 파일 저장소 (저장소가 없으면 nil)
+// 부하 테스트가 파일에 기록하는 것은 서버 모드에서만 켭니다 (에이전트는 결과에 담아 코디네이터로 보냄).
+var sampleStore *samples.Store
+
+// cfg는 서버 설정 (플래그 > 환경변수 > 설정 파일 > 기본값)
+var cfg config.ServerConfig
+
 // TestRequest - 클라이언트로부터 받을 테스트 요청 정보를 담는 구조체
 // Go에서 구조체(struct)는 관련 데이터를 하나로 묶는 자료형입니다
 type TestRequest struct {
@@ -32,6 +72,13 @@ type TestRequest struct {
 }
 
 func init() {
+	// go test로 실행하면 서버 설정(-test.* 인자 해석, 로그 파일, 저장소 디렉토리)을 건너뜀
+	// CLI 테스트는 필요한 구성 요소를 직접 설정합니다.
+	if testing.Testing() {
+		log = logger.Nop()
+		return
+	}
+
 	// .env 파일 로드
 	envFiles := []string{
 		".env",          // 현재 디렉토리
@@ -47,36 +94,255 @@ func init() {
 		}
 	}
 
-	// 로그 디렉토리 설정
-	logDir := "./logs"
+	// 서버 설정 로드 (서버 모드의 플래그, 환경변수, CONFIG_FILE)
+	var err error
+	cfg, err = config.LoadServerConfig(serverArgs(os.Args[1:]), os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		printUsage()
+		os.Exit(0)
+	}
+	if err != nil {
+		stdlog.Printf("%v", err)
+		os.Exit(2)
+	}
+
+	// 로그 디렉토리 설정 (기본: 실행 파일 옆 logs)
+	logDir := cfg.LogDir
+	if logDir == "" {
+		logDir = "./logs"
+		if executable, err := os.Executable(); err == nil {
+			logDir = filepath.Join(filepath.Dir(executable), "logs")
+		}
+	}
+
+	// 로거 하나를 만들어 모든 모듈에 주입 (콘솔과 교체되는 로그 파일에 함께 출력)
+	// 로거는 전역 변수 대신 생성자 인자나 요청 컨텍스트(api.WithLogger, logger.WithContext)로 넘깁니다.
+	// 저장소, 안전 정책, 대기열 같은 나머지 구성 요소는 아래에서 각 패키지의 Set* 함수로 한 번 설정합니다.
+	log, err = logger.New(logger.Config{
+		Level:      cfg.LogLevel,
+		Format:     cfg.LogFormat,
+		File:       filepath.Join(logDir, "loadtest.log"),
+		MaxSizeMB:  cfg.LogMaxSizeMB,
+		MaxAgeDays: cfg.LogMaxAgeDays,
+		MaxBackups: cfg.LogMaxBackups,
+	})
+	if err != nil {
+		stdlog.Printf("로거 초기화 실패: %v", err)
+		os.Exit(2)
+	}
+
+	// 초기화 완료 로그
+	log.Infow("애플리케이션 시작, 로깅 시스템 초기화 완료", "dir", logDir, "level", cfg.LogLevel, "format", cfg.LogFormat)
+
+	// 안전 정책 (허용 대상, 대상별 RPS 한도, 고부하 테스트 소유 확인)
+	// 저장소 초기화가 실패해도 적용되도록 가장 먼저 설정
+	if p := loadSafetyPolicy(); p != nil {
+		api.SetSafetyPolicy(p)
+		loadtest.SetGuard(p.Check)
+		loadtest.SetDialer(p.DialContext(nil))
+		orchestrator.SetFetchGuard(p.CheckTarget)
+		orchestrator.SetFetchDialer(p.DialContext(nil))
+		log.Infow("안전 정책 적용",
+			"allowedHosts", p.AllowedHosts,
+			"allowedNets", len(p.AllowedNets),
+			"maxRPS", p.MaxRPS,
+			"verifyAboveRPS", p.VerifyAboveRPS,
+		)
+	}
+
+	// 서버 실행 대기열 (동시 실행 수, 전체 RPS 한도, 초과분은 우선순위 FIFO 대기)
+	if cfg.MaxConcurrentRuns > 0 || cfg.MaxTotalRPS > 0 {
+		runQueue = queue.New(queue.Options{
+			MaxConcurrent: cfg.MaxConcurrentRuns,
+			MaxRPS:        cfg.MaxTotalRPS,
+			MaxWaiting:    cfg.MaxQueueLength,
+			Reject:        cfg.QueueReject,
+		})
+		loadtest.SetAdmission(func(ctx context.Context, req config.TestRequest) (func(), error) {
+			return runQueue.Acquire(ctx, queue.Job{
+				ID:       req.ID,
+				Owner:    req.Owner,
+				Target:   req.Target,
+				RPS:      safety.PeakRPS(req),
+				Priority: runPriority(req),
+			})
+		})
+		api.SetQueue(runQueue)
+		log.Infow("실행 대기열 적용", "maxConcurrentRuns", cfg.MaxConcurrentRuns, "maxTotalRPS", cfg.MaxTotalRPS)
+	}
+
+	// API 키 인증과 키별 사용량 한도 (API_KEYS="이름:키[:최대동시실행[:일일예산[:최대우선순위]]],...")
+	if cfg.APIKeys != "" {
+		authn = auth.New()
+		if err := authn.ParseKeys(cfg.APIKeys, cfg.APIKeyMaxConcurrent, cfg.APIKeyDailyBudget, cfg.APIKeyMaxPriority); err != nil {
+			log.Fatalw("API_KEYS 설정 오류", "error", err)
+		}
+		// 실시간 지표에는 대상 URL과 엔드포인트가 라벨로 들어가므로 기본은 키가 있어야 조회 (METRICS_PUBLIC=true 면 허용)
+		if cfg.MetricsPublic {
+			authn.Public = []string{"/metrics"}
+		}
+		api.SetAuthenticator(authn)
+		log.Infow("API 키 인증 적용", "keys", authn.Len())
+	} else {
+		log.Warn("API_KEYS가 없어 인증 없이 실행합니다")
+	}
+
+	// 실행 기록 저장소와 반복 테스트 스케줄 (실패해도 나머지 설정은 계속 적용)
+	setupStorage(cfg.StorageDir)
 
-	// 현재 실행 경로 기준으로 로그 디렉토리 생성
-	executable, err := os.Executable()
-	if err == nil {
-		execDir := filepath.Dir(executable)
-		logDir = filepath.Join(execDir, "logs")
+	// 분산 추적 수집기 (OTEL_EXPORTER_OTLP_ENDPOINT 가 있으면 span 전송)
+	if cfg.OTLPEndpoint != "" {
+		loadtest.SetTraceExporter(tracing.NewOTLPExporter(
+			cfg.OTLPEndpoint,
+			cfg.OTELServiceName,
+			tracing.ParseHeaders(cfg.OTLPHeaders),
+		))
+		log.Infow("OTLP trace 수집기 설정 완료", "endpoint", cfg.OTLPEndpoint)
 	}
 
-	// 로그 디렉토리 생성
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		stdlog.Printf("로그 디렉토리 생성 실패: %v", err)
+	// 분산 실행 코디네이터 (에이전트는 go run . agent 로 실행)
+	api.SetCoordinator(distributed.NewCoordinator(log, cfg.AgentToken))
+
+	// 실행 종료 웹훅 알림 (WEBHOOK_URLS="slack=https://hooks.slack.com/...,https://ci.example.com/hook")
+	if cfg.WebhookURLs != "" {
+		targets, err := notify.ParseTargets(cfg.WebhookURLs, cfg.WebhookSecret)
+		if err != nil {
+			log.Fatalw("WEBHOOK_URLS 설정 오류", "error", err)
+		}
+		notifier = notify.New(targets, cfg.BaseURL(), log)
+		notifier.OnlyFailures = cfg.WebhookOnlyFailures
+		notifier.MaxAttempts = cfg.WebhookMaxAttempts
+		api.SetNotifier(notifier)
+		log.Infow("웹훅 알림 설정 완료", "targets", len(targets))
 	}
 
-	// 날짜별 로그 파일 경로 생성
-	timestamp := time.Now().Format("2006-01-02")
-	logPath := filepath.Join(logDir, fmt.Sprintf("loadtest_%s.log", timestamp))
+	// 직전 실행과의 자동 비교
+	if cfg.AutoCompare {
+		api.SetAutoCompare(true, compare.DefaultTolerances())
+	}
 
-	// zap 로거 초기화 (load-test 모듈에 구현)
-	loadtest.InitLogger(logPath)
+	// 경로 추출 방식 (기본: 내장 크롤러, scraper=node 이면 Puppeteer 스크래퍼)
+	orchestrator.SetScraperCommand(cfg.ScraperCommand)
+	if cfg.Scraper == "node" {
+		orchestrator.UseNodeScraper(cfg.ScraperScript, time.Duration(cfg.ScraperTimeout)*time.Second)
+	} else {
+		orchestrator.SetCrawlOptions(crawler.Options{
+			MaxDepth:     cfg.CrawlMaxDepth,
+			MaxPages:     cfg.CrawlMaxPages,
+			IgnoreRobots: cfg.CrawlIgnoreRobots,
+		})
+	}
 
-	// 루트 디렉토리의 logger 패키지 초기화
-	logger.Init()
+	// 탐색/재생 소스 문서를 읽을 수 있는 서버 디렉토리 (빈 값이면 URL과 content만 사용)
+	orchestrator.SetSourceDir(cfg.SourceDir)
 
-	// logger.Logger를 log 변수에 할당
-	log = logger.Logger
+	// 테스트 기본값
+	api.SetTestDefaults(cfg.DefaultRPS, cfg.DefaultDuration)
+	orchestrator.SetAutoTestDefaults(cfg.AutoTestRPS, cfg.AutoTestDuration)
+	loadtest.SetDefaultTimeout(time.Duration(cfg.RequestTimeout) * time.Second)
 
-	// 초기화 완료 로그
-	log.Info("애플리케이션 시작, 로깅 시스템 초기화 완료")
+	// 경로 분석 제공자 (OpenAI, OpenAI 호환 로컬 서버, Azure OpenAI, 모의 분석)
+	analyzer, err := ai.New(ai.Config{
+		Provider:   cfg.AIProvider,
+		APIKey:     os.Getenv("OPENAI_API_KEY"),
+		BaseURL:    cfg.AIBaseURL,
+		Model:      cfg.OpenAIModel,
+		APIVersion: cfg.AIAPIVersion,
+
+		ResponseFormat: cfg.AIResponseFormat,
+		MaxAttempts:    cfg.AIMaxAttempts,
+		Logger:         log,
+	})
+	if err != nil {
+		log.Fatalw("경로 분석 제공자 설정 오류", "error", err)
+	}
+	ai.SetAnalyzer(analyzer)
+	log.Infow("경로 분석 제공자 설정 완료", "provider", analyzer.Name(), "model", cfg.OpenAIModel, "baseURL", cfg.AIBaseURL)
+}
+
+// serverArgs는 서버 모드일 때 명령줄 인자를 반환 (첫 인자가 -로 시작하지 않으면 CLI 명령이므로 없음)
+func serverArgs(args []string) []string {
+	if len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		return args
+	}
+	return nil
+}
+
+// setupStorage는 실행 기록 저장소와 스케줄 저장소를 초기화
+// 실패하면 기록과 스케줄 기능 없이 실행합니다.
+func setupStorage(dir string) {
+	store, err := storage.NewFileStore(dir)
+	if err != nil {
+		log.Warnw("실행 기록 저장소 초기화 실패, 기록을 저장하지 않습니다", "error", err)
+		return
+	}
+	api.SetStore(store)
+	log.Infow("실행 기록 저장소 초기화 완료", "dir", dir)
+
+	// 원시 샘플 파일 (실행 기록 JSON과 분리해 압축 저장)
+	if sampleStore, err = samples.NewStore(filepath.Join(dir, "samples")); err != nil {
+		log.Warnw("샘플 저장소 초기화 실패, 원시 샘플을 실행 기록에 담습니다", "error", err)
+	} else {
+		api.SetSampleStore(sampleStore)
+	}
+
+	// 반복 테스트 스케줄 (실행 기록과 섞이지 않도록 하위 디렉토리에 저장)
+	scheduleStore, err := schedule.NewStore(filepath.Join(dir, "schedules"))
+	if err != nil {
+		log.Warnw("스케줄 저장소 초기화 실패, 스케줄 기능을 사용하지 않습니다", "error", err)
+		return
+	}
+	scheduler = schedule.New(scheduleStore, func(sc *schedule.Schedule) (string, error) {
+		return api.RunScheduled(logger.WithContext(context.Background(), log), sc)
+	})
+	api.SetScheduler(scheduler)
+}
+
+// runPriority는 테스트가 실행 대기열에서 받을 우선순위
+// 요청 본문의 priority는 누구나 지정할 수 있으므로 실행한 키의 최대 우선순위로 제한합니다 (인증이 꺼져 있으면 0 이하).
+func runPriority(req config.TestRequest) int {
+	var key *auth.Key
+	if authn != nil && req.Owner != "" {
+		key, _ = authn.Lookup(req.Owner)
+	}
+	return auth.CapPriority(key, req.Priority)
+}
+
+// loadSafetyPolicy는 서버 설정으로 안전 정책을 구성 (설정이 하나도 없으면 nil)
+// 설정 오류는 정책 없이 실행되지 않도록 종료합니다.
+func loadSafetyPolicy() *safety.Policy {
+	if cfg.TargetAllowlist == "" && cfg.TargetRPSLimits == "" && cfg.TargetMaxRPS <= 0 && cfg.VerifyAboveRPS <= 0 {
+		return nil
+	}
+
+	hosts, nets, err := safety.ParseAllowlist(cfg.TargetAllowlist)
+	if err != nil {
+		log.Fatalw("TARGET_ALLOWLIST 설정 오류", "error", err)
+	}
+	targetLimits, err := safety.ParseLimits(cfg.TargetRPSLimits)
+	if err != nil {
+		log.Fatalw("TARGET_RPS_LIMITS 설정 오류", "error", err)
+	}
+
+	secret := cfg.VerifySecret
+	if cfg.VerifyAboveRPS > 0 && secret == "" {
+		// 재시작하면 토큰이 바뀌므로 운영 환경에서는 VERIFY_SECRET을 지정해야 함
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			log.Fatalw("소유 확인 임시 비밀값 생성 실패 (VERIFY_SECRET을 지정하세요)", "error", err)
+		}
+		secret = hex.EncodeToString(buf)
+		log.Warn("VERIFY_SECRET이 없어 임시 비밀값을 사용합니다 (재시작하면 확인 토큰이 바뀝니다)")
+	}
+
+	return &safety.Policy{
+		AllowedHosts:   hosts,
+		AllowedNets:    nets,
+		MaxRPS:         cfg.TargetMaxRPS,
+		TargetMaxRPS:   targetLimits,
+		VerifyAboveRPS: cfg.VerifyAboveRPS,
+		Secret:         secret,
+	}
 }
 
 // main - 프로그램의 진입점이 되는 함수
@@ -84,17 +350,69 @@ func main() {
 	// 종료 시 로그 버퍼 비우기
 	defer log.Sync()
 
+	// 첫 인자가 명령이면 CLI 명령 실행 (예: go run . compare -base ID -candidate ID)
+	if len(os.Args) > 1 && serverArgs(os.Args[1:]) == nil {
+		code := runCLI(os.Args[1:])
+		log.Sync()
+		os.Exit(code)
+	}
+
+	// 원시 샘플을 메모리에 모으지 않고 실행별 파일에 바로 기록
+	if sampleStore != nil {
+		loadtest.SetSampleStore(sampleStore)
+	}
+
+	// 등록된 반복 테스트 스케줄 시작
+	if scheduler != nil {
+		if err := scheduler.Start(); err != nil {
+			log.Warnw("스케줄 시작 실패", "error", err)
+		}
+	}
+
 	// 서버 시작 로그
-	log.Info("서버 실행 중: http://localhost:8080")
-	// fmt.Println("🚀 서버 실행 중 : http://localhost:8080")
+	log.Infow("서버 실행 중", "listen", cfg.Listen, "url", cfg.BaseURL(), "tls", cfg.TLS())
 
 	// API 라우트 설정
 	http.HandleFunc("/test", api.HandleStartTest)
 	http.HandleFunc("/advanced-auto-test", api.HandleAdvancedAutoTest)
+	http.HandleFunc("/distributed-test", api.HandleDistributedTest)
+	http.HandleFunc("/openapi-test", api.HandleOpenAPITest)
+	http.HandleFunc("/replay-test", api.HandleReplayTest)
+	http.HandleFunc("/agents", api.HandleAgents)
+	http.HandleFunc("/safety/verification", api.HandleVerification)
+	http.HandleFunc("/auth/usage", api.HandleUsage)
+	http.HandleFunc("/queue", api.HandleQueue)
+	http.HandleFunc("/queue/{id}", api.HandleQueueJob)
+	http.HandleFunc("/schedules", api.HandleSchedules)
+	http.HandleFunc("/schedules/{id}", api.HandleSchedule)
+	http.HandleFunc("/schedules/{id}/run", api.HandleRunSchedule)
+	http.HandleFunc("/webhooks/test", api.HandleWebhookTest)
+	http.HandleFunc("/tests", api.HandleListTests)
+	http.HandleFunc("/tests/compare", api.HandleCompareTests)
+	http.HandleFunc("/tests/{id}", api.HandleGetTest)
+	http.HandleFunc("/tests/{id}/report", api.HandleTestReport)
+	http.HandleFunc("/tests/{id}/samples", api.HandleTestSamples)
+	http.HandleFunc("/tests/{id}/recompute", api.HandleRecomputeTest)
 
-	// 8080 포트에서 HTTP 서버 시작
-	err := http.ListenAndServe(":8080", nil)
-	if err != nil {
+	// 실시간 부하 생성기 지표 (Prometheus 스크레이프용)
+	http.Handle("/metrics", metrics.Default.Handler())
+
+	// API 키 인증 미들웨어 (설정된 경우), 브라우저 사전 요청은 인증 전에 처리
+	var handler http.Handler = http.DefaultServeMux
+	if authn != nil {
+		handler = authn.Middleware(handler)
+	}
+	handler = api.WithCORS(cfg.CORSOrigins, handler)
+	handler = api.WithLogger(log, handler)
+
+	// HTTP 서버 시작 (종료 신호를 받으면 실행 중인 테스트를 정리한 뒤 종료)
+	srv := &http.Server{
+		Addr:              cfg.Listen,
+		Handler:           handler,
+		ReadHeaderTimeout: time.Duration(cfg.ReadHeaderTimeout) * time.Second,
+		IdleTimeout:       time.Duration(cfg.IdleTimeout) * time.Second,
+	}
+	if err := serve(srv, cfg.TLSCertFile, cfg.TLSKeyFile); err != nil {
 		log.Fatalw("서버 실행 실패", "error", err)
 	}
 }
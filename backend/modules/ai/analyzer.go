@@ -6,9 +6,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"time"
 
-	"github.com/sashabaranov/go-openai"
+	"github.com/Mr-Muji/LoadTest/libs/logger"
 )
 
 // PathRecommendation은 GPT가 추천하는 경로 정보를 담는 구조체
@@ -38,28 +37,48 @@ type WebsiteAnalysisResult struct {
 	RecommendedPaths []PathRecommendation `json:"recommendedPaths"` // 부하 테스트 우선순위 경로
 }
 
+// Analyzer는 웹사이트 분석을 수행하는 LLM 제공자
+// OpenAI 호환 API, Azure OpenAI, 외부 호출 없는 모의 분석기가 있으며 New로 설정에 맞는 것을 만듭니다.
+type Analyzer interface {
+	// Name은 로그에 표시할 제공자 이름
+	Name() string
+	// Analyze는 URL과 추출한 경로 목록을 분석
+	Analyze(ctx context.Context, url string, extractedPaths []string) (*WebsiteAnalysisResult, error)
+}
+
+// analyzer는 AnalyzeWebsite가 사용하는 분석기 (nil이면 OPENAI_API_KEY로 OpenAI 사용)
+var analyzer Analyzer
+
+// SetAnalyzer는 AnalyzeWebsite가 사용할 분석기를 설정
+func SetAnalyzer(a Analyzer) {
+	analyzer = a
+}
+
 // AnalyzeWebsite는 URL과 경로 목록을 분석하여 모든 정보를 한 번에 반환하는 함수
-func AnalyzeWebsite(url string, extractedPaths []string) (*WebsiteAnalysisResult, error) {
-	// OpenAI API 키 확인
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("OPENAI_API_KEY 환경변수가 설정되지 않았습니다")
+func AnalyzeWebsite(ctx context.Context, url string, extractedPaths []string) (*WebsiteAnalysisResult, error) {
+	a := analyzer
+	if a == nil {
+		a = NewOpenAI(Config{APIKey: os.Getenv("OPENAI_API_KEY"), Logger: logger.FromContext(ctx)})
 	}
+	return a.Analyze(ctx, url, extractedPaths)
+}
 
-	// OpenAI 클라이언트 생성
-	client := openai.NewClient(apiKey)
-	ctx := context.Background()
+// systemPrompt는 모든 제공자에 공통으로 전달하는 역할 지시
+const systemPrompt = "You are a web security and performance testing expert. You analyze websites and recommend test strategies. Always respond with valid JSON and make sure to write the analysis part in Korean language."
 
+// buildPrompt는 분석 요청 프롬프트를 작성 (영어로)
+func buildPrompt(url string, extractedPaths []string) string {
 	// 경로 정보를 문자열로 변환
 	pathsInfo := strings.Join(extractedPaths, "\n- ")
 	pathsInfo = "- " + pathsInfo
 
-	// GPT에 전달할 프롬프트 작성 (영어로)
-	prompt := fmt.Sprintf(`Website URL: %s
+	return fmt.Sprintf(`Website URL: %s
 	
 Discovered API endpoints:
 %s
 
+Paths containing {param} segments (e.g. /api/users/{id}) are templates that group many concrete paths differing only by an identifier. Recommend them as-is; keep the placeholders in "paths".
+
 You are a web application analysis expert. Analyze the website and API endpoints above and provide:
 
 1. A comprehensive analysis in Korean language (within 500 characters). Explain what kind of service this website is, what API structure it has, and what types of tests would be useful.
@@ -98,56 +117,36 @@ You are a web application analysis expert. Analyze the website and API endpoints
   ]
 }
 
-Specify path priorities from 1 (highest) to 5 (lowest), and provide at least 3 different test types.`, url, pathsInfo)
-
-	// API 요청 시작 시간 기록
-	requestStart := time.Now()
-
-	// ChatGPT API 호출
-	response, err := client.CreateChatCompletion(
-		ctx,
-		openai.ChatCompletionRequest{
-			Model: openai.GPT4o,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleSystem,
-					Content: "You are a web security and performance testing expert. You analyze websites and recommend test strategies. Always respond with valid JSON and make sure to write the analysis part in Korean language.",
-				},
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: prompt,
-				},
-			},
-			Temperature: 0.2, // 낮은 온도로 일관된 응답 유도
-		},
-	)
-
-	if err != nil {
-		return nil, fmt.Errorf("OpenAI API 호출 중 오류: %v", err)
-	}
-
-	// API 요청 시간 계산 및 로그 출력
-	requestDuration := time.Since(requestStart)
-	fmt.Printf("OpenAI API 요청 소요 시간: %v\n", requestDuration)
+Specify path priorities from 1 (highest) to 5 (lowest), and provide at least 3 different test types.
 
-	// 응답 내용
-	content := response.Choices[0].Message.Content
-
-	// 응답에서 JSON 부분 추출
-	jsonStart := strings.Index(content, "{")
-	jsonEnd := strings.LastIndex(content, "}")
+Constraints:
+- Use only paths from the discovered list above, written exactly as listed.
+- Test types must be one of: %s.
+- rps must be greater than 0, and duration must be between %d and %d seconds.`, url, pathsInfo, strings.Join(TestTypes, ", "), MinTestDuration, MaxTestDuration)
+}
 
-	if jsonStart == -1 || jsonEnd == -1 || jsonEnd <= jsonStart {
-		return nil, fmt.Errorf("응답에서 유효한 JSON을 찾을 수 없습니다: %s", content)
+// parseResult는 응답 본문을 분석 결과로 해석
+// 구조화된 출력/JSON 모드는 본문 전체가 JSON이지만, 일부 호환 서버는 코드 블록(```json)으로 감싸므로 그 표시만 벗겨 냅니다.
+func parseResult(content string) (*WebsiteAnalysisResult, error) {
+	body := strings.TrimSpace(content)
+	if strings.HasPrefix(body, "```") {
+		body = strings.TrimPrefix(body, "```json")
+		body = strings.TrimPrefix(body, "```")
+		body = strings.TrimSuffix(body, "```")
+		body = strings.TrimSpace(body)
 	}
 
-	jsonStr := content[jsonStart : jsonEnd+1]
-
-	// JSON 파싱
 	var result WebsiteAnalysisResult
-	if err := json.Unmarshal([]byte(jsonStr), &result); err != nil {
-		return nil, fmt.Errorf("JSON 파싱 중 오류: %v, JSON: %s", err, jsonStr)
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		return nil, fmt.Errorf("response is not valid JSON for the schema: %v", err)
 	}
-
 	return &result, nil
 }
+
+// reaskPrompt는 유효하지 않은 응답을 고쳐 달라는 요청 (err는 해석/검사 오류)
+func reaskPrompt(err error) string {
+	return fmt.Sprintf(`Your previous response was rejected for the following reasons:
+%s
+
+Return the complete corrected JSON object only, following the same structure and constraints.`, err)
+}
@@ -0,0 +1,65 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// mockAnalyzer는 외부 호출 없이 경로 목록만으로 항상 같은 결과를 만드는 분석기
+// API 키가 없거나 외부로 데이터를 보낼 수 없는 환경, 자동화 테스트에서 사용합니다.
+type mockAnalyzer struct{}
+
+// NewMock은 모의 분석기를 생성
+func NewMock() Analyzer {
+	return mockAnalyzer{}
+}
+
+// Name은 제공자 이름
+func (mockAnalyzer) Name() string {
+	return "mock"
+}
+
+// Analyze는 경로 순서대로 우선순위를 매기고 부하/스트레스/기능 테스트를 하나씩 추천
+// 입력이 같으면 결과도 항상 같습니다.
+func (mockAnalyzer) Analyze(ctx context.Context, url string, extractedPaths []string) (*WebsiteAnalysisResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// 중복을 제외한 경로 수는 모두 세고, 추천에는 상위 5개 경로만 사용
+	var paths []string
+	seen := make(map[string]bool)
+	for _, p := range extractedPaths {
+		if p = strings.TrimSpace(p); p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		if len(paths) < 5 {
+			paths = append(paths, p)
+		}
+	}
+	if len(paths) == 0 {
+		paths = []string{"/"}
+	}
+
+	result := &WebsiteAnalysisResult{
+		Analysis: fmt.Sprintf("모의 분석 결과입니다. %s 에서 경로 %d개를 찾았으며 앞쪽 경로부터 우선순위를 매겼습니다.", url, len(seen)),
+	}
+	for i, p := range paths {
+		result.RecommendedPaths = append(result.RecommendedPaths, PathRecommendation{
+			Path:        p,
+			Method:      "GET",
+			Priority:    i + 1,
+			Reason:      "모의 분석: 발견 순서",
+			RPS:         10,
+			Description: "모의 분석 추천 경로",
+		})
+	}
+	result.RecommendedTests = []TestRecommendation{
+		{Type: "load", Paths: paths, Method: "GET", RPS: 5, Duration: 10, Description: "모의 분석: 추천 경로 부하 테스트"},
+		{Type: "stress", Paths: paths[:1], Method: "GET", RPS: 20, Duration: 10, Description: "모의 분석: 최우선 경로 스트레스 테스트"},
+		{Type: "functional", Paths: paths, Method: "GET", RPS: 1, Duration: 5, Description: "모의 분석: 추천 경로 응답 확인"},
+	}
+	return result, nil
+}
@@ -0,0 +1,98 @@
+package ai
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMockIsDeterministicAndValid(t *testing.T) {
+	paths := []string{"/api/users", "/api/orders", "/api/users", " ", "/login", "/search", "/cart", "/checkout"}
+	a := NewMock()
+	first, err := a.Analyze(context.Background(), "https://example.com", paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := a.Analyze(context.Background(), "https://example.com", paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Error("mock analysis differs for the same input")
+	}
+
+	// 중복과 빈 경로를 빼고 발견 순서대로 상위 5개
+	var got []string
+	for _, p := range first.RecommendedPaths {
+		got = append(got, p.Path)
+	}
+	want := []string{"/api/users", "/api/orders", "/login", "/search", "/cart"}
+	if !reflect.DeepEqual(got, want) || first.RecommendedPaths[0].Priority != 1 {
+		t.Errorf("recommended paths = %v, want %v", got, want)
+	}
+	// 추천은 5개까지지만 찾은 경로 수는 중복을 뺀 전체 (6개)
+	if !strings.Contains(first.Analysis, "경로 6개") {
+		t.Errorf("analysis = %q, want 6 discovered paths", first.Analysis)
+	}
+	// 실제 제공자 응답과 같은 검사를 통과해야 함
+	if err := validateResult(first, paths); err != nil {
+		t.Errorf("mock result fails validation: %v", err)
+	}
+}
+
+func TestMockWithoutPaths(t *testing.T) {
+	result, err := NewMock().Analyze(context.Background(), "https://example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.RecommendedPaths) != 1 || result.RecommendedPaths[0].Path != "/" {
+		t.Errorf("recommended paths = %+v, want only /", result.RecommendedPaths)
+	}
+}
+
+func TestMockHonorsCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewMock().Analyze(ctx, "https://example.com", []string{"/"}); err == nil {
+		t.Error("Analyze with a canceled context succeeded")
+	}
+}
+
+func TestNewSelectsProvider(t *testing.T) {
+	for _, c := range []struct {
+		cfg  Config
+		name string
+	}{
+		{Config{}, ProviderOpenAI},
+		{Config{Provider: ProviderMock}, ProviderMock},
+		{Config{Provider: ProviderAzure, BaseURL: "https://r.openai.azure.com", Model: "gpt-4o"}, ProviderAzure},
+	} {
+		a, err := New(c.cfg)
+		if err != nil {
+			t.Errorf("New(%+v) = %v", c.cfg, err)
+			continue
+		}
+		if a.Name() != c.name {
+			t.Errorf("New(%+v).Name() = %q, want %q", c.cfg, a.Name(), c.name)
+		}
+	}
+
+	for _, cfg := range []Config{
+		{Provider: "bedrock"},
+		{Provider: ProviderAzure, Model: "gpt-4o"},
+		{Provider: ProviderAzure, BaseURL: "https://r.openai.azure.com"},
+		{ResponseFormat: "xml"},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(%+v) succeeded, want error", cfg)
+		}
+	}
+}
+
+func TestOpenAIRequiresKeyWithoutBaseURL(t *testing.T) {
+	_, err := NewOpenAI(Config{}).Analyze(context.Background(), "https://example.com", []string{"/"})
+	if err == nil {
+		t.Error("Analyze without an API key or base URL succeeded")
+	}
+}
@@ -0,0 +1,195 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/libs/logger"
+	"github.com/sashabaranov/go-openai"
+	"go.uber.org/zap"
+)
+
+// DefaultModel은 모델을 지정하지 않았을 때 사용하는 GPT 모델
+const DefaultModel = openai.GPT4o
+
+// DefaultAzureAPIVersion은 Azure OpenAI API 버전을 지정하지 않았을 때 사용하는 값 (구조화된 출력 지원 버전)
+const DefaultAzureAPIVersion = "2024-10-21"
+
+// DefaultMaxAttempts는 응답이 유효하지 않을 때 다시 요청하는 것을 포함한 최대 호출 횟수
+const DefaultMaxAttempts = 3
+
+// 응답 형식 (Config.ResponseFormat)
+const (
+	FormatJSONSchema = "json_schema" // 구조화된 출력: 결과 스키마를 강제 (기본값)
+	FormatJSONObject = "json_object" // JSON 모드: 올바른 JSON만 보장 (스키마를 지원하지 않는 서버용)
+)
+
+// chatAnalyzer는 Chat Completions API로 분석 (OpenAI 호환 API와 Azure OpenAI 공통)
+type chatAnalyzer struct {
+	name        string
+	client      *openai.Client
+	model       string // 모델 이름 (Azure는 배포 이름)
+	format      *openai.ChatCompletionResponseFormat
+	maxAttempts int
+	log         *zap.SugaredLogger
+	// ready는 호출 전에 설정을 검사 (API 키 누락 등, nil이면 검사하지 않음)
+	ready func() error
+}
+
+// newChatAnalyzer는 공통 설정(모델, 응답 형식, 재요청 횟수)을 적용한 분석기를 생성
+func newChatAnalyzer(name string, clientConfig openai.ClientConfig, cfg Config) *chatAnalyzer {
+	a := &chatAnalyzer{
+		name:        name,
+		client:      openai.NewClientWithConfig(clientConfig),
+		model:       cfg.Model,
+		maxAttempts: cfg.MaxAttempts,
+		log:         cfg.Logger,
+	}
+	if a.log == nil {
+		a.log = logger.Nop()
+	}
+	if a.model == "" {
+		a.model = DefaultModel
+	}
+	if a.maxAttempts <= 0 {
+		a.maxAttempts = DefaultMaxAttempts
+	}
+	if cfg.ResponseFormat == FormatJSONObject {
+		a.format = &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+	} else {
+		a.format = &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+				Name:   "website_analysis",
+				Schema: &resultSchema,
+				Strict: true,
+			},
+		}
+	}
+	return a
+}
+
+// NewOpenAI는 OpenAI 또는 OpenAI 호환 API(Ollama, vLLM 등)를 사용하는 분석기를 생성
+// BaseURL이 비어 있으면 OpenAI를 사용하며 이때는 API 키가 필요합니다.
+// 로컬 서버는 보통 키를 확인하지 않으므로 BaseURL을 지정하면 키 없이도 호출합니다.
+func NewOpenAI(cfg Config) Analyzer {
+	clientConfig := openai.DefaultConfig(cfg.APIKey)
+	if cfg.BaseURL != "" {
+		clientConfig.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	}
+	a := newChatAnalyzer(ProviderOpenAI, clientConfig, cfg)
+	a.ready = func() error {
+		if cfg.APIKey == "" && cfg.BaseURL == "" {
+			return fmt.Errorf("OPENAI_API_KEY 환경변수가 설정되지 않았습니다")
+		}
+		return nil
+	}
+	return a
+}
+
+// NewAzure는 Azure OpenAI를 사용하는 분석기를 생성
+// BaseURL은 리소스 주소(https://<리소스>.openai.azure.com), Model은 모델 배포 이름입니다.
+func NewAzure(cfg Config) Analyzer {
+	clientConfig := openai.DefaultAzureConfig(cfg.APIKey, strings.TrimRight(cfg.BaseURL, "/"))
+	clientConfig.APIVersion = cfg.APIVersion
+	if clientConfig.APIVersion == "" {
+		clientConfig.APIVersion = DefaultAzureAPIVersion
+	}
+	// 모델 이름을 그대로 배포 이름으로 사용 (기본 변환은 점을 지워 배포 이름과 달라짐)
+	clientConfig.AzureModelMapperFunc = func(model string) string { return model }
+	a := newChatAnalyzer(ProviderAzure, clientConfig, cfg)
+	a.ready = func() error {
+		if cfg.APIKey == "" {
+			return fmt.Errorf("Azure OpenAI API 키가 설정되지 않았습니다 (OPENAI_API_KEY)")
+		}
+		return nil
+	}
+	return a
+}
+
+// Name은 제공자 이름
+func (a *chatAnalyzer) Name() string {
+	return a.name
+}
+
+// Analyze는 Chat Completions API로 분석을 요청하고 결과를 검사
+// 응답을 해석할 수 없거나 검사에 실패하면 오류 목록을 대화에 덧붙여 maxAttempts번까지 다시 요청합니다.
+func (a *chatAnalyzer) Analyze(ctx context.Context, url string, extractedPaths []string) (*WebsiteAnalysisResult, error) {
+	if a.ready != nil {
+		if err := a.ready(); err != nil {
+			return nil, err
+		}
+	}
+
+	messages := []openai.ChatCompletionMessage{
+		{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: systemPrompt,
+		},
+		{
+			Role:    openai.ChatMessageRoleUser,
+			Content: buildPrompt(url, extractedPaths),
+		},
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= a.maxAttempts; attempt++ {
+		// API 요청 시작 시간 기록
+		requestStart := time.Now()
+
+		response, err := a.client.CreateChatCompletion(
+			ctx,
+			openai.ChatCompletionRequest{
+				Model:          a.model,
+				Messages:       messages,
+				ResponseFormat: a.format,
+				Temperature:    0.2, // 낮은 온도로 일관된 응답 유도
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s API 호출 중 오류: %v", a.name, err)
+		}
+		if len(response.Choices) == 0 {
+			return nil, fmt.Errorf("%s API 응답에 결과가 없습니다", a.name)
+		}
+
+		// API 요청 시간 로그
+		a.log.Infow("LLM 분석 요청 완료",
+			"provider", a.name,
+			"model", a.model,
+			"attempt", attempt,
+			"paths", len(extractedPaths),
+			"elapsed", time.Since(requestStart).String(),
+			"totalTokens", response.Usage.TotalTokens,
+		)
+
+		choice := response.Choices[0]
+		if choice.Message.Refusal != "" {
+			return nil, fmt.Errorf("%s 모델이 분석을 거부했습니다: %s", a.name, choice.Message.Refusal)
+		}
+		result, err := parseResult(choice.Message.Content)
+		if err == nil {
+			err = validateResult(result, extractedPaths)
+		}
+		if err == nil {
+			return result, nil
+		}
+
+		lastErr = err
+		a.log.Warnw("LLM 분석 결과가 유효하지 않습니다",
+			"provider", a.name,
+			"attempt", attempt,
+			"maxAttempts", a.maxAttempts,
+			"finishReason", choice.FinishReason,
+			"error", err,
+		)
+		// 직전 응답과 오류를 알려 주고 고친 결과를 다시 요청
+		messages = append(messages,
+			openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: choice.Message.Content},
+			openai.ChatCompletionMessage{Role: openai.ChatMessageRoleUser, Content: reaskPrompt(err)},
+		)
+	}
+	return nil, fmt.Errorf("%s 분석 결과가 %d번 요청 후에도 유효하지 않습니다: %v", a.name, a.maxAttempts, lastErr)
+}
@@ -0,0 +1,52 @@
+package ai
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// 분석 제공자 (Config.Provider)
+const (
+	ProviderOpenAI = "openai" // OpenAI 또는 OpenAI 호환 API (BaseURL로 Ollama, vLLM 등 지정)
+	ProviderAzure  = "azure"  // Azure OpenAI
+	ProviderMock   = "mock"   // 외부 호출 없는 모의 분석
+)
+
+// Config는 분석 제공자 설정
+type Config struct {
+	Provider   string // openai, azure, mock (빈 값이면 openai)
+	APIKey     string // API 키 (OpenAI 호환 로컬 서버는 생략 가능)
+	BaseURL    string // OpenAI 호환 API 주소 또는 Azure 리소스 주소
+	Model      string // 모델 이름 (Azure는 배포 이름)
+	APIVersion string // Azure OpenAI API 버전 (빈 값이면 DefaultAzureAPIVersion)
+
+	ResponseFormat string // json_schema 또는 json_object (빈 값이면 json_schema)
+	MaxAttempts    int    // 유효하지 않은 응답에 다시 요청하는 것을 포함한 최대 호출 횟수 (0이면 DefaultMaxAttempts)
+
+	Logger *zap.SugaredLogger // 요청 시간과 재요청 로그 (nil이면 출력하지 않음)
+}
+
+// New는 설정에 맞는 분석기를 생성
+func New(cfg Config) (Analyzer, error) {
+	switch cfg.ResponseFormat {
+	case "", FormatJSONSchema, FormatJSONObject:
+	default:
+		return nil, fmt.Errorf("지원하지 않는 응답 형식: %q (json_schema, json_object)", cfg.ResponseFormat)
+	}
+	switch cfg.Provider {
+	case "", ProviderOpenAI:
+		return NewOpenAI(cfg), nil
+	case ProviderAzure:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("Azure OpenAI는 리소스 주소(BaseURL)가 필요합니다")
+		}
+		if cfg.Model == "" {
+			return nil, fmt.Errorf("Azure OpenAI는 배포 이름(Model)이 필요합니다")
+		}
+		return NewAzure(cfg), nil
+	case ProviderMock:
+		return NewMock(), nil
+	}
+	return nil, fmt.Errorf("지원하지 않는 분석 제공자: %q (openai, azure, mock)", cfg.Provider)
+}
@@ -0,0 +1,150 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+// TestTypes는 추천 테스트 유형으로 허용하는 값
+var TestTypes = []string{"load", "stress", "spike", "soak", "security", "functional"}
+
+// 추천 값의 허용 범위
+const (
+	MinPriority     = 1   // 가장 높은 우선순위
+	MaxPriority     = 5   // 가장 낮은 우선순위
+	MinTestDuration = 5   // 추천 테스트 최소 실행 시간(초)
+	MaxTestDuration = 600 // 추천 테스트 최대 실행 시간(초)
+)
+
+// methods는 추천 경로/테스트의 HTTP 메서드로 허용하는 값
+var methods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// resultSchema는 WebsiteAnalysisResult의 JSON 스키마 (구조화된 출력용)
+// strict 모드는 모든 필드가 필수이고 추가 필드가 없어야 하며 숫자 범위는 표현할 수 없어 설명에 적고 validateResult로 검사합니다.
+var resultSchema = jsonschema.Definition{
+	Type:                 jsonschema.Object,
+	AdditionalProperties: false,
+	Required:             []string{"analysis", "recommendedPaths", "recommendedTests"},
+	Properties: map[string]jsonschema.Definition{
+		"analysis": {
+			Type:        jsonschema.String,
+			Description: "Analysis of the service and its API structure, in Korean, within 500 characters",
+		},
+		"recommendedPaths": {
+			Type: jsonschema.Array,
+			Items: &jsonschema.Definition{
+				Type:                 jsonschema.Object,
+				AdditionalProperties: false,
+				Required:             []string{"path", "method", "priority", "reason", "rps", "description"},
+				Properties: map[string]jsonschema.Definition{
+					"path":        {Type: jsonschema.String, Description: "One of the discovered paths, exactly as listed"},
+					"method":      {Type: jsonschema.String, Enum: methods},
+					"priority":    {Type: jsonschema.Integer, Description: "1 (highest) to 5 (lowest)"},
+					"reason":      {Type: jsonschema.String},
+					"rps":         {Type: jsonschema.Integer, Description: "Recommended requests per second, greater than 0"},
+					"description": {Type: jsonschema.String},
+				},
+			},
+		},
+		"recommendedTests": {
+			Type: jsonschema.Array,
+			Items: &jsonschema.Definition{
+				Type:                 jsonschema.Object,
+				AdditionalProperties: false,
+				Required:             []string{"type", "paths", "method", "rps", "duration", "description"},
+				Properties: map[string]jsonschema.Definition{
+					"type":        {Type: jsonschema.String, Enum: TestTypes},
+					"paths":       {Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.String}, Description: "Discovered paths to test, exactly as listed"},
+					"method":      {Type: jsonschema.String, Enum: methods},
+					"rps":         {Type: jsonschema.Integer, Description: "Requests per second, greater than 0"},
+					"duration":    {Type: jsonschema.Integer, Description: fmt.Sprintf("Test duration in seconds, %d to %d", MinTestDuration, MaxTestDuration)},
+					"description": {Type: jsonschema.String},
+				},
+			},
+		},
+	},
+}
+
+// validateResult는 분석 결과가 허용 범위 안에 있는지 검사하고 모든 오류를 모아 반환
+// discovered가 비어 있지 않으면 추천 경로가 모두 발견한 경로 중 하나여야 합니다.
+// 오류 메시지는 모델에게 다시 요청할 때 그대로 전달하므로 영어로 작성합니다.
+func validateResult(result *WebsiteAnalysisResult, discovered []string) error {
+	var errs []error
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	// 끝의 / 차이는 같은 경로로 취급
+	known := make(map[string]bool, len(discovered))
+	for _, p := range discovered {
+		known[normalizePath(p)] = true
+	}
+	checkPath := func(field, p string) {
+		if strings.TrimSpace(p) == "" {
+			add("%s: path is empty", field)
+		} else if len(known) > 0 && !known[normalizePath(p)] {
+			add("%s: %q is not one of the discovered paths", field, p)
+		}
+	}
+	checkMethod := func(field, m string) {
+		if !contains(methods, strings.ToUpper(m)) {
+			add("%s: unknown method %q (allowed: %s)", field, m, strings.Join(methods, ", "))
+		}
+	}
+
+	if strings.TrimSpace(result.Analysis) == "" {
+		add("analysis: must not be empty")
+	}
+	for i, p := range result.RecommendedPaths {
+		field := fmt.Sprintf("recommendedPaths[%d]", i)
+		checkPath(field+".path", p.Path)
+		checkMethod(field+".method", p.Method)
+		if p.Priority < MinPriority || p.Priority > MaxPriority {
+			add("%s.priority: must be between %d and %d (got %d)", field, MinPriority, MaxPriority, p.Priority)
+		}
+		if p.RPS <= 0 {
+			add("%s.rps: must be greater than 0 (got %d)", field, p.RPS)
+		}
+	}
+	if len(result.RecommendedTests) == 0 {
+		add("recommendedTests: at least one test is required")
+	}
+	for i, t := range result.RecommendedTests {
+		field := fmt.Sprintf("recommendedTests[%d]", i)
+		if !contains(TestTypes, strings.ToLower(t.Type)) {
+			add("%s.type: unknown test type %q (allowed: %s)", field, t.Type, strings.Join(TestTypes, ", "))
+		}
+		if len(t.Paths) == 0 {
+			add("%s.paths: at least one path is required", field)
+		}
+		for j, p := range t.Paths {
+			checkPath(fmt.Sprintf("%s.paths[%d]", field, j), p)
+		}
+		checkMethod(field+".method", t.Method)
+		if t.RPS <= 0 {
+			add("%s.rps: must be greater than 0 (got %d)", field, t.RPS)
+		}
+		if t.Duration < MinTestDuration || t.Duration > MaxTestDuration {
+			add("%s.duration: must be between %d and %d seconds (got %d)", field, MinTestDuration, MaxTestDuration, t.Duration)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// normalizePath는 비교용으로 앞뒤 공백과 끝의 /를 제거
+func normalizePath(p string) string {
+	return strings.TrimRight(strings.TrimSpace(p), "/")
+}
+
+// contains는 목록에 값이 있는지 반환
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+var discovered = []string{"/", "/api/users", "/api/orders/"}
+
+func validResult() *WebsiteAnalysisResult {
+	return &WebsiteAnalysisResult{
+		Analysis: "사용자와 주문 API가 있습니다.",
+		RecommendedPaths: []PathRecommendation{
+			{Path: "/api/users", Method: "GET", Priority: 1, RPS: 10},
+		},
+		RecommendedTests: []TestRecommendation{
+			{Type: "load", Paths: []string{"/api/orders"}, Method: "get", RPS: 5, Duration: 30},
+		},
+	}
+}
+
+func TestValidateResultAcceptsValid(t *testing.T) {
+	// 끝의 / 차이와 메서드 대소문자는 허용
+	if err := validateResult(validResult(), discovered); err != nil {
+		t.Errorf("validateResult = %v", err)
+	}
+	// 발견한 경로가 없으면 경로 목록은 검사하지 않음
+	r := validResult()
+	r.RecommendedPaths[0].Path = "/anything"
+	if err := validateResult(r, nil); err != nil {
+		t.Errorf("validateResult without discovered paths = %v", err)
+	}
+}
+
+func TestValidateResultReportsEveryError(t *testing.T) {
+	r := validResult()
+	r.Analysis = " "
+	r.RecommendedPaths[0] = PathRecommendation{Path: "/admin", Method: "FETCH", Priority: 9, RPS: 0}
+	r.RecommendedTests = append(r.RecommendedTests, TestRecommendation{Type: "chaos", Method: "GET", RPS: -1, Duration: 3600})
+
+	err := validateResult(r, discovered)
+	if err == nil {
+		t.Fatal("validateResult succeeded, want errors")
+	}
+	for _, want := range []string{
+		"analysis: must not be empty",
+		`recommendedPaths[0].path: "/admin" is not one of the discovered paths`,
+		`recommendedPaths[0].method: unknown method "FETCH"`,
+		"recommendedPaths[0].priority: must be between 1 and 5 (got 9)",
+		"recommendedPaths[0].rps: must be greater than 0",
+		`recommendedTests[1].type: unknown test type "chaos"`,
+		"recommendedTests[1].paths: at least one path is required",
+		"recommendedTests[1].rps: must be greater than 0",
+		"recommendedTests[1].duration: must be between 5 and 600 seconds (got 3600)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("errors do not mention %q:\n%v", want, err)
+		}
+	}
+
+	r = validResult()
+	r.RecommendedTests = nil
+	if err := validateResult(r, discovered); err == nil || !strings.Contains(err.Error(), "at least one test") {
+		t.Errorf("validateResult without tests = %v", err)
+	}
+}
+
+func TestParseResult(t *testing.T) {
+	body, _ := json.Marshal(validResult())
+	for _, content := range []string{
+		string(body),
+		"```json\n" + string(body) + "\n```",
+		"```\n" + string(body) + "\n```",
+	} {
+		r, err := parseResult(content)
+		if err != nil || r.RecommendedPaths[0].Path != "/api/users" {
+			t.Errorf("parseResult(%.20q) = %+v, %v", content, r, err)
+		}
+	}
+	if _, err := parseResult("분석 결과는 다음과 같습니다"); err == nil {
+		t.Error("parseResult of plain text succeeded")
+	}
+}
+
+// chatServer는 replies를 차례로 응답하는 OpenAI 호환 서버 (받은 요청 본문을 기록)
+func chatServer(t *testing.T, replies ...string) (*httptest.Server, func() []openai.ChatCompletionRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var got []openai.ChatCompletionRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openai.ChatCompletionRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		got = append(got, req)
+		reply := replies[min(len(got), len(replies))-1]
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openai.ChatCompletionResponse{
+			Choices: []openai.ChatCompletionChoice{{
+				Message:      openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: reply},
+				FinishReason: openai.FinishReasonStop,
+			}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []openai.ChatCompletionRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]openai.ChatCompletionRequest(nil), got...)
+	}
+}
+
+func TestAnalyzeReasksUntilValid(t *testing.T) {
+	invalid := validResult()
+	invalid.RecommendedPaths[0].Priority = 9
+	bad, _ := json.Marshal(invalid)
+	good, _ := json.Marshal(validResult())
+	srv, requests := chatServer(t, string(bad), string(good))
+
+	a := NewOpenAI(Config{BaseURL: srv.URL, Model: "local"})
+	result, err := a.Analyze(context.Background(), "https://example.com", discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RecommendedPaths[0].Priority != 1 {
+		t.Errorf("result = %+v, want the corrected response", result)
+	}
+
+	got := requests()
+	if len(got) != 2 {
+		t.Fatalf("requests = %d, want 2", len(got))
+	}
+	// 구조화된 출력 스키마를 요청하고, 다시 요청할 때는 직전 응답과 오류를 덧붙임
+	if f := got[0].ResponseFormat; f == nil || f.Type != openai.ChatCompletionResponseFormatTypeJSONSchema || f.JSONSchema == nil || !f.JSONSchema.Strict {
+		t.Errorf("response format = %+v", got[0].ResponseFormat)
+	}
+	msgs := got[1].Messages
+	if len(msgs) != 4 || msgs[2].Content != string(bad) || !strings.Contains(msgs[3].Content, "priority: must be between 1 and 5") {
+		t.Errorf("re-ask messages = %+v", msgs)
+	}
+}
+
+func TestAnalyzeGivesUpAfterMaxAttempts(t *testing.T) {
+	srv, requests := chatServer(t, "not json")
+	a := NewOpenAI(Config{BaseURL: srv.URL, Model: "local", MaxAttempts: 2, ResponseFormat: FormatJSONObject})
+	if _, err := a.Analyze(context.Background(), "https://example.com", discovered); err == nil || !strings.Contains(err.Error(), "2번") {
+		t.Errorf("Analyze = %v, want failure after 2 attempts", err)
+	}
+	got := requests()
+	if len(got) != 2 {
+		t.Errorf("requests = %d, want 2", len(got))
+	}
+	if f := got[0].ResponseFormat; f == nil || f.Type != openai.ChatCompletionResponseFormatTypeJSONObject {
+		t.Errorf("response format = %+v, want json_object", f)
+	}
+}
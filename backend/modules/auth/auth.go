@@ -0,0 +1,273 @@
+// Package auth는 API 키 인증과 키별 사용량 한도(동시 실행 수, 일일 요청 예산)를 제공
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// Key는 API 키 하나의 설정
+type Key struct {
+	Name          string // 키 이름 (실행 기록의 Owner로 저장, 예: team-a)
+	MaxConcurrent int    // 동시에 실행할 수 있는 최대 테스트 수 (0이면 제한 없음)
+	DailyBudget   int    // 하루(UTC)에 보낼 수 있는 최대 요청 수 (RPS × 시간 합계, 0이면 제한 없음)
+	MaxPriority   int    // 실행 대기열에서 지정할 수 있는 최대 우선순위 (더 높게 요청하면 이 값으로 낮춤)
+}
+
+// CapPriority는 요청한 우선순위를 키의 최대 우선순위로 제한
+// 키가 없으면(인증이 꺼져 있거나 등록되지 않은 키) 누구나 지정할 수 있는 값이므로 0보다 높일 수 없습니다.
+func CapPriority(key *Key, priority int) int {
+	if key == nil {
+		return min(priority, 0)
+	}
+	return min(priority, key.MaxPriority)
+}
+
+// Usage는 키의 현재 사용량
+type Usage struct {
+	Name          string `json:"name"`          // 키 이름
+	Running       int    `json:"running"`       // 실행 중인 테스트 수
+	MaxConcurrent int    `json:"maxConcurrent"` // 최대 동시 실행 수 (0이면 제한 없음)
+	UsedToday     int    `json:"usedToday"`     // 오늘 사용한 요청 예산
+	DailyBudget   int    `json:"dailyBudget"`   // 일일 요청 예산 (0이면 제한 없음)
+}
+
+// QuotaError는 사용량 한도 초과 (API는 429로 응답)
+type QuotaError struct {
+	Key    string
+	Reason string
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("사용량 한도 초과 (%s): %s", e.Key, e.Reason)
+}
+
+// usage는 키별 사용량 상태
+type usage struct {
+	running int
+	day     string // 예산을 집계 중인 날짜 (UTC, 2006-01-02)
+	used    int
+}
+
+// Authenticator는 등록된 API 키로 요청을 인증하고 사용량을 관리
+type Authenticator struct {
+	// 인증 없이 허용할 경로 (예: /metrics)
+	Public []string
+
+	keys  map[[sha256.Size]byte]*Key // 키 해시 → 키 설정 (원문은 보관하지 않음)
+	names map[string]*Key            // 키 이름 → 키 설정 (이름은 사용량과 실행 기록의 기준이므로 키마다 달라야 함)
+
+	mu    sync.Mutex
+	usage map[string]*usage // 키 이름 → 사용량
+	now   func() time.Time
+}
+
+// New는 빈 Authenticator를 생성
+func New() *Authenticator {
+	return &Authenticator{
+		keys:  make(map[[sha256.Size]byte]*Key),
+		names: make(map[string]*Key),
+		usage: make(map[string]*usage),
+		now:   time.Now,
+	}
+}
+
+// Add는 API 키를 등록
+// 값이나 이름이 이미 등록된 키와 같으면 거부합니다 (같은 이름의 키는 사용량을 나눠 쓰게 되므로).
+func (a *Authenticator) Add(secret string, key Key) error {
+	if secret == "" || key.Name == "" {
+		return fmt.Errorf("API 키 이름과 값이 필요합니다")
+	}
+	hash := sha256.Sum256([]byte(secret))
+	if _, ok := a.keys[hash]; ok {
+		return fmt.Errorf("중복된 API 키: %s", key.Name)
+	}
+	if _, ok := a.names[key.Name]; ok {
+		return fmt.Errorf("중복된 API 키 이름: %s", key.Name)
+	}
+	k := key
+	a.keys[hash] = &k
+	a.names[k.Name] = &k
+	return nil
+}
+
+// Len은 등록된 키 수를 반환
+func (a *Authenticator) Len() int {
+	return len(a.keys)
+}
+
+// Lookup은 이름으로 등록된 키를 찾음 (예약 실행처럼 요청 없이 한도를 적용할 때 사용)
+func (a *Authenticator) Lookup(name string) (*Key, bool) {
+	key, ok := a.names[name]
+	return key, ok
+}
+
+// Authenticate는 요청의 Authorization: Bearer 또는 X-API-Key 헤더로 키를 찾음
+func (a *Authenticator) Authenticate(r *http.Request) (*Key, bool) {
+	secret := r.Header.Get("X-API-Key")
+	if h := r.Header.Get("Authorization"); secret == "" && h != "" {
+		scheme, token, ok := strings.Cut(h, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			return nil, false
+		}
+		secret = strings.TrimSpace(token)
+	}
+	if secret == "" {
+		return nil, false
+	}
+	key, ok := a.keys[sha256.Sum256([]byte(secret))]
+	return key, ok
+}
+
+// Middleware는 인증된 요청만 next로 전달하는 미들웨어
+// 인증된 키는 요청 컨텍스트에 담기며 FromContext로 꺼낼 수 있습니다.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, p := range a.Public {
+			if r.URL.Path == p {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		key, ok := a.Authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="loadtest"`)
+			http.Error(w, "인증이 필요합니다 (Authorization: Bearer <키> 또는 X-API-Key 헤더)", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithKey(r.Context(), key)))
+	})
+}
+
+// Begin은 테스트 하나를 시작하며 동시 실행 수를 차지하고 요청 예산 cost를 사용
+// 반환된 release는 테스트가 끝나면 반드시 호출해야 합니다 (동시 실행 수 반환).
+func (a *Authenticator) Begin(key *Key, cost int) (release func(), err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	u := a.usageOf(key)
+	if key.MaxConcurrent > 0 && u.running >= key.MaxConcurrent {
+		return nil, &QuotaError{Key: key.Name, Reason: fmt.Sprintf("동시 실행 한도 %d개를 모두 사용 중입니다", key.MaxConcurrent)}
+	}
+	if err := a.charge(key, u, cost); err != nil {
+		return nil, err
+	}
+	u.running++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			a.mu.Lock()
+			u.running--
+			a.mu.Unlock()
+		})
+	}, nil
+}
+
+// Charge는 실행 중인 테스트에서 추가로 요청 예산을 사용 (실행 후에야 강도가 정해지는 자동 테스트용)
+func (a *Authenticator) Charge(key *Key, cost int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.charge(key, a.usageOf(key), cost)
+}
+
+// Usage는 키의 현재 사용량을 반환
+func (a *Authenticator) Usage(key *Key) Usage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	u := a.usageOf(key)
+	return Usage{
+		Name:          key.Name,
+		Running:       u.running,
+		MaxConcurrent: key.MaxConcurrent,
+		UsedToday:     u.used,
+		DailyBudget:   key.DailyBudget,
+	}
+}
+
+// usageOf는 키의 사용량을 반환하며 날짜가 바뀌었으면 예산을 초기화 (잠금 상태에서 호출)
+func (a *Authenticator) usageOf(key *Key) *usage {
+	u, ok := a.usage[key.Name]
+	if !ok {
+		u = &usage{}
+		a.usage[key.Name] = u
+	}
+	if today := a.now().UTC().Format("2006-01-02"); u.day != today {
+		u.day = today
+		u.used = 0
+	}
+	return u
+}
+
+// charge는 요청 예산을 사용 (잠금 상태에서 호출)
+func (a *Authenticator) charge(key *Key, u *usage, cost int) error {
+	if key.DailyBudget > 0 && u.used+cost > key.DailyBudget {
+		return &QuotaError{Key: key.Name, Reason: fmt.Sprintf(
+			"일일 요청 예산 초과 (사용 %d + 요청 %d > 한도 %d)", u.used, cost, key.DailyBudget)}
+	}
+	u.used += cost
+	return nil
+}
+
+// Cost는 테스트 요청이 사용하는 요청 예산 (최대 요청 수)
+// 일반 테스트는 RPS × 시간, 재생 테스트는 재생할 요청 수입니다.
+func Cost(req config.TestRequest) int {
+	if len(req.Schedule) > 0 {
+		if req.Duration > 0 && req.RPS > 0 {
+			return min(len(req.Schedule), req.RPS*req.Duration)
+		}
+		return len(req.Schedule)
+	}
+	return req.RPS * req.Duration
+}
+
+type contextKey struct{}
+
+// WithKey는 인증된 키를 컨텍스트에 담음
+func WithKey(ctx context.Context, key *Key) context.Context {
+	return context.WithValue(ctx, contextKey{}, key)
+}
+
+// FromContext는 요청 컨텍스트의 인증된 키를 반환 (인증이 꺼져 있으면 nil)
+func FromContext(ctx context.Context) *Key {
+	key, _ := ctx.Value(contextKey{}).(*Key)
+	return key
+}
+
+// ParseKeys는 "이름:키[:최대동시실행[:일일예산[:최대우선순위]]]" 목록을 쉼표로 구분해 등록
+// 생략한 한도는 기본값(defaultConcurrent, defaultBudget, defaultPriority)을 사용합니다.
+// 예: "team-a:s3cr3t:2:500000:10,team-b:an0ther"
+func (a *Authenticator) ParseKeys(s string, defaultConcurrent, defaultBudget, defaultPriority int) error {
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) < 2 || len(parts) > 5 {
+			return fmt.Errorf("잘못된 API 키 설정: %s (이름:키[:최대동시실행[:일일예산[:최대우선순위]]])", parts[0])
+		}
+		key := Key{Name: parts[0], MaxConcurrent: defaultConcurrent, DailyBudget: defaultBudget, MaxPriority: defaultPriority}
+		for i, dst := range []*int{&key.MaxConcurrent, &key.DailyBudget, &key.MaxPriority} {
+			if len(parts) > i+2 && parts[i+2] != "" {
+				n, err := strconv.Atoi(parts[i+2])
+				if err != nil || n < 0 {
+					return fmt.Errorf("잘못된 API 키 한도: %s", parts[0])
+				}
+				*dst = n
+			}
+		}
+		if err := a.Add(parts[1], key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+func TestParseKeysAndAuthenticate(t *testing.T) {
+	a := New()
+	if err := a.ParseKeys("team-a:s3cr3t:2:500:10, team-b:an0ther", 1, 1000, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer s3cr3t")
+	key, ok := a.Authenticate(req)
+	if !ok || key.Name != "team-a" || key.MaxConcurrent != 2 || key.DailyBudget != 500 || key.MaxPriority != 10 {
+		t.Fatalf("Authenticate(Bearer) = %+v, %v", key, ok)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-API-Key", "an0ther")
+	key, ok = a.Authenticate(req)
+	if !ok || key.Name != "team-b" || key.MaxConcurrent != 1 || key.DailyBudget != 1000 || key.MaxPriority != 0 {
+		t.Fatalf("Authenticate(X-API-Key) = %+v, %v (want defaults)", key, ok)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Basic s3cr3t")
+	if _, ok := a.Authenticate(req); ok {
+		t.Error("Basic scheme accepted")
+	}
+
+	for _, bad := range []string{"team-a", "team-a:k:x", "team-a:k:1:-1", "a:k:1:1:x", "a:k:1:1:1:1"} {
+		if err := New().ParseKeys(bad, 0, 0, 0); err == nil {
+			t.Errorf("ParseKeys(%q) succeeded, want error", bad)
+		}
+	}
+	if err := New().ParseKeys("a:same,b:same", 0, 0, 0); err == nil {
+		t.Error("duplicate secret accepted")
+	}
+	// 같은 이름의 키는 사용량을 나눠 쓰고 Lookup이 어느 쪽을 줄지 정할 수 없으므로 거부
+	if err := New().ParseKeys("a:one:1,a:two:5", 0, 0, 0); err == nil {
+		t.Error("duplicate name accepted")
+	}
+
+	if key, ok := a.Lookup("team-b"); !ok || key.MaxConcurrent != 1 {
+		t.Errorf("Lookup(team-b) = %+v, %v", key, ok)
+	}
+	if _, ok := a.Lookup("team-c"); ok {
+		t.Error("Lookup of unknown name succeeded")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	a := New()
+	a.Add("s3cr3t", Key{Name: "team-a"})
+	a.Public = []string{"/metrics"}
+
+	var owner string
+	h := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := FromContext(r.Context()); key != nil {
+			owner = key.Name
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tests", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("no key: status = %d, want 401", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("public path: status = %d, want 200", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/tests", nil)
+	req.Header.Set("X-API-Key", "s3cr3t")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || owner != "team-a" {
+		t.Errorf("valid key: status = %d, owner = %q", rec.Code, owner)
+	}
+}
+
+func TestBeginAndChargeLimits(t *testing.T) {
+	a := New()
+	day := time.Date(2025, 4, 1, 23, 0, 0, 0, time.UTC)
+	a.now = func() time.Time { return day }
+	key := &Key{Name: "team-a", MaxConcurrent: 1, DailyBudget: 1000}
+
+	release, err := a.Begin(key, 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var quota *QuotaError
+	if _, err := a.Begin(key, 0); !errors.As(err, &quota) {
+		t.Errorf("second concurrent Begin = %v, want QuotaError", err)
+	}
+	release()
+	release() // 여러 번 호출해도 한 번만 반환
+
+	// 자동 테스트처럼 실행 중에 추가로 차감
+	if err := a.Charge(key, 400); err != nil {
+		t.Fatalf("Charge within budget = %v", err)
+	}
+	if err := a.Charge(key, 1); !errors.As(err, &quota) {
+		t.Errorf("Charge over budget = %v, want QuotaError", err)
+	}
+	if _, err := a.Begin(key, 1); !errors.As(err, &quota) {
+		t.Errorf("Begin over budget = %v, want QuotaError", err)
+	}
+	if u := a.Usage(key); u.Running != 0 || u.UsedToday != 1000 {
+		t.Errorf("usage = %+v, want running 0, used 1000", u)
+	}
+
+	// 날짜(UTC)가 바뀌면 예산 초기화
+	day = day.Add(2 * time.Hour)
+	if _, err := a.Begin(key, 1000); err != nil {
+		t.Errorf("Begin on the next day = %v", err)
+	}
+}
+
+func TestCapPriority(t *testing.T) {
+	key := &Key{Name: "team-a", MaxPriority: 5}
+	cases := []struct {
+		key       *Key
+		requested int
+		want      int
+	}{
+		{key, 3, 3},
+		{key, 100, 5},
+		{key, -1, -1}, // 낮추는 것은 언제나 허용
+		{&Key{Name: "team-b"}, 10, 0},
+		{nil, 10, 0}, // 인증이 꺼져 있으면 아무도 새치기할 수 없음
+	}
+	for _, c := range cases {
+		if got := CapPriority(c.key, c.requested); got != c.want {
+			t.Errorf("CapPriority(%v, %d) = %d, want %d", c.key, c.requested, got, c.want)
+		}
+	}
+}
+
+func TestCost(t *testing.T) {
+	schedule := make([]config.ScheduledRequest, 50)
+	cases := []struct {
+		name string
+		req  config.TestRequest
+		want int
+	}{
+		{"rps × duration", config.TestRequest{RPS: 20, Duration: 30}, 600},
+		{"replay", config.TestRequest{Schedule: schedule}, 50},
+		{"replay cut by duration", config.TestRequest{Schedule: schedule, RPS: 2, Duration: 10}, 20},
+	}
+	for _, c := range cases {
+		if got := Cost(c.req); got != c.want {
+			t.Errorf("%s: Cost = %d, want %d", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// package compare는 두 부하 테스트 결과를 비교하여 성능 회귀를 감지합니다.
+package compare
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// Tolerances는 회귀로 판단하기 전까지 허용하는 변화량
+type Tolerances struct {
+	LatencyPct    float64 `json:"latencyPct"`    // 응답 시간 허용 증가율 (%)
+	LatencyMinMs  float64 `json:"latencyMinMs"`  // 응답 시간 허용 증가량 (ms) - 이보다 작은 변화는 무시
+	ErrorRatePts  float64 `json:"errorRatePts"`  // 오류율 허용 증가폭 (%p)
+	ThroughputPct float64 `json:"throughputPct"` // 처리량 허용 감소율 (%)
+}
+
+// DefaultTolerances는 기본 허용치를 반환
+func DefaultTolerances() Tolerances {
+	return Tolerances{
+		LatencyPct:    10,
+		LatencyMinMs:  5,
+		ErrorRatePts:  1,
+		ThroughputPct: 10,
+	}
+}
+
+// Delta는 지표 하나의 변화량
+type Delta struct {
+	Metric     string  `json:"metric"`     // 지표 이름
+	Base       float64 `json:"base"`       // 기준 실행 값
+	Candidate  float64 `json:"candidate"`  // 비교 실행 값
+	Diff       float64 `json:"diff"`       // 변화량 (candidate - base)
+	DiffPct    float64 `json:"diffPct"`    // 변화율 (%) - 기준값이 0이면 0
+	Regression bool    `json:"regression"` // 허용치를 넘는 악화 여부
+}
+
+// EndpointDelta는 엔드포인트별 변화량
+type EndpointDelta struct {
+	Endpoint string  `json:"endpoint"`         // "METHOD /path"
+	Deltas   []Delta `json:"deltas,omitempty"` // 지표별 변화량
+	OnlyIn   string  `json:"onlyIn,omitempty"` // 한쪽 실행에만 있는 경우 "base" 또는 "candidate"
+}
+
+// Result는 두 실행의 비교 결과
+type Result struct {
+	BaseID      string          `json:"baseId,omitempty"`      // 기준 실행 ID
+	CandidateID string          `json:"candidateId,omitempty"` // 비교 실행 ID
+	Tolerances  Tolerances      `json:"tolerances"`            // 적용한 허용치
+	Metrics     []Delta         `json:"metrics"`               // 전체 지표 변화량
+	Endpoints   []EndpointDelta `json:"endpoints,omitempty"`   // 엔드포인트별 변화량
+	Regressed   bool            `json:"regressed"`             // 하나라도 회귀가 있으면 true
+	Regressions []string        `json:"regressions,omitempty"` // 회귀가 감지된 항목 설명
+}
+
+// Compare는 base 결과 대비 candidate 결과의 변화를 계산
+func Compare(base, candidate config.TestResult, tol Tolerances) Result {
+	result := Result{Tolerances: tol}
+
+	result.Metrics = []Delta{
+		latencyDelta("avgLatencyMs", base.AvgLatencyMs, candidate.AvgLatencyMs, tol),
+		latencyDelta("p50LatencyMs", base.P50LatencyMs, candidate.P50LatencyMs, tol),
+		latencyDelta("p90LatencyMs", base.P90LatencyMs, candidate.P90LatencyMs, tol),
+		latencyDelta("p95LatencyMs", base.P95LatencyMs, candidate.P95LatencyMs, tol),
+		latencyDelta("p99LatencyMs", base.P99LatencyMs, candidate.P99LatencyMs, tol),
+		latencyDelta("maxLatencyMs", base.MaxLatencyMs, candidate.MaxLatencyMs, Tolerances{LatencyPct: math.Inf(1)}),
+		errorRateDelta("errorRate", base.ErrorRate, candidate.ErrorRate, tol),
+		throughputDelta("throughputRps", base.ThroughputRPS, candidate.ThroughputRPS, tol),
+	}
+	for _, d := range result.Metrics {
+		if d.Regression {
+			result.Regressions = append(result.Regressions, describe("", d))
+		}
+	}
+
+	// 엔드포인트 비교 (이름순으로 정렬하여 결과를 안정적으로 유지)
+	keys := make(map[string]bool)
+	for k := range base.Endpoints {
+		keys[k] = true
+	}
+	for k := range candidate.Endpoints {
+		keys[k] = true
+	}
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		b, inBase := base.Endpoints[name]
+		c, inCandidate := candidate.Endpoints[name]
+		switch {
+		case !inCandidate:
+			result.Endpoints = append(result.Endpoints, EndpointDelta{Endpoint: name, OnlyIn: "base"})
+		case !inBase:
+			result.Endpoints = append(result.Endpoints, EndpointDelta{Endpoint: name, OnlyIn: "candidate"})
+		default:
+			ed := EndpointDelta{
+				Endpoint: name,
+				Deltas: []Delta{
+					latencyDelta("avgLatencyMs", b.AvgLatencyMs, c.AvgLatencyMs, tol),
+					latencyDelta("p95LatencyMs", b.P95LatencyMs, c.P95LatencyMs, tol),
+					latencyDelta("p99LatencyMs", b.P99LatencyMs, c.P99LatencyMs, tol),
+					errorRateDelta("errorRate", b.ErrorRate, c.ErrorRate, tol),
+				},
+			}
+			for _, d := range ed.Deltas {
+				if d.Regression {
+					result.Regressions = append(result.Regressions, describe(name, d))
+				}
+			}
+			result.Endpoints = append(result.Endpoints, ed)
+		}
+	}
+
+	result.Regressed = len(result.Regressions) > 0
+	return result
+}
+
+// newDelta는 공통 변화량 필드를 채움
+func newDelta(metric string, base, candidate float64) Delta {
+	d := Delta{Metric: metric, Base: base, Candidate: candidate, Diff: candidate - base}
+	if base != 0 {
+		d.DiffPct = d.Diff / base * 100
+	}
+	return d
+}
+
+// latencyDelta는 응답 시간 지표 비교 (증가가 악화)
+func latencyDelta(metric string, base, candidate float64, tol Tolerances) Delta {
+	d := newDelta(metric, base, candidate)
+	// 절대 증가량과 증가율이 모두 허용치를 넘어야 회귀로 판단
+	d.Regression = d.Diff > tol.LatencyMinMs && base > 0 && d.DiffPct > tol.LatencyPct
+	return d
+}
+
+// errorRateDelta는 오류율 비교 (증가가 악화, 허용치는 %p 단위)
+func errorRateDelta(metric string, base, candidate float64, tol Tolerances) Delta {
+	d := newDelta(metric, base, candidate)
+	d.Regression = d.Diff*100 > tol.ErrorRatePts
+	return d
+}
+
+// throughputDelta는 처리량 비교 (감소가 악화)
+func throughputDelta(metric string, base, candidate float64, tol Tolerances) Delta {
+	d := newDelta(metric, base, candidate)
+	d.Regression = base > 0 && -d.DiffPct > tol.ThroughputPct
+	return d
+}
+
+// describe는 회귀 항목을 사람이 읽을 수 있는 문장으로 변환
+func describe(endpoint string, d Delta) string {
+	prefix := d.Metric
+	if endpoint != "" {
+		prefix = endpoint + " " + d.Metric
+	}
+	if d.Metric == "errorRate" {
+		return fmt.Sprintf("%s: %.2f%% → %.2f%% (%+.2f%%p)", prefix, d.Base*100, d.Candidate*100, d.Diff*100)
+	}
+	return fmt.Sprintf("%s: %.2f → %.2f (%+.1f%%)", prefix, d.Base, d.Candidate, d.DiffPct)
+}
+
+// WriteText는 비교 결과를 표 형태의 텍스트로 출력 (CLI용)
+func (r Result) WriteText(w io.Writer) {
+	fmt.Fprintf(w, "기준: %s  비교: %s\n\n", r.BaseID, r.CandidateID)
+	fmt.Fprintf(w, "%-16s %12s %12s %12s %9s\n", "지표", "기준", "비교", "변화", "변화율")
+	for _, d := range r.Metrics {
+		writeDeltaLine(w, "", d)
+	}
+
+	if len(r.Endpoints) > 0 {
+		fmt.Fprintln(w, "\n엔드포인트별:")
+		for _, ed := range r.Endpoints {
+			if ed.OnlyIn != "" {
+				fmt.Fprintf(w, "  %s (%s 에만 존재)\n", ed.Endpoint, ed.OnlyIn)
+				continue
+			}
+			fmt.Fprintf(w, "  %s\n", ed.Endpoint)
+			for _, d := range ed.Deltas {
+				writeDeltaLine(w, "    ", d)
+			}
+		}
+	}
+
+	fmt.Fprintln(w)
+	if r.Regressed {
+		fmt.Fprintf(w, "회귀 감지 (%d건):\n", len(r.Regressions))
+		for _, reg := range r.Regressions {
+			fmt.Fprintf(w, "  - %s\n", reg)
+		}
+	} else {
+		fmt.Fprintln(w, "회귀 없음")
+	}
+}
+
+// writeDeltaLine은 지표 한 줄을 출력
+func writeDeltaLine(w io.Writer, indent string, d Delta) {
+	mark := ""
+	if d.Regression {
+		mark = "  ⚠ 회귀"
+	}
+	fmt.Fprintf(w, "%s%-16s %12.2f %12.2f %+12.2f %+8.1f%%%s\n", indent, d.Metric, d.Base, d.Candidate, d.Diff, d.DiffPct, mark)
+}
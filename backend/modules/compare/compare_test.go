@@ -0,0 +1,151 @@
+package compare
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// metric은 결과에서 이름이 metric인 변화량
+func metric(t *testing.T, deltas []Delta, name string) Delta {
+	t.Helper()
+	for _, d := range deltas {
+		if d.Metric == name {
+			return d
+		}
+	}
+	t.Fatalf("no %s delta in %+v", name, deltas)
+	return Delta{}
+}
+
+func TestLatencyTolerances(t *testing.T) {
+	tol := DefaultTolerances() // 10%, 5ms
+	cases := []struct {
+		name            string
+		base, candidate float64
+		regression      bool
+	}{
+		{"over both limits", 100, 115, true},
+		{"under percent limit", 100, 109, false},
+		{"under absolute limit", 10, 14, false}, // 40% 증가지만 4ms
+		{"faster", 100, 50, false},
+		{"no baseline", 0, 50, false},
+	}
+	for _, c := range cases {
+		result := Compare(config.TestResult{P95LatencyMs: c.base, P99LatencyMs: c.base}, config.TestResult{P95LatencyMs: c.candidate, P99LatencyMs: c.candidate}, tol)
+		for _, name := range []string{"p95LatencyMs", "p99LatencyMs"} {
+			if d := metric(t, result.Metrics, name); d.Regression != c.regression {
+				t.Errorf("%s: %s %v → %v regression = %v, want %v", c.name, name, c.base, c.candidate, d.Regression, c.regression)
+			}
+		}
+		if result.Regressed != c.regression {
+			t.Errorf("%s: Regressed = %v", c.name, result.Regressed)
+		}
+	}
+
+	// 최대 응답 시간은 변동이 커서 회귀로 판단하지 않음
+	result := Compare(config.TestResult{MaxLatencyMs: 100}, config.TestResult{MaxLatencyMs: 1000}, tol)
+	if d := metric(t, result.Metrics, "maxLatencyMs"); d.Regression || d.DiffPct != 900 {
+		t.Errorf("maxLatencyMs = %+v", d)
+	}
+}
+
+func TestErrorRateTolerance(t *testing.T) {
+	tol := DefaultTolerances() // 1%p
+	cases := []struct {
+		base, candidate float64
+		regression      bool
+	}{
+		{0.01, 0.025, true},
+		{0.01, 0.015, false},
+		{0, 0.02, true},
+		{0.05, 0.01, false},
+	}
+	for _, c := range cases {
+		result := Compare(config.TestResult{ErrorRate: c.base}, config.TestResult{ErrorRate: c.candidate}, tol)
+		if d := metric(t, result.Metrics, "errorRate"); d.Regression != c.regression {
+			t.Errorf("errorRate %v → %v regression = %v, want %v", c.base, c.candidate, d.Regression, c.regression)
+		}
+	}
+
+	result := Compare(config.TestResult{ErrorRate: 0.01}, config.TestResult{ErrorRate: 0.03}, tol)
+	if len(result.Regressions) != 1 || result.Regressions[0] != "errorRate: 1.00% → 3.00% (+2.00%p)" {
+		t.Errorf("regressions = %q", result.Regressions)
+	}
+}
+
+func TestThroughputTolerance(t *testing.T) {
+	tol := Tolerances{ThroughputPct: 10}
+	cases := []struct {
+		base, candidate float64
+		regression      bool
+	}{
+		{100, 85, true},
+		{100, 95, false},
+		{100, 150, false},
+		{0, 10, false},
+	}
+	for _, c := range cases {
+		result := Compare(config.TestResult{ThroughputRPS: c.base}, config.TestResult{ThroughputRPS: c.candidate}, tol)
+		if d := metric(t, result.Metrics, "throughputRps"); d.Regression != c.regression {
+			t.Errorf("throughput %v → %v regression = %v, want %v", c.base, c.candidate, d.Regression, c.regression)
+		}
+	}
+
+	result := Compare(config.TestResult{ThroughputRPS: 100}, config.TestResult{ThroughputRPS: 80}, tol)
+	if len(result.Regressions) != 1 || result.Regressions[0] != "throughputRps: 100.00 → 80.00 (-20.0%)" {
+		t.Errorf("regressions = %q", result.Regressions)
+	}
+}
+
+func TestEndpointDeltas(t *testing.T) {
+	base := config.TestResult{Endpoints: map[string]*config.EndpointStats{
+		"GET /a":   {AvgLatencyMs: 50, P95LatencyMs: 100, P99LatencyMs: 200, ErrorRate: 0.01},
+		"GET /old": {AvgLatencyMs: 10},
+	}}
+	candidate := config.TestResult{Endpoints: map[string]*config.EndpointStats{
+		"GET /a":   {AvgLatencyMs: 52, P95LatencyMs: 150, P99LatencyMs: 205, ErrorRate: 0.01},
+		"GET /new": {AvgLatencyMs: 10},
+	}}
+	result := Compare(base, candidate, DefaultTolerances())
+
+	if len(result.Endpoints) != 3 {
+		t.Fatalf("endpoints = %+v, want 3", result.Endpoints)
+	}
+	a, added, removed := result.Endpoints[0], result.Endpoints[1], result.Endpoints[2]
+	if a.Endpoint != "GET /a" || added.Endpoint != "GET /new" || added.OnlyIn != "candidate" || removed.Endpoint != "GET /old" || removed.OnlyIn != "base" {
+		t.Errorf("endpoints = %+v", result.Endpoints)
+	}
+	if !metric(t, a.Deltas, "p95LatencyMs").Regression {
+		t.Error("GET /a p95 +50ms (+50%) is not a regression")
+	}
+	for _, name := range []string{"avgLatencyMs", "p99LatencyMs", "errorRate"} {
+		if metric(t, a.Deltas, name).Regression {
+			t.Errorf("GET /a %s within tolerance is a regression", name)
+		}
+	}
+
+	if !result.Regressed || len(result.Regressions) != 1 || result.Regressions[0] != "GET /a p95LatencyMs: 100.00 → 150.00 (+50.0%)" {
+		t.Errorf("regressions = %q", result.Regressions)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var b strings.Builder
+	result := Compare(config.TestResult{P95LatencyMs: 100}, config.TestResult{P95LatencyMs: 200}, DefaultTolerances())
+	result.BaseID, result.CandidateID = "run-1", "run-2"
+	result.WriteText(&b)
+	out := b.String()
+	for _, want := range []string{"기준: run-1  비교: run-2", "⚠ 회귀", "회귀 감지 (1건):", "  - p95LatencyMs: 100.00 → 200.00 (+100.0%)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	b.Reset()
+	Compare(config.TestResult{}, config.TestResult{}, DefaultTolerances()).WriteText(&b)
+	if !strings.Contains(b.String(), "회귀 없음") {
+		t.Errorf("output without regressions:\n%s", b.String())
+	}
+}
@@ -0,0 +1,415 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// 기본 크롤링 제한값
+const (
+	DefaultMaxDepth   = 2
+	DefaultMaxPages   = 50
+	DefaultMaxScripts = 30
+	DefaultUserAgent  = "LoadTestCrawler/1.0"
+	maxBodyBytes      = 2 << 20 // 페이지/스크립트당 최대 2MB
+	maxRedirects      = 10      // 요청당 따라갈 최대 리다이렉트 수
+)
+
+// 엔드포인트로 취급하지 않는 정적 리소스 확장자
+var staticExtensions = map[string]bool{
+	".css": true, ".js": true, ".mjs": true, ".map": true,
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".webp": true, ".ico": true, ".avif": true,
+	".woff": true, ".woff2": true, ".ttf": true, ".eot": true, ".otf": true,
+	".mp4": true, ".webm": true, ".mp3": true, ".wav": true,
+	".pdf": true, ".zip": true, ".gz": true,
+}
+
+// Options는 크롤러 설정
+type Options struct {
+	MaxDepth     int           // 시작 페이지로부터 따라갈 링크 깊이 (0이면 기본값)
+	MaxPages     int           // 가져올 최대 HTML 페이지 수 (0이면 기본값)
+	MaxScripts   int           // 분석할 최대 외부 스크립트 수 (0이면 기본값)
+	Timeout      time.Duration // 요청당 타임아웃 (0이면 10초)
+	UserAgent    string        // 요청 User-Agent (빈 값이면 기본값)
+	IgnoreRobots bool          // robots.txt 무시 여부
+	Client       *http.Client  // 사용할 HTTP 클라이언트 (nil이면 기본 클라이언트, 리다이렉트는 항상 같은 출처로 제한)
+}
+
+// Result는 크롤링 결과
+type Result struct {
+	Endpoints    []config.Endpoint `json:"endpoints"`
+	PagesVisited int               `json:"pagesVisited"`
+	Scripts      int               `json:"scripts"`
+	Blocked      []string          `json:"blocked,omitempty"` // robots.txt로 제외된 URL
+	Errors       []string          `json:"errors,omitempty"`  // 가져오기 실패한 URL과 사유
+}
+
+// Paths는 발견된 엔드포인트의 경로 목록 (중복 제거, 정렬)
+func (r *Result) Paths() []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, e := range r.Endpoints {
+		if !seen[e.Path] {
+			seen[e.Path] = true
+			paths = append(paths, e.Path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// crawler는 단일 크롤링 작업의 상태
+type crawler struct {
+	opts      Options
+	client    *http.Client
+	origin    *url.URL
+	robots    *Robots
+	endpoints map[string]*config.Endpoint
+	order     []string
+	result    *Result
+}
+
+type queued struct {
+	url   string
+	depth int
+}
+
+// Crawl은 시작 URL과 같은 출처(scheme+host+port)의 페이지를 너비 우선으로 탐색하여
+// 링크, 폼, 스크립트의 fetch/XHR 호출에서 엔드포인트를 수집합니다.
+func Crawl(ctx context.Context, startURL string, opts Options) (*Result, error) {
+	origin, err := url.Parse(startURL)
+	if err != nil || origin.Host == "" || (origin.Scheme != "http" && origin.Scheme != "https") {
+		return nil, fmt.Errorf("잘못된 시작 URL: %s", startURL)
+	}
+	origin.Fragment = ""
+
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = DefaultMaxDepth
+	}
+	if opts.MaxPages <= 0 {
+		opts.MaxPages = DefaultMaxPages
+	}
+	if opts.MaxScripts <= 0 {
+		opts.MaxScripts = DefaultMaxScripts
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = DefaultUserAgent
+	}
+	c := &crawler{
+		opts:      opts,
+		origin:    origin,
+		endpoints: make(map[string]*config.Endpoint),
+		result:    &Result{},
+	}
+	c.client = c.scopedClient(opts.Client)
+
+	if !opts.IgnoreRobots {
+		c.robots = c.fetchRobots(ctx)
+	}
+
+	// 시작 페이지 자체도 엔드포인트
+	c.addEndpoint("GET", origin, "link", "", nil)
+
+	visited := map[string]bool{}
+	scripts := map[string]bool{}
+	queue := []queued{{url: origin.String(), depth: 0}}
+
+	for len(queue) > 0 && c.result.PagesVisited < opts.MaxPages {
+		if err := ctx.Err(); err != nil {
+			return c.finish(), err
+		}
+		item := queue[0]
+		queue = queue[1:]
+		if visited[item.url] {
+			continue
+		}
+		visited[item.url] = true
+
+		pageURL, _ := url.Parse(item.url)
+		if !c.allowed(pageURL) {
+			c.block(item.url)
+			continue
+		}
+
+		body, contentType, err := c.fetch(ctx, item.url)
+		if err != nil {
+			c.result.Errors = append(c.result.Errors, fmt.Sprintf("%s: %v", item.url, err))
+			continue
+		}
+		if !strings.Contains(contentType, "html") {
+			// HTML이 아닌 응답(JSON 등)은 엔드포인트로만 기록
+			continue
+		}
+		c.result.PagesVisited++
+
+		links := parseHTML(body)
+		base := pageURL
+		if links.base != "" {
+			if b, err := pageURL.Parse(links.base); err == nil {
+				base = b
+			}
+		}
+
+		for _, href := range links.links {
+			u := c.resolve(base, href)
+			if u == nil || IsStaticPath(u.Path) {
+				continue
+			}
+			c.addEndpoint("GET", u, "link", item.url, nil)
+			if item.depth < opts.MaxDepth {
+				next := withoutQuery(u)
+				if !visited[next] {
+					queue = append(queue, queued{url: next, depth: item.depth + 1})
+				}
+			}
+		}
+
+		for _, ref := range links.refs {
+			c.addRef(base, ref, item.url)
+		}
+
+		for _, src := range links.scripts {
+			u := c.resolve(base, src)
+			if u == nil || scripts[u.String()] || len(scripts) >= opts.MaxScripts {
+				continue
+			}
+			scripts[u.String()] = true
+			if !c.allowed(u) {
+				c.block(u.String())
+				continue
+			}
+			code, _, err := c.fetch(ctx, u.String())
+			if err != nil {
+				c.result.Errors = append(c.result.Errors, fmt.Sprintf("%s: %v", u, err))
+				continue
+			}
+			c.result.Scripts++
+			for _, ref := range parseJS(code) {
+				c.addRef(base, ref, item.url)
+			}
+		}
+	}
+
+	return c.finish(), nil
+}
+
+// scopedClient는 리다이렉트가 시작 URL과 같은 출처를 벗어나지 않도록 제한한 클라이언트를 반환
+// 전달받은 클라이언트는 복사해서 쓰며(타임아웃이 없으면 Options.Timeout 적용), 기존 CheckRedirect가 있으면 출처 검사 뒤에 호출합니다.
+func (c *crawler) scopedClient(base *http.Client) *http.Client {
+	var client http.Client
+	if base != nil {
+		client = *base
+	}
+	if client.Timeout <= 0 {
+		client.Timeout = c.opts.Timeout
+	}
+	next := client.CheckRedirect
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("리다이렉트가 %d번을 넘음", maxRedirects)
+		}
+		if !c.inScope(req.URL) {
+			return fmt.Errorf("크롤링 범위 밖으로 리다이렉트: %s", req.URL.Redacted())
+		}
+		if next != nil {
+			return next(req, via)
+		}
+		return nil
+	}
+	return &client
+}
+
+// inScope는 URL이 시작 URL과 같은 출처(scheme+host+port)인지 확인
+func (c *crawler) inScope(u *url.URL) bool {
+	return u.Scheme == c.origin.Scheme && u.Host == c.origin.Host
+}
+
+// fetchRobots는 출처의 robots.txt를 가져와 파싱 (RFC 9309)
+// 2xx는 내용을 따르고, 4xx(없음)는 전체 허용, 5xx나 연결 실패는 전체 금지로 처리합니다.
+func (c *crawler) fetchRobots(ctx context.Context) *Robots {
+	robotsURL := &url.URL{Scheme: c.origin.Scheme, Host: c.origin.Host, Path: "/robots.txt"}
+	req, err := http.NewRequestWithContext(ctx, "GET", robotsURL.String(), nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", c.opts.UserAgent)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		c.result.Errors = append(c.result.Errors, fmt.Sprintf("%s: %v (전체 금지로 처리)", robotsURL, err))
+		return DisallowAll()
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return ParseRobots(io.LimitReader(resp.Body, maxBodyBytes), c.opts.UserAgent)
+	case resp.StatusCode >= 500:
+		c.result.Errors = append(c.result.Errors, fmt.Sprintf("%s: 상태 코드 %d (전체 금지로 처리)", robotsURL, resp.StatusCode))
+		return DisallowAll()
+	default:
+		return nil
+	}
+}
+
+// fetch는 URL의 본문과 Content-Type을 반환
+func (c *crawler) fetch(ctx context.Context, target string) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", target, nil)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("User-Agent", c.opts.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return "", "", fmt.Errorf("상태 코드 %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return "", "", err
+	}
+	return string(body), strings.ToLower(resp.Header.Get("Content-Type")), nil
+}
+
+// allowed는 robots.txt 규칙상 접근 가능한지 확인
+func (c *crawler) allowed(u *url.URL) bool {
+	if c.robots == nil {
+		return true
+	}
+	p := u.EscapedPath()
+	if p == "" {
+		p = "/"
+	}
+	if u.RawQuery != "" {
+		p += "?" + u.RawQuery
+	}
+	return c.robots.Allowed(p)
+}
+
+// resolve는 참조를 절대 URL로 변환하고, 같은 출처가 아니면 nil 반환
+func (c *crawler) resolve(base *url.URL, ref string) *url.URL {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") {
+		return nil
+	}
+	lower := strings.ToLower(ref)
+	for _, scheme := range []string{"javascript:", "mailto:", "tel:", "data:"} {
+		if strings.HasPrefix(lower, scheme) {
+			return nil
+		}
+	}
+	// 템플릿 리터럴 보간(${...})은 경로 세그먼트 하나로 취급
+	ref = strings.ReplaceAll(ref, "${", "{")
+
+	u, err := base.Parse(ref)
+	if err != nil {
+		return nil
+	}
+	if !c.inScope(u) {
+		return nil
+	}
+	u.Fragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u
+}
+
+// addRef는 폼/JS에서 찾은 참조를 엔드포인트로 추가
+func (c *crawler) addRef(base *url.URL, ref rawRef, page string) {
+	target := ref.ref
+	if target == "" && ref.source == "form" {
+		// action이 없는 폼은 현재 페이지로 전송
+		target = base.String()
+	}
+	u := c.resolve(base, target)
+	if u == nil || IsStaticPath(u.Path) {
+		return
+	}
+	c.addEndpoint(ref.method, u, ref.source, page, ref.fields)
+}
+
+// addEndpoint는 메서드+URL 기준으로 엔드포인트를 병합하여 기록
+// robots.txt로 금지된 URL은 엔드포인트에서 제외합니다.
+func (c *crawler) addEndpoint(method string, u *url.URL, source, page string, fields []string) {
+	if !c.allowed(u) {
+		c.block(withoutQuery(u))
+		return
+	}
+	ep := config.Endpoint{
+		Method: method,
+		URL:    withoutQuery(u),
+		Path:   u.Path,
+		Source: source,
+		Page:   page,
+	}
+	if ep.Path == "" {
+		ep.Path = "/"
+	}
+
+	existing, ok := c.endpoints[ep.Key()]
+	if !ok {
+		existing = &ep
+		c.endpoints[ep.Key()] = existing
+		c.order = append(c.order, ep.Key())
+	}
+	for name := range u.Query() {
+		existing.QueryParams = appendUnique(existing.QueryParams, name)
+	}
+	for _, f := range fields {
+		existing.FormFields = appendUnique(existing.FormFields, f)
+	}
+}
+
+// block은 robots.txt로 제외된 URL을 중복 없이 기록
+func (c *crawler) block(target string) {
+	c.result.Blocked = appendUnique(c.result.Blocked, target)
+}
+
+// finish는 수집한 엔드포인트를 발견 순서대로 결과에 담아 반환
+func (c *crawler) finish() *Result {
+	c.result.Endpoints = make([]config.Endpoint, 0, len(c.order))
+	for _, key := range c.order {
+		ep := c.endpoints[key]
+		sort.Strings(ep.QueryParams)
+		c.result.Endpoints = append(c.result.Endpoints, *ep)
+	}
+	return c.result
+}
+
+func withoutQuery(u *url.URL) string {
+	clean := *u
+	clean.RawQuery = ""
+	clean.Fragment = ""
+	return clean.String()
+}
+
+// IsStaticPath는 경로가 이미지/스타일/스크립트/폰트 등 정적 리소스인지 확장자로 판단
+func IsStaticPath(p string) bool {
+	return staticExtensions[strings.ToLower(path.Ext(p))]
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
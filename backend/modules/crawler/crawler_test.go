@@ -0,0 +1,188 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseRobots(t *testing.T) {
+	robots := ParseRobots(strings.NewReader(`
+User-agent: *
+Disallow: /private
+Allow: /private/public
+Disallow: /*.json$
+
+User-agent: LoadTestCrawler
+Disallow: /admin
+`), DefaultUserAgent)
+
+	// 이름이 일치하는 그룹이 "*" 그룹보다 우선
+	if robots.Allowed("/admin/users") {
+		t.Error("/admin/users allowed, want disallowed by agent group")
+	}
+	if !robots.Allowed("/private") {
+		t.Error("/private disallowed, want allowed (agent group has no such rule)")
+	}
+
+	wildcard := ParseRobots(strings.NewReader(`
+User-agent: *
+Disallow: /private
+Allow: /private/public
+Disallow: /*.json$
+`), "OtherBot/2.0")
+	cases := map[string]bool{
+		"/":                    true,
+		"/private/x":           false,
+		"/private/public/page": true,
+		"/data.json":           false,
+		"/data.json?x=1":       true,
+	}
+	for p, want := range cases {
+		if got := wildcard.Allowed(p); got != want {
+			t.Errorf("Allowed(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestParseRobotsMatchesExactProductToken(t *testing.T) {
+	robots := ParseRobots(strings.NewReader(`
+User-agent:
+Disallow: /blank
+
+User-agent: crawler
+User-agent: test
+Disallow: /partial
+
+User-agent: *
+Disallow: /wildcard
+`), DefaultUserAgent)
+
+	// 빈 User-agent나 토큰 일부("crawler", "test")에 일치하는 그룹은 적용하지 않고 "*" 그룹 사용
+	for p, want := range map[string]bool{"/blank": true, "/partial": true, "/wildcard": false} {
+		if got := robots.Allowed(p); got != want {
+			t.Errorf("Allowed(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestParseRobotsMergesGroupsForSameAgent(t *testing.T) {
+	robots := ParseRobots(strings.NewReader(`
+User-agent: LOADTESTCRAWLER/2.0
+Disallow: /a
+
+User-agent: *
+Disallow: /wildcard
+
+User-agent: loadtestcrawler
+Disallow: /b
+`), "LoadTestCrawler/1.0")
+
+	// 같은 에이전트의 그룹은 대소문자와 버전에 관계없이 모두 합침
+	for p, want := range map[string]bool{"/a": false, "/b": false, "/wildcard": true} {
+		if got := robots.Allowed(p); got != want {
+			t.Errorf("Allowed(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestDisallowAll(t *testing.T) {
+	if DisallowAll().Allowed("/") {
+		t.Error("DisallowAll().Allowed(\"/\") = true")
+	}
+	var none *Robots
+	if !none.Allowed("/anything") {
+		t.Error("nil Robots should allow everything")
+	}
+}
+
+func TestCrawlDoesNotFollowOffOriginRedirect(t *testing.T) {
+	var outsideHits int
+	outside := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outsideHits++
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<a href="/secret">secret</a>`))
+	}))
+	defer outside.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<a href="/away">away</a><a href="/moved">moved</a>`))
+	})
+	mux.HandleFunc("/away", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, outside.URL+"/", http.StatusFound)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/landing", http.StatusFound)
+	})
+	mux.HandleFunc("/landing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<form action="/api/search" method="post"><input name="q"></form>`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	result, err := Crawl(context.Background(), srv.URL+"/", Options{IgnoreRobots: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outsideHits != 0 {
+		t.Errorf("off-origin server was requested %d times, want 0", outsideHits)
+	}
+	paths := strings.Join(result.Paths(), ",")
+	if !strings.Contains(paths, "/api/search") {
+		t.Errorf("same-origin redirect not followed, paths = %s", paths)
+	}
+	if strings.Contains(paths, "/secret") {
+		t.Errorf("endpoints from off-origin page collected, paths = %s", paths)
+	}
+	found := false
+	for _, e := range result.Errors {
+		if strings.Contains(e, "/away") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("off-origin redirect not reported, errors = %v", result.Errors)
+	}
+}
+
+func TestCrawlRobotsStatus(t *testing.T) {
+	for _, tc := range []struct {
+		status  int
+		blocked bool
+	}{
+		{http.StatusNotFound, false},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusInternalServerError, true},
+	} {
+		var pageHits int
+		mux := http.NewServeMux()
+		mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.status)
+		})
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			pageHits++
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<a href="/about">about</a>`))
+		})
+		srv := httptest.NewServer(mux)
+
+		result, err := Crawl(context.Background(), srv.URL+"/", Options{})
+		srv.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.blocked {
+			if pageHits != 0 || len(result.Endpoints) != 0 || len(result.Blocked) == 0 {
+				t.Errorf("robots %d: hits=%d endpoints=%d blocked=%v, want everything disallowed",
+					tc.status, pageHits, len(result.Endpoints), result.Blocked)
+			}
+		} else if pageHits == 0 || len(result.Blocked) != 0 {
+			t.Errorf("robots %d: hits=%d blocked=%v, want everything allowed", tc.status, pageHits, result.Blocked)
+		}
+	}
+}
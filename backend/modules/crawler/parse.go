@@ -0,0 +1,153 @@
+package crawler
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// HTML/JS 파싱용 정규식
+// 외부 HTML 파서 없이 링크/폼/스크립트 추출에 필요한 범위만 처리합니다.
+var (
+	tagPattern       = regexp.MustCompile(`(?is)<(a|area|iframe|script|form|input|select|textarea|button|base)\b([^>]*)>`)
+	attrPattern      = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)\s*(?:=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'=<>` + "`" + `]+)))?`)
+	formBlockPattern = regexp.MustCompile(`(?is)<form\b([^>]*)>(.*?)</form>`)
+	fieldPattern     = regexp.MustCompile(`(?is)<(input|select|textarea|button)\b([^>]*)>`)
+	inlineScript     = regexp.MustCompile(`(?is)<script\b([^>]*)>(.*?)</script>`)
+	commentPattern   = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+	// JS에서 요청 URL을 찾는 패턴
+	fetchPattern  = regexp.MustCompile("fetch\\(\\s*[\"'`]([^\"'`\\s]+)[\"'`]")
+	axiosPattern  = regexp.MustCompile("axios\\.(get|post|put|patch|delete|head)\\(\\s*[\"'`]([^\"'`\\s]+)[\"'`]")
+	xhrPattern    = regexp.MustCompile("\\.open\\(\\s*[\"'](GET|POST|PUT|PATCH|DELETE|HEAD|get|post|put|patch|delete|head)[\"']\\s*,\\s*[\"'`]([^\"'`\\s]+)[\"'`]")
+	jqueryPattern = regexp.MustCompile("\\$\\.(get|post|getJSON)\\(\\s*[\"'`]([^\"'`\\s]+)[\"'`]")
+	methodOption  = regexp.MustCompile(`(?i)method\s*:\s*["'](GET|POST|PUT|PATCH|DELETE|HEAD)["']`)
+	apiLiteral    = regexp.MustCompile("[\"'`](/(?:api|v\\d+|graphql|rest)(?:/[^\"'`\\s<>]*)?)[\"'`]")
+)
+
+// attrs는 태그 속성 문자열을 소문자 키의 맵으로 변환
+func attrs(raw string) map[string]string {
+	result := make(map[string]string)
+	for _, m := range attrPattern.FindAllStringSubmatch(raw, -1) {
+		value := m[2] + m[3] + m[4]
+		result[strings.ToLower(m[1])] = html.UnescapeString(value)
+	}
+	return result
+}
+
+// rawRef는 페이지에서 찾은 참조 (아직 절대 URL로 변환하지 않은 상태)
+type rawRef struct {
+	method string
+	ref    string
+	source string
+	fields []string
+}
+
+// pageLinks는 HTML에서 추출한 링크/폼/스크립트 정보
+type pageLinks struct {
+	base    string   // <base href> 값
+	links   []string // 따라갈 수 있는 링크 (a, area, iframe)
+	scripts []string // 외부 스크립트 src
+	refs    []rawRef // 엔드포인트 후보 (폼, JS 요청)
+}
+
+// parseHTML은 HTML 문서에서 링크, 폼, 스크립트, 인라인 JS 요청을 추출
+func parseHTML(body string) pageLinks {
+	var result pageLinks
+	body = commentPattern.ReplaceAllString(body, "")
+
+	for _, m := range tagPattern.FindAllStringSubmatch(body, -1) {
+		tag := strings.ToLower(m[1])
+		a := attrs(m[2])
+		switch tag {
+		case "base":
+			if result.base == "" {
+				result.base = a["href"]
+			}
+		case "a", "area":
+			if href := a["href"]; href != "" {
+				result.links = append(result.links, href)
+			}
+		case "iframe":
+			if src := a["src"]; src != "" {
+				result.links = append(result.links, src)
+			}
+		case "script":
+			if src := a["src"]; src != "" {
+				result.scripts = append(result.scripts, src)
+			}
+		}
+	}
+
+	// 폼: action/method와 하위 입력 필드 이름
+	for _, m := range formBlockPattern.FindAllStringSubmatch(body, -1) {
+		a := attrs(m[1])
+		method := strings.ToUpper(a["method"])
+		if method == "" {
+			method = "GET"
+		}
+		var fields []string
+		for _, f := range fieldPattern.FindAllStringSubmatch(m[2], -1) {
+			if name := attrs(f[2])["name"]; name != "" {
+				fields = append(fields, name)
+			}
+		}
+		result.refs = append(result.refs, rawRef{method: method, ref: a["action"], source: "form", fields: fields})
+	}
+
+	// 인라인 스크립트
+	for _, m := range inlineScript.FindAllStringSubmatch(body, -1) {
+		if _, external := attrs(m[1])["src"]; external {
+			continue
+		}
+		result.refs = append(result.refs, parseJS(m[2])...)
+	}
+
+	return result
+}
+
+// parseJS는 자바스크립트 코드에서 fetch/XHR/axios/jQuery 요청 URL을 추출
+func parseJS(code string) []rawRef {
+	var refs []rawRef
+
+	for _, loc := range fetchPattern.FindAllStringSubmatchIndex(code, -1) {
+		ref := code[loc[2]:loc[3]]
+		// fetch 옵션의 method를 호출 뒤 일정 범위에서 탐색
+		method := "GET"
+		window := code[loc[1]:min(len(code), loc[1]+300)]
+		if end := strings.Index(window, ")"); end >= 0 {
+			window = window[:end]
+		}
+		if mm := methodOption.FindStringSubmatch(window); mm != nil {
+			method = strings.ToUpper(mm[1])
+		}
+		refs = append(refs, rawRef{method: method, ref: ref, source: "fetch"})
+	}
+	for _, m := range axiosPattern.FindAllStringSubmatch(code, -1) {
+		refs = append(refs, rawRef{method: strings.ToUpper(m[1]), ref: m[2], source: "xhr"})
+	}
+	for _, m := range xhrPattern.FindAllStringSubmatch(code, -1) {
+		refs = append(refs, rawRef{method: strings.ToUpper(m[1]), ref: m[2], source: "xhr"})
+	}
+	for _, m := range jqueryPattern.FindAllStringSubmatch(code, -1) {
+		method := "GET"
+		if strings.EqualFold(m[1], "post") {
+			method = "POST"
+		}
+		refs = append(refs, rawRef{method: method, ref: m[2], source: "xhr"})
+	}
+	// 호출 형태를 알 수 없는 API 경로 문자열 (예: const USERS = "/api/users")
+	// 위 호출 패턴에서 이미 찾은 URL은 제외합니다.
+	called := make(map[string]bool, len(refs))
+	for _, r := range refs {
+		called[r.ref] = true
+	}
+	for _, m := range apiLiteral.FindAllStringSubmatch(code, -1) {
+		if !called[m[1]] {
+			called[m[1]] = true
+			refs = append(refs, rawRef{method: "GET", ref: m[1], source: "script"})
+		}
+	}
+
+	return refs
+}
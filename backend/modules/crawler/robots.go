@@ -0,0 +1,143 @@
+package crawler
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// robotsRule은 robots.txt의 Allow/Disallow 규칙 하나
+type robotsRule struct {
+	allow   bool
+	pattern *regexp.Regexp
+	length  int // 패턴 길이 (가장 긴 규칙이 우선)
+}
+
+// Robots는 특정 User-Agent에 적용되는 robots.txt 규칙
+type Robots struct {
+	rules       []robotsRule
+	disallowAll bool // robots.txt를 가져올 수 없어(5xx, 연결 실패) 전체 금지로 처리
+}
+
+// DisallowAll은 모든 경로를 금지하는 규칙을 반환
+// RFC 9309에 따라 robots.txt가 서버 오류(5xx)이거나 연결할 수 없을 때 사용합니다.
+func DisallowAll() *Robots {
+	return &Robots{disallowAll: true}
+}
+
+// ParseRobots는 robots.txt 내용에서 userAgent에 해당하는 그룹을 파싱
+// userAgent의 제품 토큰과 같은 그룹이 있으면 그 그룹들을, 없으면 "*" 그룹들을 합쳐 사용합니다.
+func ParseRobots(r io.Reader, userAgent string) *Robots {
+	type group struct {
+		agents []string
+		rules  []robotsRule
+	}
+
+	var groups []*group
+	var current *group
+	lastWasAgent := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// 연속된 User-agent 줄은 같은 그룹
+			if current == nil || !lastWasAgent {
+				current = &group{}
+				groups = append(groups, current)
+			}
+			current.agents = append(current.agents, productToken(value))
+			lastWasAgent = true
+		case "allow", "disallow":
+			lastWasAgent = false
+			if current == nil || value == "" {
+				// 빈 Disallow는 전체 허용을 의미하므로 규칙 없음
+				continue
+			}
+			current.rules = append(current.rules, robotsRule{
+				allow:   key == "allow",
+				pattern: compileRobotsPattern(value),
+				length:  len(value),
+			})
+		default:
+			lastWasAgent = false
+		}
+	}
+
+	// User-Agent 토큰(예: "LoadTestCrawler/1.0" → "loadtestcrawler")과 정확히 같은 그룹을 모두 합쳐 사용
+	// RFC 9309: 제품 토큰을 대소문자 구분 없이 비교하며, 부분 일치나 빈 User-agent는 해당하지 않습니다.
+	token := productToken(userAgent)
+	var matched, wildcard []robotsRule
+	found := false
+	for _, g := range groups {
+		switch {
+		case token != "" && slices.Contains(g.agents, token):
+			matched = append(matched, g.rules...)
+			found = true
+		case slices.Contains(g.agents, "*"):
+			wildcard = append(wildcard, g.rules...)
+		}
+	}
+	if found {
+		return &Robots{rules: matched}
+	}
+	return &Robots{rules: wildcard}
+}
+
+// productToken은 User-Agent 값에서 버전을 뺀 제품 토큰을 소문자로 반환 ("LoadTestCrawler/1.0" → "loadtestcrawler")
+func productToken(userAgent string) string {
+	name, _, _ := strings.Cut(userAgent, "/")
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// compileRobotsPattern은 robots.txt 경로 패턴(* 와 $ 지원)을 정규식으로 변환
+func compileRobotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+// Allowed는 경로(쿼리 포함)에 접근 가능한지 판단
+// 일치하는 규칙 중 가장 긴 규칙을 따르며, 길이가 같으면 Allow가 우선합니다.
+func (r *Robots) Allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+	if r.disallowAll {
+		return false
+	}
+	best := -1
+	allowed := true
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if rule.length > best || (rule.length == best && rule.allow) {
+			best = rule.length
+			allowed = rule.allow
+		}
+	}
+	return allowed
+}
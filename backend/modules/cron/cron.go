@@ -0,0 +1,200 @@
+// Package cron은 5필드 cron 표현식(분 시 일 월 요일)을 해석하고 다음 실행 시각을 계산
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 자주 쓰는 표현식 별칭
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// field는 필드 하나의 허용 범위
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var fields = [5]field{
+	{"분", 0, 59, nil},
+	{"시", 0, 23, nil},
+	{"일", 1, 31, nil},
+	{"월", 1, 12, monthNames},
+	{"요일", 0, 7, dayNames}, // 7도 일요일
+}
+
+// Expr은 해석된 cron 표현식
+type Expr struct {
+	minute, hour, dom, month, dow uint64 // 허용 값 비트 집합
+	domAny, dowAny                bool   // 일/요일 필드가 * 인지 (둘 다 지정하면 둘 중 하나만 맞아도 실행)
+}
+
+// Parse는 "분 시 일 월 요일" 표현식이나 @daily 같은 별칭을 해석
+// 각 필드는 *, 값, 범위(1-5), 목록(1,3,5), 간격(*/15, 0-30/10)과 월/요일 이름(JAN, MON)을 지원합니다.
+func Parse(spec string) (*Expr, error) {
+	spec = strings.TrimSpace(spec)
+	if m, ok := macros[strings.ToLower(spec)]; ok {
+		spec = m
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("cron 표현식은 5개 필드(분 시 일 월 요일)여야 합니다: %q", spec)
+	}
+
+	var sets [5]uint64
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+	// 요일 7은 일요일(0)과 같음
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+		sets[4] &^= 1 << 7
+	}
+	return &Expr{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: parts[2] == "*" || parts[2] == "?",
+		dowAny: parts[4] == "*" || parts[4] == "?",
+	}, nil
+}
+
+// parseField는 필드 하나를 허용 값 비트 집합으로 변환
+func parseField(s string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(s, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s 필드의 간격이 잘못되었습니다: %q", f.name, item)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			a, b, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = parseValue(a, f); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(b, f); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("%s 필드의 범위가 잘못되었습니다: %q", f.name, item)
+			}
+		default:
+			v, err := parseValue(rangePart, f)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if !hasStep {
+				hi = v
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// parseValue는 숫자 또는 이름(JAN, MON)을 값으로 변환
+func parseValue(s string, f field) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s 필드 값은 %d~%d 사이여야 합니다: %q", f.name, f.min, f.max, s)
+	}
+	return v, nil
+}
+
+// Next는 after 이후(after는 제외) 표현식에 맞는 가장 빠른 시각을 after의 시간대로 반환
+// 5년 안에 맞는 시각이 없으면(예: 2월 30일) zero 시각을 반환합니다.
+func (e *Expr) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if e.month&(1<<uint(t.Month())) == 0 {
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if !e.dayMatches(t) {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if e.hour&(1<<uint(t.Hour())) == 0 {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc))
+			continue
+		}
+		if e.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// forward는 t 다음으로 확인할 시각 next를 반환
+// 서머타임 시작으로 없는 시각(예: 02:00)은 time.Date가 전환 전 시각(01:00)으로 정규화할 수 있어
+// next가 t보다 앞이면 한 시간 뒤(전환 후 시각)로 옮깁니다. 그렇지 않으면 같은 시각을 계속 반복합니다.
+func forward(t, next time.Time) time.Time {
+	if !next.After(t) {
+		next = next.Add(time.Hour)
+	}
+	return next
+}
+
+// dayMatches는 날짜가 일/요일 조건에 맞는지 반환
+// 둘 다 지정되면 둘 중 하나만 맞아도 됩니다 (표준 cron 동작).
+func (e *Expr) dayMatches(t time.Time) bool {
+	dom := e.dom&(1<<uint(t.Day())) != 0
+	dow := e.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case e.domAny && e.dowAny:
+		return true
+	case e.domAny:
+		return dow
+	case e.dowAny:
+		return dom
+	}
+	return dom || dow
+}
@@ -0,0 +1,118 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, spec string) *Expr {
+	t.Helper()
+	e, err := Parse(spec)
+	if err != nil {
+		t.Fatalf("Parse(%q) = %v", spec, err)
+	}
+	return e
+}
+
+func TestParseRejectsInvalidSpecs(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",     // 필드 4개
+		"* * * * * *", // 필드 6개
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * FOO *",
+		"@every 5m",
+	} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// 2025-01-01은 수요일
+	base := time.Date(2025, 1, 1, 10, 7, 30, 0, time.UTC)
+	cases := []struct {
+		spec  string
+		after time.Time
+		want  time.Time
+	}{
+		{"* * * * *", base, time.Date(2025, 1, 1, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", base, time.Date(2025, 1, 1, 10, 15, 0, 0, time.UTC)},
+		{"0-30/10 * * * *", base, time.Date(2025, 1, 1, 10, 10, 0, 0, time.UTC)},
+		{"5,50 * * * *", base, time.Date(2025, 1, 1, 10, 50, 0, 0, time.UTC)},
+		{"0 3 * * *", base, time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)},
+		{"@hourly", base, time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{"@daily", base, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", base, time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", base, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC), time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", base, time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)}, // 7도 일요일
+		{"0 0 1 JAN *", base, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", base, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)}, // 윤년
+		// 일과 요일을 모두 지정하면 둘 중 하나만 맞아도 실행
+		{"0 0 15 * FRI", base, time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+		// after 자체는 포함하지 않음
+		{"0 10 * * *", time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		if got := mustParse(t, c.spec).Next(c.after); !got.Equal(c.want) {
+			t.Errorf("%q.Next(%s) = %s, want %s", c.spec, c.after, got, c.want)
+		}
+	}
+}
+
+func TestNextImpossibleDate(t *testing.T) {
+	if got := mustParse(t, "0 0 30 2 *").Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Errorf("Next for Feb 30 = %s, want zero time", got)
+	}
+}
+
+func TestNextKeepsLocation(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		t.Skip("시간대 데이터가 없습니다")
+	}
+	// 서울 03:00은 UTC 전날 18:00
+	after := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).In(seoul)
+	got := mustParse(t, "0 3 * * *").Next(after)
+	want := time.Date(2025, 1, 2, 3, 0, 0, 0, seoul)
+	if !got.Equal(want) || got.Location() != seoul {
+		t.Errorf("Next = %s, want %s", got, want)
+	}
+}
+
+func TestNextSkipsNonexistentLocalTime(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("시간대 데이터가 없습니다")
+	}
+	// 2025-03-09 02:00~03:00은 서머타임 시작으로 없는 시각이므로 그날은 건너뛰고 다음 날 실행
+	after := time.Date(2025, 3, 9, 0, 0, 0, 0, ny)
+	got := mustParse(t, "30 2 * * *").Next(after)
+	want := time.Date(2025, 3, 10, 2, 30, 0, 0, ny)
+	if !got.Equal(want) {
+		t.Errorf("Next across DST gap = %s, want %s", got, want)
+	}
+}
+
+func TestNextSkipsNonexistentMidnight(t *testing.T) {
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Skip("시간대 데이터가 없습니다")
+	}
+	// 칠레는 2025-09-07 00:00에 서머타임이 시작되어 그날 자정이 없음
+	after := time.Date(2025, 9, 6, 13, 0, 0, 0, santiago)
+	got := mustParse(t, "0 12 * * *").Next(after)
+	want := time.Date(2025, 9, 7, 12, 0, 0, 0, santiago)
+	if !got.Equal(want) {
+		t.Errorf("Next across midnight DST gap = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,185 @@
+package distributed
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"github.com/Mr-Muji/LoadTest/libs/logger"
+	"go.uber.org/zap"
+)
+
+// 에이전트 설정값
+const (
+	progressInterval  = time.Second      // 중간 결과 전송 주기
+	heartbeatInterval = 10 * time.Second // 코디네이터 재등록 주기
+	maxStartDelay     = time.Minute      // 허용하는 최대 시작 대기 시간
+)
+
+// Agent는 코디네이터의 명령을 받아 부하를 생성하는 프로세스
+type Agent struct {
+	ID             string // 에이전트 ID
+	AdvertiseURL   string // 코디네이터가 접근할 주소
+	CoordinatorURL string // 코디네이터 주소 (비어 있으면 등록하지 않음)
+	APIKey         string // 코디네이터 API 키 (인증이 켜진 코디네이터에 등록할 때 사용)
+	Token          string // 코디네이터와 공유하는 토큰 (실행 명령은 이 토큰이 있어야 받음)
+	Log            *zap.SugaredLogger
+
+	mu      sync.Mutex
+	running bool
+}
+
+// Handler는 에이전트의 HTTP 핸들러를 반환
+// POST /agent/run, GET /agent/health
+func (a *Agent) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/agent/run", a.handleRun)
+	mux.HandleFunc("/agent/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": a.ID, "running": a.isRunning()})
+	})
+	return mux
+}
+
+// isRunning은 테스트 실행 중인지 반환
+func (a *Agent) isRunning() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.running
+}
+
+// handleRun은 실행 명령을 받아 테스트를 실행하고 결과를 NDJSON으로 스트리밍
+func (a *Agent) handleRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "허용되지 않은 메서드", http.StatusMethodNotAllowed)
+		return
+	}
+	// 토큰이 없으면 누구나 이 에이전트로 부하를 보낼 수 있으므로 거부
+	if !ValidToken(r.Header.Get(TokenHeader), a.Token) {
+		http.Error(w, "에이전트 토큰이 올바르지 않습니다", http.StatusUnauthorized)
+		return
+	}
+
+	var cmd RunCommand
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		http.Error(w, "잘못된 요청 형식", http.StatusBadRequest)
+		return
+	}
+	// 재생 모드는 기록된 시점대로 보내므로 rps/duration이 없어도 됨
+	if len(cmd.Request.Schedule) == 0 && (cmd.Request.RPS <= 0 || cmd.Request.Duration <= 0) {
+		http.Error(w, "rps와 duration은 0보다 커야 합니다", http.StatusBadRequest)
+		return
+	}
+	if time.Until(cmd.StartAt) > maxStartDelay {
+		http.Error(w, "시작 시각이 너무 먼 미래입니다", http.StatusBadRequest)
+		return
+	}
+
+	// 에이전트는 한 번에 하나의 테스트만 실행
+	a.mu.Lock()
+	if a.running {
+		a.mu.Unlock()
+		http.Error(w, "이미 테스트를 실행 중입니다", http.StatusConflict)
+		return
+	}
+	a.running = true
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		a.running = false
+		a.mu.Unlock()
+	}()
+
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	// 스트림 쓰기는 진행 콜백 고루틴과 경합하므로 잠금으로 보호
+	var writeMu sync.Mutex
+	encoder := json.NewEncoder(w)
+	send := func(msg StreamMessage) {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		msg.Agent = a.ID
+		encoder.Encode(msg)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	// 공통 시작 시각까지 대기
+	if wait := time.Until(cmd.StartAt); wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	a.Log.Infow("분산 테스트 시작", "id", cmd.Request.ID, "target", cmd.Request.Target, "rps", cmd.Request.RPS)
+	// 부하 생성기 로그도 에이전트 로거로 남김
+	ctx := logger.WithContext(r.Context(), a.Log)
+	result, err := loadtest.RunLoadTestWithProgress(ctx, cmd.Request, progressInterval, func(snapshot config.TestResult) {
+		send(StreamMessage{Type: MessageProgress, Result: &snapshot})
+	})
+	if err != nil {
+		send(StreamMessage{Type: MessageError, Error: err.Error()})
+		return
+	}
+	send(StreamMessage{Type: MessageResult, Result: &result})
+	a.Log.Infow("분산 테스트 완료", "id", cmd.Request.ID, "totalRequests", result.TotalRequests)
+}
+
+// Register는 코디네이터에 에이전트를 한 번 등록
+func (a *Agent) Register() error {
+	body, _ := json.Marshal(AgentInfo{ID: a.ID, URL: a.AdvertiseURL})
+	req, err := http.NewRequest(http.MethodPost, a.CoordinatorURL+"/agents", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("코디네이터 등록 실패: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if a.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.APIKey)
+	}
+	req.Header.Set(TokenHeader, a.Token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("코디네이터 등록 실패: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("코디네이터 등록 실패: %s", resp.Status)
+	}
+	return nil
+}
+
+// Heartbeat는 stop이 닫힐 때까지 주기적으로 코디네이터에 재등록
+func (a *Agent) Heartbeat(stop <-chan struct{}) {
+	if a.CoordinatorURL == "" {
+		return
+	}
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	registered := false
+	for {
+		if err := a.Register(); err != nil {
+			a.Log.Warnw("코디네이터 등록 실패, 재시도합니다", "coordinator", a.CoordinatorURL, "error", err)
+			registered = false
+		} else if !registered {
+			a.Log.Infow("코디네이터 등록 완료", "coordinator", a.CoordinatorURL, "id", a.ID)
+			registered = true
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
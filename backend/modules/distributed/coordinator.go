@@ -0,0 +1,287 @@
+package distributed
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"go.uber.org/zap"
+)
+
+// 코디네이터 설정값
+const (
+	agentTTL         = 30 * time.Second // 이 시간 동안 갱신이 없으면 에이전트 제외
+	startDelay       = 2 * time.Second  // 명령 전송 후 공통 시작까지 여유 시간
+	maxMessageSize   = 64 << 20         // 스트림 메시지 한 줄 최대 크기 (원시 샘플 포함 대비)
+	progressLogEvery = 10 * time.Second // 합산 진행 상황 로그 주기
+)
+
+// AgentOutcome은 분산 실행에서 에이전트 하나의 결과
+type AgentOutcome struct {
+	Agent  string             `json:"agent"`            // 에이전트 ID
+	RPS    int                `json:"rps"`              // 할당된 RPS
+	Result *config.TestResult `json:"result,omitempty"` // 최종 결과 (실패 시 마지막 중간 결과)
+	Error  string             `json:"error,omitempty"`  // 실패 사유
+}
+
+// Coordinator는 등록된 에이전트를 관리하고 분산 테스트를 실행
+type Coordinator struct {
+	Log   *zap.SugaredLogger
+	Token string // 에이전트와 공유하는 토큰 (비어 있으면 등록과 실행을 모두 거부)
+
+	mu     sync.Mutex
+	agents map[string]AgentInfo
+	client *http.Client
+}
+
+// NewCoordinator는 빈 코디네이터를 생성
+func NewCoordinator(log *zap.SugaredLogger, token string) *Coordinator {
+	return &Coordinator{
+		Log:    log,
+		Token:  token,
+		agents: make(map[string]AgentInfo),
+		// 스트림은 테스트 시간 동안 열려 있으므로 전체 타임아웃은 두지 않음
+		client: &http.Client{},
+	}
+}
+
+// Register는 에이전트를 등록하거나 갱신
+func (c *Coordinator) Register(info AgentInfo) error {
+	if info.ID == "" || info.URL == "" {
+		return fmt.Errorf("에이전트 id와 url이 필요합니다")
+	}
+	if err := validateAgentURL(info.URL); err != nil {
+		return err
+	}
+	info.URL = strings.TrimRight(info.URL, "/")
+	info.LastSeen = time.Now()
+
+	c.mu.Lock()
+	_, known := c.agents[info.ID]
+	c.agents[info.ID] = info
+	c.mu.Unlock()
+
+	if !known {
+		c.Log.Infow("에이전트 등록", "id", info.ID, "url", info.URL)
+	}
+	return nil
+}
+
+// Agents는 TTL 안에 갱신된 에이전트 목록을 ID 순으로 반환
+func (c *Coordinator) Agents() []AgentInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	agents := make([]AgentInfo, 0, len(c.agents))
+	for id, info := range c.agents {
+		if time.Since(info.LastSeen) > agentTTL {
+			delete(c.agents, id)
+			continue
+		}
+		agents = append(agents, info)
+	}
+	sort.Slice(agents, func(i, j int) bool { return agents[i].ID < agents[j].ID })
+	return agents
+}
+
+// SplitRPS는 전체 RPS를 n개로 최대한 균등하게 나눔 (앞쪽에 나머지를 배분)
+func SplitRPS(total, n int) []int {
+	shares := make([]int, n)
+	for i := range shares {
+		shares[i] = total / n
+		if i < total%n {
+			shares[i]++
+		}
+	}
+	return shares
+}
+
+// SplitSchedule은 재생 요청을 순서대로 n개에 번갈아 배분 (각 요청의 시점은 유지)
+func SplitSchedule(schedule []config.ScheduledRequest, n int) [][]config.ScheduledRequest {
+	parts := make([][]config.ScheduledRequest, n)
+	for i, s := range schedule {
+		parts[i%n] = append(parts[i%n], s)
+	}
+	return parts
+}
+
+// Run은 요청을 등록된 에이전트들에 나누어 실행하고 결과를 합침
+// 일부 에이전트가 실패해도 나머지 결과(실패한 에이전트는 마지막 중간 결과)로 합칩니다.
+func (c *Coordinator) Run(ctx context.Context, req config.TestRequest) (config.TestResult, []AgentOutcome, error) {
+	agents := c.Agents()
+	if len(agents) == 0 {
+		return config.TestResult{}, nil, fmt.Errorf("등록된 에이전트가 없습니다")
+	}
+
+	// RPS가 에이전트 수보다 작으면 일부 에이전트만 사용
+	// 재생 모드는 RPS 대신 재생 요청을 나누어 맡김
+	shares := SplitRPS(req.RPS, len(agents))
+	schedules := make(map[string][]config.ScheduledRequest)
+	if len(req.Schedule) > 0 {
+		for i, part := range SplitSchedule(req.Schedule, len(agents)) {
+			schedules[agents[i].ID] = part
+		}
+	}
+	startAt := time.Now().Add(startDelay)
+
+	outcomes := make([]AgentOutcome, 0, len(agents))
+	for i, agent := range agents {
+		if shares[i] > 0 || len(schedules[agent.ID]) > 0 {
+			outcomes = append(outcomes, AgentOutcome{Agent: agent.ID, RPS: shares[i]})
+		}
+	}
+	c.Log.Infow("분산 테스트 시작",
+		"id", req.ID,
+		"target", req.Target,
+		"rps", req.RPS,
+		"agents", len(outcomes),
+		"startAt", startAt,
+	)
+
+	// 에이전트별 최신 중간 결과 (합산 진행 로그용)
+	var progressMu sync.Mutex
+	latest := make(map[string]config.TestResult)
+
+	stopLog := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(progressLogEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				progressMu.Lock()
+				snapshots := make([]config.TestResult, 0, len(latest))
+				for _, r := range latest {
+					snapshots = append(snapshots, r)
+				}
+				progressMu.Unlock()
+				merged := loadtest.MergeResults(snapshots, req.Thresholds)
+				c.Log.Infow("분산 테스트 진행 상황",
+					"id", req.ID,
+					"요청수", merged.TotalRequests,
+					"실패", merged.FailCount,
+					"p95", merged.P95LatencyMs,
+				)
+			case <-stopLog:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	byID := make(map[string]AgentInfo, len(agents))
+	for _, agent := range agents {
+		byID[agent.ID] = agent
+	}
+	for i := range outcomes {
+		wg.Add(1)
+		go func(outcome *AgentOutcome) {
+			defer wg.Done()
+
+			agentReq := req
+			agentReq.RPS = outcome.RPS
+			if len(req.Schedule) > 0 {
+				agentReq.Schedule = schedules[outcome.Agent]
+			}
+			agentReq.ID = req.ID + "-" + outcome.Agent
+
+			result, err := c.runAgent(ctx, byID[outcome.Agent], RunCommand{Request: agentReq, StartAt: startAt}, func(r config.TestResult) {
+				progressMu.Lock()
+				latest[outcome.Agent] = r
+				progressMu.Unlock()
+			})
+			if err != nil {
+				outcome.Error = err.Error()
+				c.Log.Warnw("에이전트 실행 실패", "agent", outcome.Agent, "error", err)
+				progressMu.Lock()
+				if last, ok := latest[outcome.Agent]; ok {
+					outcome.Result = &last
+				}
+				progressMu.Unlock()
+				return
+			}
+			outcome.Result = &result
+		}(&outcomes[i])
+	}
+	wg.Wait()
+	close(stopLog)
+
+	results := make([]config.TestResult, 0, len(outcomes))
+	for _, o := range outcomes {
+		if o.Result != nil {
+			results = append(results, *o.Result)
+		}
+	}
+	if len(results) == 0 {
+		return config.TestResult{}, outcomes, fmt.Errorf("모든 에이전트 실행이 실패했습니다")
+	}
+
+	merged := loadtest.MergeResults(results, req.Thresholds)
+	c.Log.Infow("분산 테스트 완료",
+		"id", req.ID,
+		"총요청", merged.TotalRequests,
+		"처리량", merged.ThroughputRPS,
+		"p95", merged.P95LatencyMs,
+	)
+	return merged, outcomes, nil
+}
+
+// runAgent는 에이전트 하나에 실행 명령을 보내고 스트림을 끝까지 읽음
+func (c *Coordinator) runAgent(ctx context.Context, agent AgentInfo, cmd RunCommand, onProgress func(config.TestResult)) (config.TestResult, error) {
+	body, err := json.Marshal(cmd)
+	if err != nil {
+		return config.TestResult{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, agent.URL+"/agent/run", bytes.NewReader(body))
+	if err != nil {
+		return config.TestResult{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(TokenHeader, c.Token)
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return config.TestResult{}, fmt.Errorf("에이전트 연결 실패: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var msg bytes.Buffer
+		msg.ReadFrom(resp.Body)
+		return config.TestResult{}, fmt.Errorf("에이전트 응답 오류: %s %s", resp.Status, strings.TrimSpace(msg.String()))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		var msg StreamMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			return config.TestResult{}, fmt.Errorf("스트림 메시지 파싱 실패: %v", err)
+		}
+		switch msg.Type {
+		case MessageProgress:
+			if msg.Result != nil {
+				onProgress(*msg.Result)
+			}
+		case MessageResult:
+			if msg.Result == nil {
+				return config.TestResult{}, fmt.Errorf("최종 결과가 비어 있습니다")
+			}
+			return *msg.Result, nil
+		case MessageError:
+			return config.TestResult{}, fmt.Errorf("에이전트 실행 오류: %s", msg.Error)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return config.TestResult{}, fmt.Errorf("스트림 읽기 실패: %v", err)
+	}
+	return config.TestResult{}, fmt.Errorf("최종 결과 없이 스트림이 종료되었습니다")
+}
@@ -0,0 +1,108 @@
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/libs/logger"
+)
+
+func TestSplitRPS(t *testing.T) {
+	got := SplitRPS(10, 3)
+	if len(got) != 3 || got[0] != 4 || got[1] != 3 || got[2] != 3 {
+		t.Fatalf("SplitRPS(10, 3) = %v, want [4 3 3]", got)
+	}
+}
+
+func TestRegisterValidatesURL(t *testing.T) {
+	c := NewCoordinator(logger.Nop(), "token")
+	for _, raw := range []string{
+		"ftp://10.0.0.5:9091",
+		"http://",
+		"http://user:pw@10.0.0.5:9091",
+		"http://10.0.0.5:9091/admin",
+		"http://10.0.0.5:9091?x=1",
+		"10.0.0.5:9091",
+	} {
+		if err := c.Register(AgentInfo{ID: "a", URL: raw}); err == nil {
+			t.Errorf("Register(%q) succeeded, want error", raw)
+		}
+	}
+	if err := c.Register(AgentInfo{ID: "a", URL: "http://10.0.0.5:9091/"}); err != nil {
+		t.Errorf("Register(valid) = %v", err)
+	}
+}
+
+func TestAgentRejectsMissingToken(t *testing.T) {
+	agent := &Agent{ID: "a", Token: "secret", Log: logger.Nop()}
+	srv := httptest.NewServer(agent.Handler())
+	defer srv.Close()
+
+	body, _ := json.Marshal(RunCommand{Request: config.TestRequest{Target: "http://127.0.0.1", RPS: 1, Duration: 1}})
+	for _, token := range []string{"", "wrong"} {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/agent/run", bytes.NewReader(body))
+		if token != "" {
+			req.Header.Set(TokenHeader, token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("token %q: status %d, want 401", token, resp.StatusCode)
+		}
+	}
+	if ValidToken("", "") {
+		t.Error("empty shared token must never validate")
+	}
+}
+
+func TestCoordinatorRunsLocalAgents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("실제 부하 테스트를 실행하므로 -short 에서는 생략")
+	}
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+
+	c := NewCoordinator(logger.Nop(), "secret")
+	for _, id := range []string{"a1", "a2"} {
+		agent := &Agent{ID: id, Token: "secret", Log: logger.Nop()}
+		srv := httptest.NewServer(agent.Handler())
+		defer srv.Close()
+		if err := c.Register(AgentInfo{ID: id, URL: srv.URL}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, outcomes, err := c.Run(context.Background(), config.TestRequest{
+		ID:       "dist",
+		Target:   target.URL,
+		Method:   "GET",
+		PathList: []string{"/"},
+		RPS:      10,
+		Duration: 1,
+		Silent:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 2 {
+		t.Fatalf("outcomes = %d, want 2", len(outcomes))
+	}
+	for _, o := range outcomes {
+		if o.Error != "" {
+			t.Errorf("agent %s: %s", o.Agent, o.Error)
+		}
+	}
+	if result.TotalRequests == 0 || result.SuccessCount != result.TotalRequests {
+		t.Errorf("merged result: total %d, success %d", result.TotalRequests, result.SuccessCount)
+	}
+}
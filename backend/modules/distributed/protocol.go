@@ -0,0 +1,69 @@
+// package distributed는 여러 에이전트 프로세스로 부하를 나누어 생성하는
+// 코디네이터/에이전트 모드를 제공합니다.
+//
+// 흐름:
+//  1. 에이전트가 코디네이터의 POST /agents 로 자신을 등록하고 주기적으로 갱신
+//  2. 코디네이터가 TestRequest의 RPS를 에이전트 수로 나누고 공통 시작 시각을 정함
+//  3. 각 에이전트의 POST /agent/run 을 호출하면 에이전트는 시작 시각까지 기다렸다가
+//     테스트를 실행하며 NDJSON 스트림으로 중간/최종 결과를 보냄
+//  4. 코디네이터가 최종 결과를 히스토그램까지 합쳐 하나의 TestResult로 만듦
+//
+// 에이전트 등록과 실행 명령에는 코디네이터와 에이전트가 공유하는 토큰(TokenHeader)이 필요합니다.
+package distributed
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// AgentInfo는 코디네이터에 등록된 에이전트 정보
+type AgentInfo struct {
+	ID       string    `json:"id"`       // 에이전트 ID
+	URL      string    `json:"url"`      // 코디네이터가 접근할 에이전트 주소 (예: http://10.0.0.5:9091)
+	LastSeen time.Time `json:"lastSeen"` // 마지막 등록/갱신 시각
+}
+
+// TokenHeader는 코디네이터와 에이전트가 공유 토큰을 보내는 헤더
+const TokenHeader = "X-Agent-Token"
+
+// ValidToken은 받은 토큰이 공유 토큰과 같은지 검사 (공유 토큰이 비어 있으면 항상 거부)
+func ValidToken(got, want string) bool {
+	return want != "" && subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
+
+// validateAgentURL은 에이전트 주소가 http(s) 기본 주소인지 검사 (경로, 쿼리, 사용자 정보 불가)
+func validateAgentURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Hostname() == "" {
+		return fmt.Errorf("잘못된 에이전트 주소: %q (예: http://10.0.0.5:9091)", raw)
+	}
+	if u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("에이전트 주소에는 경로, 쿼리, 사용자 정보를 넣을 수 없습니다: %q", raw)
+	}
+	return nil
+}
+
+// RunCommand는 코디네이터가 에이전트에 보내는 실행 명령
+type RunCommand struct {
+	Request config.TestRequest `json:"request"` // 에이전트 몫으로 나눈 테스트 요청
+	StartAt time.Time          `json:"startAt"` // 모든 에이전트가 동시에 시작할 시각
+}
+
+// 스트림 메시지 종류
+const (
+	MessageProgress = "progress" // 중간 결과
+	MessageResult   = "result"   // 최종 결과
+	MessageError    = "error"    // 실행 실패
+)
+
+// StreamMessage는 에이전트가 NDJSON 한 줄로 보내는 메시지
+type StreamMessage struct {
+	Type   string             `json:"type"`             // progress, result, error
+	Agent  string             `json:"agent"`            // 에이전트 ID
+	Result *config.TestResult `json:"result,omitempty"` // progress/result일 때 결과
+	Error  string             `json:"error,omitempty"`  // error일 때 메시지
+}
@@ -0,0 +1,99 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// WriteTimeSeriesCSV는 초 단위 시계열을 CSV로 출력
+func WriteTimeSeriesCSV(w io.Writer, run *storage.TestRun) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"second", "time", "requests", "errors", "responses", "avg_latency_ms", "max_latency_ms"})
+
+	if run.Result != nil {
+		for _, p := range run.Result.TimeSeries {
+			cw.Write([]string{
+				strconv.Itoa(p.Second),
+				run.StartedAt.Add(time.Duration(p.Second) * time.Second).Format(time.RFC3339),
+				strconv.Itoa(p.Requests),
+				strconv.Itoa(p.Errors),
+				strconv.Itoa(p.Responses),
+				formatFloat(p.AvgLatencyMs),
+				formatFloat(p.MaxLatencyMs),
+			})
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteSamplesCSV는 요청별 원시 샘플을 CSV로 출력
+// 샘플은 recordSamples 옵션으로 실행한 경우에만 존재합니다.
+// 서버가 샘플 파일에 기록한 실행은 호출하는 쪽에서 run.Result.Samples를 채워 넘겨야 합니다.
+func WriteSamplesCSV(w io.Writer, run *storage.TestRun) error {
+	if run.Result == nil || len(run.Result.Samples) == 0 {
+		return fmt.Errorf("원시 샘플이 없습니다 (recordSamples 옵션으로 실행해야 합니다)")
+	}
+
+	sw := NewSamplesCSV(w)
+	for _, s := range run.Result.Samples {
+		sw.Write(s)
+	}
+	return sw.Flush()
+}
+
+// SamplesCSV는 원시 샘플을 한 줄씩 CSV로 출력 (샘플 파일을 메모리에 올리지 않고 변환할 때 사용)
+type SamplesCSV struct {
+	cw *csv.Writer
+}
+
+// NewSamplesCSV는 머리글을 쓴 뒤 샘플 CSV 출력기를 반환
+// 기존 열(time, endpoint, status, latency_ms, error) 뒤에 새 열을 덧붙여 기존 도구와 호환됩니다.
+func NewSamplesCSV(w io.Writer) *SamplesCSV {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{
+		"time", "endpoint", "status", "latency_ms", "error",
+		"failed", "error_class", "dns_ms", "connect_ms", "tls_ms", "ttfb_ms", "download_ms", "failed_after_ms",
+		"bytes_sent", "bytes_received",
+	})
+	return &SamplesCSV{cw: cw}
+}
+
+// Write는 샘플 하나를 출력
+func (sw *SamplesCSV) Write(s config.Sample) error {
+	return sw.cw.Write([]string{
+		s.Time.Format(time.RFC3339Nano),
+		s.Endpoint,
+		strconv.Itoa(s.Status),
+		formatFloat(s.LatencyMs),
+		s.Error,
+		strconv.FormatBool(s.Failed),
+		s.ErrorClass,
+		formatFloat(s.DNSMs),
+		formatFloat(s.ConnectMs),
+		formatFloat(s.TLSMs),
+		formatFloat(s.TTFBMs),
+		formatFloat(s.DownloadMs),
+		formatFloat(s.FailedAfterMs),
+		strconv.FormatInt(s.BytesSent, 10),
+		strconv.FormatInt(s.BytesReceived, 10),
+	})
+}
+
+// Flush는 남은 출력을 비우고 쓰기 오류를 반환
+func (sw *SamplesCSV) Flush() error {
+	sw.cw.Flush()
+	return sw.cw.Error()
+}
+
+// formatFloat는 CSV/Prometheus용 숫자 포맷 (불필요한 0 제거)
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,68 @@
+// package export는 테스트 실행 결과를 CI/분석 도구용 형식으로 변환합니다.
+// 지원 형식: JSON, JUnit XML, CSV(시계열/원시 샘플), Prometheus 텍스트
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// 지원하는 출력 형식
+const (
+	FormatJSON       = "json"        // 실행 기록 전체 (기본값)
+	FormatJUnit      = "junit"       // 합격 기준을 테스트 케이스로 변환한 JUnit XML
+	FormatCSV        = "csv"         // 초 단위 시계열 CSV
+	FormatCSVSamples = "csv-samples" // 요청별 원시 샘플 CSV
+	FormatPrometheus = "prometheus"  // 최종 지표의 Prometheus 텍스트 노출 형식
+)
+
+// Formats는 지원하는 형식 목록
+var Formats = []string{FormatJSON, FormatJUnit, FormatCSV, FormatCSVSamples, FormatPrometheus}
+
+// ContentType은 형식에 맞는 HTTP Content-Type을 반환
+func ContentType(format string) string {
+	switch format {
+	case FormatJUnit:
+		return "application/xml; charset=utf-8"
+	case FormatCSV, FormatCSVSamples:
+		return "text/csv; charset=utf-8"
+	case FormatPrometheus:
+		return "text/plain; version=0.0.4; charset=utf-8"
+	default:
+		return "application/json"
+	}
+}
+
+// Passed는 실행이 오류 없이 끝났고 모든 합격 기준을 통과했는지 반환 (CLI 종료 코드용)
+// 결과가 없거나 실행 중 오류가 있으면 불합격입니다.
+func Passed(run *storage.TestRun) bool {
+	if run.Error != "" || run.Result == nil {
+		return false
+	}
+	_, passed := loadtest.Verdicts(*run.Result, run.Request.Thresholds)
+	return passed
+}
+
+// Write는 실행 기록을 지정한 형식으로 w에 씀
+func Write(w io.Writer, format string, run *storage.TestRun) error {
+	switch format {
+	case "", FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(run)
+	case FormatJUnit:
+		return WriteJUnit(w, run)
+	case FormatCSV:
+		return WriteTimeSeriesCSV(w, run)
+	case FormatCSVSamples:
+		return WriteSamplesCSV(w, run)
+	case FormatPrometheus:
+		return WritePrometheus(w, run)
+	}
+	return fmt.Errorf("지원하지 않는 형식: %s (지원: %s)", format, strings.Join(Formats, ", "))
+}
@@ -0,0 +1,171 @@
+package export
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+var update = flag.Bool("update", false, "testdata의 golden 파일을 현재 출력으로 갱신")
+
+var start = time.Date(2025, 4, 12, 21, 0, 0, 0, time.UTC)
+
+// testRun은 합격 기준 하나가 실패하고, 라벨 이스케이프가 필요한 대상/경로를 가진 실행 기록
+func testRun() *storage.TestRun {
+	return &storage.TestRun{
+		ID:         "run-1",
+		Kind:       storage.KindBasic,
+		Target:     `https://example.com/a"b\c`,
+		StartedAt:  start,
+		FinishedAt: start.Add(2500 * time.Millisecond),
+		Result: &config.TestResult{
+			TotalRequests: 40,
+			SuccessCount:  36,
+			FailCount:     4,
+			TimeoutCount:  1,
+			StatusMap:     map[int]int{200: 36, 503: 3, -1: 1},
+			AvgLatencyMs:  50,
+			MaxLatencyMs:  1200.5,
+			P50LatencyMs:  40,
+			P90LatencyMs:  90,
+			P95LatencyMs:  620,
+			P99LatencyMs:  1100,
+			ErrorRate:     0.1,
+			ThroughputRPS: 16,
+			Latency:       config.Histogram{Count: 39},
+			Endpoints: map[string]*config.EndpointStats{
+				"GET /":                {TotalRequests: 30, FailCount: 1, P95LatencyMs: 80},
+				"POST /search?q=\"x\"": {TotalRequests: 10, FailCount: 3, P95LatencyMs: 900},
+			},
+			TimeSeries: []config.TimePoint{
+				{Second: 0, Requests: 20, Errors: 1, Responses: 20, AvgLatencyMs: 45.25, MaxLatencyMs: 300},
+				{Second: 1, Requests: 20, Errors: 3, Responses: 19, AvgLatencyMs: 55, MaxLatencyMs: 1200.5},
+			},
+			Thresholds: []config.ThresholdVerdict{
+				{Threshold: config.Threshold{Metric: "p95", Operator: "<", Value: 500}, Actual: 620},
+				{Threshold: config.Threshold{Metric: "errorRate", Operator: "<", Value: 0.2}, Actual: 0.1, Passed: true},
+			},
+			Samples: []config.Sample{
+				{Time: start.Add(10 * time.Millisecond), Endpoint: "GET /", Status: 200, LatencyMs: 12.5, DNSMs: 1, ConnectMs: 2, TTFBMs: 10, DownloadMs: 0.5, BytesSent: 80, BytesReceived: 1024},
+				{Time: start.Add(1500 * time.Millisecond), Endpoint: "POST /search?q=\"x\"", Status: -1, Failed: true, ErrorClass: "timeout", Error: "context deadline exceeded, retry", FailedAfterMs: 10000},
+			},
+		},
+	}
+}
+
+// checkGolden은 출력이 testdata의 golden 파일과 같은지 비교 (-update면 파일을 갱신)
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s output differs from %s\n--- got ---\n%s\n--- want ---\n%s", name, path, got, want)
+	}
+}
+
+func TestGoldenOutputs(t *testing.T) {
+	for _, c := range []struct {
+		format string
+		golden string
+	}{
+		{FormatJUnit, "run.junit.xml"},
+		{FormatCSV, "run.timeseries.csv"},
+		{FormatCSVSamples, "run.samples.csv"},
+		{FormatPrometheus, "run.prom"},
+	} {
+		t.Run(c.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, c.format, testRun()); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, c.golden, buf.Bytes())
+		})
+	}
+}
+
+func TestJUnitRunError(t *testing.T) {
+	run := testRun()
+	run.Result = nil
+	run.Error = "대상에 연결할 수 없습니다"
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, run); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "error.junit.xml", buf.Bytes())
+}
+
+func TestWriteErrors(t *testing.T) {
+	run := testRun()
+	if err := Write(&bytes.Buffer{}, "xml", run); err == nil {
+		t.Error("unknown format accepted")
+	}
+	run.Result.Samples = nil
+	if err := Write(&bytes.Buffer{}, FormatCSVSamples, run); err == nil {
+		t.Error("samples CSV without samples succeeded")
+	}
+	run.Result = nil
+	if err := Write(&bytes.Buffer{}, FormatPrometheus, run); err == nil {
+		t.Error("Prometheus output without a result succeeded")
+	}
+}
+
+func TestJUnitRecomputesMissingThresholds(t *testing.T) {
+	// 이전 버전 기록: 판정 없이 요청의 합격 기준만 있음
+	run := testRun()
+	run.Result.Thresholds = nil
+	run.Request.Thresholds = []config.Threshold{
+		{Metric: "p95", Operator: "<", Value: 500},
+		{Metric: "errorRate", Operator: "<", Value: 0.2},
+	}
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, run); err != nil {
+		t.Fatal(err)
+	}
+	// 판정을 저장한 기록과 같은 출력
+	checkGolden(t, "run.junit.xml", buf.Bytes())
+
+	// 합격 기준도 없으면 기본 기준으로 판정
+	run.Request.Thresholds = nil
+	buf.Reset()
+	if err := WriteJUnit(&buf, run); err != nil {
+		t.Fatal(err)
+	}
+	if want := len(config.DefaultThresholds()); !bytes.Contains(buf.Bytes(), []byte(fmt.Sprintf(`tests="%d"`, want))) {
+		t.Errorf("JUnit without thresholds has no default test cases:\n%s", buf.Bytes())
+	}
+}
+
+func TestPassed(t *testing.T) {
+	run := testRun()
+	if Passed(run) {
+		t.Error("run with a failed threshold passed")
+	}
+	run.Result.Thresholds = run.Result.Thresholds[1:]
+	if !Passed(run) {
+		t.Error("run with only passing thresholds failed")
+	}
+	run.Error = "중간에 중단됨"
+	if Passed(run) {
+		t.Error("run with an error passed")
+	}
+	run.Error, run.Result = "", nil
+	if Passed(run) {
+		t.Error("run without a result passed")
+	}
+}
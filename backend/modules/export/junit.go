@@ -0,0 +1,107 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// junitSuites는 JUnit XML의 최상위 요소
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+// junitSuite는 실행 하나에 해당하는 테스트 스위트
+type junitSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      float64         `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Props     []junitProperty `xml:"properties>property,omitempty"`
+	Cases     []junitCase     `xml:"testcase"`
+}
+
+// junitProperty는 스위트 속성 (실행 ID, 대상 등)
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// junitCase는 합격 기준 하나에 해당하는 테스트 케이스
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+}
+
+// junitFailure는 실패/오류 상세
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit은 합격 기준 판정을 JUnit XML 테스트 케이스로 출력
+// 실행 자체가 실패했으면 error 케이스 하나를 추가합니다.
+func WriteJUnit(w io.Writer, run *storage.TestRun) error {
+	suite := junitSuite{
+		Name:      "loadtest " + run.Target,
+		Time:      run.FinishedAt.Sub(run.StartedAt).Seconds(),
+		Timestamp: run.StartedAt.Format("2006-01-02T15:04:05"),
+		Props: []junitProperty{
+			{Name: "id", Value: run.ID},
+			{Name: "target", Value: run.Target},
+			{Name: "kind", Value: run.Kind},
+		},
+	}
+
+	if run.Error != "" {
+		suite.Errors++
+		suite.Cases = append(suite.Cases, junitCase{
+			Name:      "run",
+			ClassName: "loadtest",
+			Error:     &junitFailure{Message: run.Error, Type: "RunError", Text: run.Error},
+		})
+	}
+
+	if run.Result != nil {
+		suite.Props = append(suite.Props,
+			junitProperty{Name: "totalRequests", Value: fmt.Sprint(run.Result.TotalRequests)},
+			junitProperty{Name: "throughputRps", Value: fmt.Sprintf("%.2f", run.Result.ThroughputRPS)},
+		)
+		// 이전 버전 기록에는 판정이 없으므로 보고서와 같이 다시 계산
+		verdicts, _ := loadtest.Verdicts(*run.Result, run.Request.Thresholds)
+		for _, v := range verdicts {
+			tc := junitCase{
+				Name:      fmt.Sprintf("%s %s %g", v.Metric, v.Operator, v.Value),
+				ClassName: "loadtest.thresholds",
+			}
+			if !v.Passed {
+				suite.Failures++
+				msg := fmt.Sprintf("%s = %g (기준: %s %g)", v.Metric, v.Actual, v.Operator, v.Value)
+				tc.Failure = &junitFailure{Message: msg, Type: "ThresholdFailed", Text: msg}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+	}
+	suite.Tests = len(suite.Cases)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitSuites{Suites: []junitSuite{suite}}); err != nil {
+		return fmt.Errorf("JUnit XML 생성 실패: %v", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,129 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// promWriter는 Prometheus 텍스트 노출 형식 작성을 돕는 구조체
+type promWriter struct {
+	w      io.Writer
+	labels string // 모든 지표에 붙는 공통 라벨 (test_id, target)
+	err    error
+}
+
+// header는 지표의 HELP/TYPE 줄을 출력
+func (p *promWriter) header(name, help, typ string) {
+	p.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample은 지표 값 한 줄을 출력 (extra는 추가 라벨)
+func (p *promWriter) sample(name, extra string, value float64) {
+	labels := p.labels
+	if extra != "" {
+		labels += "," + extra
+	}
+	p.printf("%s{%s} %s\n", name, labels, formatFloat(value))
+}
+
+func (p *promWriter) printf(format string, args ...interface{}) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
+
+// escapeLabel은 Prometheus 라벨 값의 특수문자를 이스케이프
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// WritePrometheus는 최종 결과 지표를 Prometheus 텍스트 형식으로 출력
+// Pushgateway나 node_exporter textfile collector로 그대로 넘길 수 있습니다.
+func WritePrometheus(w io.Writer, run *storage.TestRun) error {
+	if run.Result == nil {
+		return fmt.Errorf("부하 테스트 결과가 없습니다")
+	}
+	r := run.Result
+	p := &promWriter{
+		w:      w,
+		labels: fmt.Sprintf(`test_id="%s",target="%s"`, escapeLabel(run.ID), escapeLabel(run.Target)),
+	}
+
+	p.header("loadtest_requests_total", "Total requests sent.", "counter")
+	p.sample("loadtest_requests_total", "", float64(r.TotalRequests))
+
+	p.header("loadtest_responses_total", "Responses by status code (-1 means timeout).", "counter")
+	codes := make([]int, 0, len(r.StatusMap))
+	for code := range r.StatusMap {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		p.sample("loadtest_responses_total", fmt.Sprintf(`status="%d"`, code), float64(r.StatusMap[code]))
+	}
+
+	p.header("loadtest_failures_total", "Failed requests including timeouts.", "counter")
+	p.sample("loadtest_failures_total", "", float64(r.FailCount))
+	p.header("loadtest_timeouts_total", "Timed out requests.", "counter")
+	p.sample("loadtest_timeouts_total", "", float64(r.TimeoutCount))
+
+	p.header("loadtest_error_ratio", "Failed requests divided by total requests.", "gauge")
+	p.sample("loadtest_error_ratio", "", r.ErrorRate)
+	p.header("loadtest_throughput_rps", "Completed requests per second.", "gauge")
+	p.sample("loadtest_throughput_rps", "", r.ThroughputRPS)
+
+	p.header("loadtest_latency_ms", "Latency summary in milliseconds.", "summary")
+	for _, q := range []struct {
+		quantile string
+		value    float64
+	}{{"0.5", r.P50LatencyMs}, {"0.9", r.P90LatencyMs}, {"0.95", r.P95LatencyMs}, {"0.99", r.P99LatencyMs}} {
+		p.sample("loadtest_latency_ms", fmt.Sprintf(`quantile="%s"`, q.quantile), q.value)
+	}
+	p.sample("loadtest_latency_ms_sum", "", r.AvgLatencyMs*float64(r.Latency.Count))
+	p.sample("loadtest_latency_ms_count", "", float64(r.Latency.Count))
+
+	p.header("loadtest_latency_max_ms", "Maximum latency in milliseconds.", "gauge")
+	p.sample("loadtest_latency_max_ms", "", r.MaxLatencyMs)
+
+	if len(r.Endpoints) > 0 {
+		names := make([]string, 0, len(r.Endpoints))
+		for name := range r.Endpoints {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		p.header("loadtest_endpoint_requests_total", "Requests per endpoint.", "counter")
+		for _, name := range names {
+			p.sample("loadtest_endpoint_requests_total", endpointLabel(name), float64(r.Endpoints[name].TotalRequests))
+		}
+		p.header("loadtest_endpoint_failures_total", "Failures per endpoint.", "counter")
+		for _, name := range names {
+			p.sample("loadtest_endpoint_failures_total", endpointLabel(name), float64(r.Endpoints[name].FailCount))
+		}
+		p.header("loadtest_endpoint_latency_p95_ms", "95th percentile latency per endpoint in milliseconds.", "gauge")
+		for _, name := range names {
+			p.sample("loadtest_endpoint_latency_p95_ms", endpointLabel(name), r.Endpoints[name].P95LatencyMs)
+		}
+	}
+
+	p.header("loadtest_thresholds_passed", "1 if all thresholds passed, 0 otherwise.", "gauge")
+	passed := 0.0
+	if _, ok := loadtest.Verdicts(*r, run.Request.Thresholds); ok {
+		passed = 1
+	}
+	p.sample("loadtest_thresholds_passed", "", passed)
+
+	return p.err
+}
+
+// endpointLabel은 "METHOD /path" 키를 method/path 라벨로 변환
+func endpointLabel(name string) string {
+	method, path, _ := strings.Cut(name, " ")
+	return fmt.Sprintf(`method="%s",path="%s"`, escapeLabel(method), escapeLabel(path))
+}
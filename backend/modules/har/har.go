@@ -0,0 +1,195 @@
+package har
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// HAR은 브라우저 개발자 도구에서 내보낸 HAR(HTTP Archive) 파일
+type HAR struct {
+	Log Log `json:"log"`
+}
+
+// Log는 HAR의 페이지/요청 기록
+type Log struct {
+	Pages   []Page  `json:"pages"`
+	Entries []Entry `json:"entries"`
+}
+
+// Page는 HAR에 기록된 페이지
+type Page struct {
+	ID    string `json:"id"`
+	Title string `json:"title"` // 보통 페이지 URL
+}
+
+// Entry는 요청/응답 한 쌍
+type Entry struct {
+	PageRef         string    `json:"pageref"`
+	StartedDateTime time.Time `json:"startedDateTime"`
+	Time            float64   `json:"time"` // 전체 소요 시간 (ms)
+	Request         Request   `json:"request"`
+	Response        Response  `json:"response"`
+	ResourceType    string    `json:"_resourceType"` // Chrome 확장 필드 (xhr, fetch, document 등)
+}
+
+// Request는 기록된 요청
+type Request struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	Headers     []NameValue `json:"headers"`
+	QueryString []NameValue `json:"queryString"`
+	PostData    *PostData   `json:"postData"`
+}
+
+// PostData는 요청 본문
+type PostData struct {
+	MimeType string      `json:"mimeType"`
+	Text     string      `json:"text"`
+	Params   []NameValue `json:"params"`
+}
+
+// Response는 기록된 응답
+type Response struct {
+	Status  int `json:"status"`
+	Content struct {
+		MimeType string `json:"mimeType"`
+	} `json:"content"`
+}
+
+// NameValue는 헤더/쿼리 항목
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// 엔드포인트에 담지 않는 헤더 (민감 정보 또는 요청마다 자동으로 붙는 헤더)
+var skippedHeaders = map[string]bool{
+	"cookie": true, "authorization": true, "proxy-authorization": true,
+	"host": true, "content-length": true, "connection": true,
+	"accept-encoding": true, "user-agent": true,
+}
+
+// 엔드포인트당 보관할 최대 샘플 본문 수와 본문 길이
+const (
+	maxSampleBodies = 3
+	maxBodyLength   = 2048
+)
+
+// Parse는 HAR JSON을 해석하고 요청 시작 시각 순으로 정렬
+func Parse(r io.Reader) (*HAR, error) {
+	var h HAR
+	if err := json.NewDecoder(r).Decode(&h); err != nil {
+		return nil, fmt.Errorf("HAR 해석 실패: %v", err)
+	}
+	if len(h.Log.Entries) == 0 {
+		return nil, fmt.Errorf("HAR에 요청 기록이 없습니다")
+	}
+	sort.SliceStable(h.Log.Entries, func(i, j int) bool {
+		return h.Log.Entries[i].StartedDateTime.Before(h.Log.Entries[j].StartedDateTime)
+	})
+	return &h, nil
+}
+
+// IsStatic은 이미지/스타일/스크립트/폰트 등 정적 리소스 요청인지 판단
+func (e Entry) IsStatic() bool {
+	switch e.ResourceType {
+	case "image", "stylesheet", "script", "font", "media", "manifest":
+		return true
+	case "xhr", "fetch", "document":
+		return false
+	}
+	mime := strings.ToLower(e.Response.Content.MimeType)
+	return strings.HasPrefix(mime, "image/") || strings.HasPrefix(mime, "font/") ||
+		strings.HasPrefix(mime, "video/") || strings.HasPrefix(mime, "audio/") ||
+		strings.Contains(mime, "css") || strings.Contains(mime, "javascript")
+}
+
+// Endpoints는 host와 같은 호스트로 보낸 요청을 메서드+URL 기준으로 묶어 엔드포인트로 변환
+// host가 빈 값이면 모든 호스트를 포함합니다.
+func (h *HAR) Endpoints(host string) []config.Endpoint {
+	pages := make(map[string]string, len(h.Log.Pages))
+	for _, p := range h.Log.Pages {
+		pages[p.ID] = p.Title
+	}
+
+	index := make(map[string]int)
+	var endpoints []config.Endpoint
+	for _, e := range h.Log.Entries {
+		u, err := url.Parse(e.Request.URL)
+		if err != nil || e.IsStatic() || (host != "" && u.Host != host) {
+			continue
+		}
+
+		ep := config.Endpoint{
+			Method: strings.ToUpper(e.Request.Method),
+			URL:    u.Scheme + "://" + u.Host + u.Path,
+			Path:   u.Path,
+			Source: "har",
+			Page:   pages[e.PageRef],
+		}
+		if ep.Path == "" {
+			ep.Path = "/"
+		}
+
+		i, ok := index[ep.Key()]
+		if !ok {
+			i = len(endpoints)
+			index[ep.Key()] = i
+			endpoints = append(endpoints, ep)
+		}
+		merge(&endpoints[i], e)
+	}
+	return endpoints
+}
+
+// merge는 요청 하나의 쿼리/헤더/본문 정보를 엔드포인트에 합침
+func merge(ep *config.Endpoint, e Entry) {
+	for _, q := range e.Request.QueryString {
+		ep.QueryParams = appendUnique(ep.QueryParams, q.Name)
+	}
+	for _, hdr := range e.Request.Headers {
+		name := strings.ToLower(hdr.Name)
+		// HTTP/2 의사 헤더(:authority 등) 제외
+		if skippedHeaders[name] || strings.HasPrefix(name, ":") {
+			continue
+		}
+		if ep.Headers == nil {
+			ep.Headers = make(map[string]string)
+		}
+		ep.Headers[name] = hdr.Value
+	}
+	if pd := e.Request.PostData; pd != nil {
+		if pd.MimeType != "" {
+			ep.RequestContentType = pd.MimeType
+		}
+		for _, p := range pd.Params {
+			ep.FormFields = appendUnique(ep.FormFields, p.Name)
+		}
+		if pd.Text != "" && len(ep.SampleBodies) < maxSampleBodies {
+			body := pd.Text
+			if len(body) > maxBodyLength {
+				body = body[:maxBodyLength]
+			}
+			ep.SampleBodies = append(ep.SampleBodies, body)
+		}
+	}
+	if mime := e.Response.Content.MimeType; mime != "" {
+		ep.ResponseContentType = mime
+	}
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
@@ -0,0 +1,117 @@
+package har
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+func loadHAR(t *testing.T) *HAR {
+	t.Helper()
+	f, err := os.Open("testdata/session.har")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	h, err := Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestParseSortsEntries(t *testing.T) {
+	h := loadHAR(t)
+	for i := 1; i < len(h.Log.Entries); i++ {
+		if h.Log.Entries[i].StartedDateTime.Before(h.Log.Entries[i-1].StartedDateTime) {
+			t.Fatalf("entries are not sorted by start time: %d before %d", i, i-1)
+		}
+	}
+	if got := h.Log.Entries[0].Request.URL; got != "https://example.com/api/users?page=1" {
+		t.Errorf("first entry = %s", got)
+	}
+
+	for _, bad := range []string{`{"log": {"entries": []}}`, `not json`} {
+		if _, err := Parse(strings.NewReader(bad)); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestIsStatic(t *testing.T) {
+	cases := []struct {
+		resourceType, mime string
+		want               bool
+	}{
+		{"script", "application/json", true},
+		{"xhr", "text/css", false}, // 리소스 유형이 MIME보다 우선
+		{"", "image/png", true},
+		{"", "text/css; charset=utf-8", true},
+		{"", "application/javascript", true},
+		{"", "font/woff2", true},
+		{"", "application/json", false},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		e := Entry{ResourceType: c.resourceType}
+		e.Response.Content.MimeType = c.mime
+		if got := e.IsStatic(); got != c.want {
+			t.Errorf("IsStatic(%q, %q) = %v, want %v", c.resourceType, c.mime, got, c.want)
+		}
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	got := loadHAR(t).Endpoints("example.com")
+	want := []config.Endpoint{
+		{
+			// 같은 메서드+URL은 쿼리 이름을 합쳐 하나로 묶고, 민감/자동 헤더와 의사 헤더는 제외
+			Method:              "GET",
+			URL:                 "https://example.com/api/users",
+			Path:                "/api/users",
+			QueryParams:         []string{"page", "sort"},
+			Headers:             map[string]string{"accept": "*/*"},
+			ResponseContentType: "application/json",
+			Source:              "har",
+			Page:                "https://example.com/users",
+		},
+		{
+			Method:              "POST",
+			URL:                 "https://example.com/api/users",
+			Path:                "/api/users",
+			Headers:             map[string]string{"content-type": "application/json"},
+			RequestContentType:  "application/json",
+			ResponseContentType: "application/json",
+			SampleBodies:        []string{`{"name":"a"}`},
+			Source:              "har",
+			Page:                "https://example.com/users",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Endpoints =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if all := loadHAR(t).Endpoints(""); len(all) != 3 {
+		t.Errorf("Endpoints(\"\") = %d endpoints, want 3 (other hosts included)", len(all))
+	}
+}
+
+func TestEndpointsLimitsSampleBodies(t *testing.T) {
+	long := strings.Repeat("x", maxBodyLength+10)
+	h := &HAR{}
+	for _, body := range []string{"1", "2", long, "4"} {
+		e := Entry{Request: Request{Method: "POST", URL: "https://example.com/api", PostData: &PostData{Text: body}}}
+		h.Log.Entries = append(h.Log.Entries, e)
+	}
+	eps := h.Endpoints("")
+	if len(eps) != 1 {
+		t.Fatalf("endpoints = %+v", eps)
+	}
+	bodies := eps[0].SampleBodies
+	if len(bodies) != maxSampleBodies || bodies[0] != "1" || len(bodies[2]) != maxBodyLength {
+		t.Errorf("sample bodies = %d items, lengths %d", len(bodies), len(bodies[len(bodies)-1]))
+	}
+}
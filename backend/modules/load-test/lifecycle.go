@@ -0,0 +1,28 @@
+package loadtest
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrShuttingDown은 서버 종료 중이라 새 테스트를 시작하지 않을 때 반환되는 오류 (API는 503)
+var ErrShuttingDown = errors.New("서버가 종료 중이라 새 테스트를 시작할 수 없습니다")
+
+var (
+	draining   atomic.Bool
+	stopRuns   = make(chan struct{}) // 닫히면 실행 중인 모든 테스트가 중단됨
+	cancelOnce sync.Once
+)
+
+// Drain은 이후의 테스트 실행을 거부 (실행 중인 테스트는 계속 진행)
+func Drain() {
+	draining.Store(true)
+}
+
+// CancelRuns는 실행 중인 모든 테스트에 새 요청 전송을 멈추게 함
+// 보낸 요청의 응답까지 집계한 부분 결과(Interrupted=true)로 정상 반환되므로 호출자가 그대로 저장할 수 있습니다.
+func CancelRuns() {
+	draining.Store(true)
+	cancelOnce.Do(func() { close(stopRuns) })
+}
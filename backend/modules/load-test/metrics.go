@@ -0,0 +1,65 @@
+package loadtest
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/metrics"
+)
+
+// liveMetricsRetention은 테스트 종료 후 test_id 라벨 지표를 유지하는 시간
+// 마지막 스크레이프가 최종 값을 가져갈 수 있도록 바로 지우지 않습니다.
+const liveMetricsRetention = 5 * time.Minute
+
+// 실행 중 /metrics 로 노출되는 부하 생성기 지표
+var (
+	liveRequestsSent = metrics.Default.NewCounterVec(
+		"loadgen_requests_sent_total", "Requests sent by the load generator.",
+		"test_id", "target")
+	liveResponses = metrics.Default.NewCounterVec(
+		"loadgen_responses_total", "Responses by endpoint and status code (status=\"timeout\" or \"error\" when no response).",
+		"test_id", "endpoint", "status")
+	liveLatency = metrics.Default.NewHistogramVec(
+		"loadgen_request_duration_seconds", "Request latency in seconds by endpoint.",
+		nil, "test_id", "endpoint")
+	liveInFlight = metrics.Default.NewGaugeVec(
+		"loadgen_in_flight_requests", "Requests currently waiting for a response.",
+		"test_id")
+	liveSchedulerLag = metrics.Default.NewHistogramVec(
+		"loadgen_scheduler_lag_seconds", "Delay between the scheduled tick and the actual request dispatch.",
+		[]float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1}, "test_id")
+	liveTestsRunning = metrics.Default.NewGaugeVec(
+		"loadgen_tests_running", "Load tests currently running.")
+	liveTargetRPS = metrics.Default.NewGaugeVec(
+		"loadgen_target_rps", "Configured requests per second for a running test.",
+		"test_id", "target")
+)
+
+// metricsTestID는 지표 라벨로 사용할 테스트 ID (ID가 없는 내부 실행은 adhoc)
+func metricsTestID(id string) string {
+	if id == "" {
+		return "adhoc"
+	}
+	return id
+}
+
+// statusLabel은 응답 코드를 지표 라벨 값으로 변환
+func statusLabel(status int) string {
+	return strconv.Itoa(status)
+}
+
+// releaseLiveMetrics는 보존 시간이 지난 뒤 테스트의 지표 series를 삭제
+func releaseLiveMetrics(testID string) {
+	if testID == "adhoc" {
+		// adhoc은 여러 실행이 공유하므로 삭제하지 않음
+		return
+	}
+	time.AfterFunc(liveMetricsRetention, func() {
+		liveRequestsSent.DeleteMatching("test_id", testID)
+		liveResponses.DeleteMatching("test_id", testID)
+		liveLatency.DeleteMatching("test_id", testID)
+		liveInFlight.DeleteMatching("test_id", testID)
+		liveSchedulerLag.DeleteMatching("test_id", testID)
+		liveTargetRPS.DeleteMatching("test_id", testID)
+	})
+}
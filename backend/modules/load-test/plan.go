@@ -0,0 +1,179 @@
+package loadtest
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/pathtemplate"
+)
+
+// plannedRequest는 틱 하나에서 보낼 요청
+type plannedRequest struct {
+	spec     config.RequestSpec
+	url      string // 전체 요청 URL
+	endpoint string // 통계용 엔드포인트 키
+}
+
+// newHTTPRequest는 계획된 요청으로 http.Request를 생성
+// randomHeaders는 TestRequest.Headers에서 고른 헤더로, 요청별 헤더가 같은 이름을 덮어씁니다.
+func (p plannedRequest) newHTTPRequest(randomHeaders map[string][]string) (*http.Request, error) {
+	var body io.Reader
+	if p.spec.Body != "" {
+		body = bytes.NewBufferString(p.spec.Body)
+	}
+	httpReq, err := http.NewRequest(p.spec.Method, p.url, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range randomHeaders {
+		for _, v := range vs {
+			httpReq.Header.Add(k, v)
+		}
+	}
+	for k, v := range p.spec.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	return httpReq, nil
+}
+
+// requestPlanner는 TestRequest 설정에 따라 매 틱 보낼 요청을 선택
+// 틱을 처리하는 루프 한 곳에서만 호출해야 합니다 (재생 위치는 잠금 없이 증가).
+type requestPlanner struct {
+	target     string
+	specs      []config.RequestSpec
+	names      []string
+	cumulative []int // 가중치 누적합 (선택용)
+	replay     bool  // true면 specs를 순서대로 한 번씩 사용 (재생 모드)
+	pos        int   // 재생 모드의 다음 요청 위치
+	paramPos   []int // 요청별 다음에 사용할 경로 파라미터 묶음 위치
+}
+
+// newRequestPlanner는 요청 목록(Requests 또는 재생용 Schedule)을 준비
+// Requests가 비어 있으면 기존 방식(Method + PathList 랜덤 + POST 본문)으로 동작합니다.
+func newRequestPlanner(req config.TestRequest) *requestPlanner {
+	p := &requestPlanner{target: strings.TrimRight(req.Target, "/")}
+
+	specs := req.Requests
+	if len(req.Schedule) > 0 {
+		p.replay = true
+		specs = make([]config.RequestSpec, len(req.Schedule))
+		for i, s := range req.Schedule {
+			specs[i] = s.RequestSpec
+		}
+	}
+
+	total := 0
+	for _, spec := range specs {
+		spec.Method = strings.ToUpper(spec.Method)
+		if spec.Method == "" {
+			spec.Method = "GET"
+		}
+		weight := spec.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		total += weight
+
+		name := spec.Name
+		if name == "" {
+			path, _, _ := strings.Cut(spec.Path, "?")
+			name = endpointKey(spec.Method, path)
+		}
+		p.specs = append(p.specs, spec)
+		p.names = append(p.names, name)
+		p.cumulative = append(p.cumulative, total)
+	}
+	p.paramPos = make([]int, len(p.specs))
+	return p
+}
+
+// next는 다음에 보낼 요청을 선택
+func (p *requestPlanner) next(req config.TestRequest) plannedRequest {
+	if len(p.specs) == 0 {
+		// 기존 방식: 경로 랜덤 선택, 본문은 POST에만 사용
+		path := GetRandomPath(req.PathList)
+		method := strings.ToUpper(req.Method)
+		spec := config.RequestSpec{Method: req.Method, Path: path}
+		if method == "POST" {
+			spec.Body = req.Body
+		}
+		return plannedRequest{
+			spec:     spec,
+			url:      p.url(path),
+			endpoint: endpointKey(method, path),
+		}
+	}
+
+	i := 0
+	if p.replay {
+		i = min(p.pos, len(p.specs)-1)
+		p.pos++
+	} else if n := len(p.specs); n > 1 {
+		pick := rand.Intn(p.cumulative[n-1])
+		for p.cumulative[i] <= pick {
+			i++
+		}
+	}
+	spec := p.specs[i]
+	path := spec.Path
+	if n := len(spec.PathParams); n > 0 {
+		// 함께 관찰된 값 묶음을 순서대로 돌아가며 사용 (파라미터를 따로 고르면 존재하지 않는 조합이 생김)
+		path = pathtemplate.Expand(path, spec.PathParams[p.paramPos[i]%n])
+		p.paramPos[i]++
+	}
+	return plannedRequest{
+		spec:     spec,
+		url:      p.url(path),
+		endpoint: p.names[i],
+	}
+}
+
+func (p *requestPlanner) url(path string) string {
+	return requestURL(p.target, path)
+}
+
+// requestURL은 대상 주소와 경로로 요청 URL을 만듦
+// 경로가 http(s)://로 시작하면 대상이 아닌 호스트의 URL로 보고 그대로 사용합니다 (범위 검사는 별도).
+func requestURL(target, path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return target + "/" + strings.TrimLeft(path, "/")
+}
+
+// scheduleTicks는 재생 모드에서 각 요청의 예정 시각마다 값을 보내는 채널을 반환
+// 모든 요청을 보내면 채널을 닫고, stop이 닫히면 중단합니다.
+func scheduleTicks(schedule []config.ScheduledRequest, speed float64, stop <-chan struct{}) <-chan time.Time {
+	if speed <= 0 {
+		speed = 1
+	}
+	ticks := make(chan time.Time)
+	go func() {
+		defer close(ticks)
+		start := time.Now()
+		timer := time.NewTimer(time.Hour)
+		defer timer.Stop()
+		for _, s := range schedule {
+			at := start.Add(time.Duration(s.OffsetMs / speed * float64(time.Millisecond)))
+			if wait := time.Until(at); wait > 0 {
+				timer.Reset(wait)
+				select {
+				case <-timer.C:
+				case <-stop:
+					return
+				}
+			}
+			select {
+			case ticks <- at:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return ticks
+}
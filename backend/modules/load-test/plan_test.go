@@ -0,0 +1,37 @@
+package loadtest
+
+import (
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+func TestPlannerRotatesObservedParamTuples(t *testing.T) {
+	req := config.TestRequest{
+		Target: "https://example.com/",
+		Requests: []config.RequestSpec{{
+			Method: "get",
+			Path:   "/users/{id}/orders/{id2}",
+			PathParams: []map[string]string{
+				{"id": "1", "id2": "10"},
+				{"id": "2", "id2": "20"},
+			},
+		}},
+	}
+	p := newRequestPlanner(req)
+
+	want := []string{
+		"https://example.com/users/1/orders/10",
+		"https://example.com/users/2/orders/20",
+		"https://example.com/users/1/orders/10",
+	}
+	for i, w := range want {
+		got := p.next(req)
+		if got.url != w {
+			t.Errorf("request %d url = %s, want %s", i, got.url, w)
+		}
+		if got.endpoint != "GET /users/{id}/orders/{id2}" {
+			t.Errorf("request %d endpoint = %s, want the template", i, got.endpoint)
+		}
+	}
+}
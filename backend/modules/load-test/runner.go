@@ -1,10 +1,12 @@
 package loadtest
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http" // 요청 보낼 때 사용
+	"net/http/httptrace"
 	"os"
 	"strings"
 	"sync" // 병렬 처리할 때 결과를 안전하게 저장하려고 mutex 사용
@@ -12,85 +14,94 @@ import (
 
 	// 경로 업데이트
 	"github.com/Mr-Muji/LoadTest/backend/config"
-	"go.uber.org/zap"         // zap 로깅 라이브러리
-	"go.uber.org/zap/zapcore" // zap 설정을 위한 패키지
+	"github.com/Mr-Muji/LoadTest/backend/modules/samples"
+	"github.com/Mr-Muji/LoadTest/backend/modules/scope"
+	"github.com/Mr-Muji/LoadTest/backend/modules/tracing"
+	"github.com/Mr-Muji/LoadTest/libs/logger"
+	"go.uber.org/zap"
 )
 
-// 전역 로거 변수 선언
-var log *zap.SugaredLogger
-
-// InitLogger zap 로거를 초기화하는 함수
-func InitLogger(logPath string) {
-	// 로그 설정 구성
-	encoderConfig := zapcore.EncoderConfig{
-		TimeKey:        "time",
-		LevelKey:       "level",
-		NameKey:        "logger",
-		CallerKey:      "caller",
-		MessageKey:     "msg",
-		StacktraceKey:  "stacktrace",
-		LineEnding:     zapcore.DefaultLineEnding,
-		EncodeLevel:    zapcore.CapitalLevelEncoder, // 대문자로 로그 레벨 표시 (INFO, ERROR 등)
-		EncodeTime:     zapcore.ISO8601TimeEncoder,  // ISO8601 시간 포맷 사용
-		EncodeDuration: zapcore.StringDurationEncoder,
-		EncodeCaller:   zapcore.ShortCallerEncoder,
-	}
+// traceExporter는 요청별 span을 내보낼 Exporter (nil이면 traceparent 헤더만 전파)
+var traceExporter tracing.Exporter
 
-	// 로그 파일 설정
-	var core zapcore.Core
-	if logPath != "" {
-		// 로그 디렉토리 생성
-		logDir := logPath[:strings.LastIndex(logPath, "/")]
-		if err := os.MkdirAll(logDir, 0755); err != nil {
-			fmt.Printf("로그 디렉토리 생성 실패: %v\n", err)
-		}
+// SetTraceExporter는 분산 추적 span을 내보낼 Exporter를 설정
+func SetTraceExporter(exporter tracing.Exporter) {
+	traceExporter = exporter
+}
 
-		// 로그 파일 열기
-		logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
-		if err != nil {
-			fmt.Printf("로그 파일 열기 실패: %v, 콘솔에만 출력합니다.\n", err)
-			// 콘솔에만 출력하는 설정
-			core = zapcore.NewCore(
-				zapcore.NewConsoleEncoder(encoderConfig),
-				zapcore.AddSync(os.Stdout),
-				zap.InfoLevel,
-			)
-		} else {
-			// 파일과 콘솔 모두에 출력
-			fileWriter := zapcore.AddSync(logFile)
-			consoleWriter := zapcore.AddSync(os.Stdout)
-			multiWriter := zapcore.NewMultiWriteSyncer(fileWriter, consoleWriter)
-
-			core = zapcore.NewCore(
-				zapcore.NewConsoleEncoder(encoderConfig),
-				multiWriter,
-				zap.InfoLevel,
-			)
-		}
-	} else {
-		// 로그 파일 경로가 없으면 콘솔에만 출력
-		core = zapcore.NewCore(
-			zapcore.NewConsoleEncoder(encoderConfig),
-			zapcore.AddSync(os.Stdout),
-			zap.InfoLevel,
-		)
+// defaultTimeout은 요청에 timeout이 없을 때 사용하는 요청 하나의 제한 시간
+var defaultTimeout = 10 * time.Second
+
+// SetDefaultTimeout은 요청 하나의 기본 제한 시간을 설정 (요청의 timeout이 우선)
+func SetDefaultTimeout(d time.Duration) {
+	if d > 0 {
+		defaultTimeout = d
 	}
+}
+
+// dialContext는 부하 요청의 연결을 만드는 함수 (nil이면 기본 Dialer)
+var dialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// SetDialer는 부하 요청의 연결 함수를 설정
+// 안전 정책의 DialContext를 넘기면 연결할 때마다 실제 주소가 허용 대역 안인지 검사합니다 (DNS 리바인딩 방지).
+func SetDialer(dial func(ctx context.Context, network, addr string) (net.Conn, error)) {
+	dialContext = dial
+}
+
+// Guard는 테스트 실행 전에 요청을 검사하는 함수 (오류를 반환하면 실행하지 않음)
+type Guard func(ctx context.Context, req config.TestRequest) error
 
-	// 로거 생성
-	zapLogger := zap.New(core, zap.AddCaller())
-	log = zapLogger.Sugar() // SugaredLogger는 사용하기 더 편리한 API 제공
+// guard는 모든 테스트 실행 전에 적용할 검사 (nil이면 검사하지 않음)
+var guard Guard
 
-	log.Info("로깅 시스템 초기화 완료")
+// SetGuard는 테스트 실행 전 검사(안전 정책 등)를 설정
+func SetGuard(g Guard) {
+	guard = g
 }
 
-func RunLoadTest(req config.TestRequest) (config.TestResult, error) {
-	// 로그 시스템이 초기화되지 않은 경우를 대비
-	if log == nil {
-		// 기본 콘솔 로거 생성
-		zapLogger, _ := zap.NewProduction()
-		log = zapLogger.Sugar()
-		defer zapLogger.Sync()
+// Admission은 테스트를 실행해도 될 때까지 기다리는 함수 (서버 실행 대기열 등)
+// ctx가 취소되면 기다리지 않고 오류를 반환해야 하며, 반환된 release는 테스트가 끝나면 호출됩니다.
+type Admission func(ctx context.Context, req config.TestRequest) (release func(), err error)
+
+// admission은 모든 테스트 실행 전에 거치는 대기 (nil이면 바로 실행)
+var admission Admission
+
+// SetAdmission은 테스트 실행 대기(동시 실행 수, 전체 RPS 한도)를 설정
+func SetAdmission(a Admission) {
+	admission = a
+}
+
+// Admit은 테스트를 실행해도 될 때까지 기다림 (대기열이 없으면 바로 반환)
+// 부하를 직접 만들지 않는 실행(분산 실행의 코디네이터 등)도 같은 대기열을 거치게 할 때 사용합니다.
+func Admit(ctx context.Context, req config.TestRequest) (release func(), err error) {
+	if admission == nil {
+		return func() {}, nil
 	}
+	return admission(ctx, req)
+}
+
+// ProgressFunc는 실행 중 주기적으로 호출되는 중간 결과 콜백
+// 전달되는 결과는 복사본이므로 자유롭게 사용해도 됩니다 (원시 샘플은 제외).
+type ProgressFunc func(snapshot config.TestResult)
+
+// RunLoadTest는 요청 설정대로 부하 테스트를 실행하고 결과를 반환 (로그는 log로 남김)
+func RunLoadTest(log *zap.SugaredLogger, req config.TestRequest) (config.TestResult, error) {
+	return RunLoadTestContext(logger.WithContext(context.Background(), log), req)
+}
+
+// RunLoadTestContext는 RunLoadTest와 같지만 실행 전 검사와 대기열 대기에 ctx를 사용
+// 로그는 ctx에 담긴 로거(logger.WithContext)로 남깁니다.
+// 대기 중에 ctx가 취소되면(클라이언트 연결 종료 등) 실행하지 않고 대기열에서 빠집니다.
+// 일단 시작한 테스트는 ctx와 관계없이 끝까지 실행합니다.
+func RunLoadTestContext(ctx context.Context, req config.TestRequest) (config.TestResult, error) {
+	return RunLoadTestWithProgress(ctx, req, 0, nil)
+}
+
+// RunLoadTestWithProgress는 RunLoadTestContext와 같지만 interval마다 progress로 중간 결과를 전달
+// 분산 실행의 에이전트가 코디네이터로 진행 상황을 스트리밍할 때 사용합니다.
+func RunLoadTestWithProgress(ctx context.Context, req config.TestRequest, interval time.Duration, progress ProgressFunc) (config.TestResult, error) {
+	// 이 테스트의 로그에는 모두 testId를 붙임
+	log := logger.ForTest(logger.FromContext(ctx), req.ID)
 
 	// 테스트 시작 로깅
 	log.Infow("부하 테스트 시작",
@@ -99,9 +110,46 @@ func RunLoadTest(req config.TestRequest) (config.TestResult, error) {
 		"duration", req.Duration,
 	)
 
+	// 서버 종료 중이면 새 테스트를 시작하지 않음
+	if draining.Load() {
+		return config.TestResult{}, ErrShuttingDown
+	}
+
+	// 안전 정책 검사 (허용 대상, RPS 한도, 소유 확인)
+	if guard != nil {
+		if err := guard(ctx, req); err != nil {
+			log.Warnw("테스트 실행 거부", "target", req.Target, "error", err)
+			return config.TestResult{}, err
+		}
+	}
+
+	// 요청 범위 확인 (범위 밖의 요청은 보내지 않음)
+	sc, err := scope.New(req.Target, req.Scope)
+	if err != nil {
+		return config.TestResult{}, err
+	}
+	req, dropped, err := applyScope(req, sc)
+	if err != nil {
+		return config.TestResult{}, err
+	}
+	if dropped > 0 {
+		log.Warnw("범위 밖의 요청 제외", "count", dropped)
+	}
+
+	// 실행 자리가 날 때까지 대기 (대기열이 설정된 경우)
+	if admission != nil {
+		release, err := admission(ctx, req)
+		if err != nil {
+			log.Warnw("테스트 실행 대기 거부", "target", req.Target, "error", err)
+			return config.TestResult{}, err
+		}
+		defer release()
+	}
+
 	// 결과를 저장할 구조체 생성
 	result := config.TestResult{
-		StatusMap: make(map[int]int),
+		StatusMap:  make(map[int]int),
+		OutOfScope: dropped,
 	}
 	// 평균 응답 시간 누적을 위한 변수
 	var totalLatencySum float64
@@ -109,12 +157,24 @@ func RunLoadTest(req config.TestRequest) (config.TestResult, error) {
 	// 요청 수를 안전하게 업데이트하기 위한 mutex(병렬 접근 대비)
 	var mu sync.Mutex
 
-	//요청을 보낼 초당 주기 설정(예: rps 30이면 초당 30)
-	ticker := time.NewTicker(time.Second / time.Duration(req.RPS))
-	defer ticker.Stop()
+	// 상태 고루틴 종료 신호 (timeout 채널은 값이 한 번만 전달되므로 별도 채널 사용)
+	statusDone := make(chan struct{})
+
+	// 요청 시점: 기본은 초당 주기(예: rps 30이면 초당 30), 재생 모드는 기록된 시점
+	var ticks <-chan time.Time
+	if len(req.Schedule) > 0 {
+		ticks = scheduleTicks(req.Schedule, req.Speed, statusDone)
+	} else {
+		ticker := time.NewTicker(time.Second / time.Duration(req.RPS))
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
 
-	// 테스트 시간 설정
-	timeout := time.After(time.Duration(req.Duration) * time.Second)
+	// 테스트 시간 설정 (재생 모드에서 Duration이 0이면 모든 요청을 보낼 때까지)
+	var timeout <-chan time.Time
+	if len(req.Schedule) == 0 || req.Duration > 0 {
+		timeout = time.After(time.Duration(req.Duration) * time.Second)
+	}
 
 	// WaitGroup : 모든 요청이 끝날 때까지 기다릴 수 있게 함.
 	var wg sync.WaitGroup
@@ -123,10 +183,75 @@ func RunLoadTest(req config.TestRequest) (config.TestResult, error) {
 	statusTicker := time.NewTicker(10 * time.Second)
 	defer statusTicker.Stop()
 
+	// 처리량 계산을 위한 시작 시각
+	testStart := time.Now()
+
+	// 틱마다 보낼 요청 선택기
+	planner := newRequestPlanner(req)
+
+	// 원시 샘플 기록 (저장소가 있으면 실행별 파일, 없으면 결과에 담음)
+	var sampleFile *samples.Writer
+	if req.RecordSamples && sampleStore != nil && req.ID != "" {
+		sampleFile, err = sampleStore.Create(samples.Header{RunID: req.ID, Target: req.Target, StartedAt: testStart})
+		if err != nil {
+			log.Warnw("샘플 파일을 만들지 못해 결과에 기록합니다", "error", err)
+		}
+	}
+	var sampleErr error
+	// recordSample은 mu를 잡은 상태에서 호출
+	recordSample := func(sample config.Sample) {
+		if sampleFile == nil {
+			result.Samples = append(result.Samples, sample)
+			return
+		}
+		if err := sampleFile.Write(sample); err != nil && sampleErr == nil {
+			sampleErr = err
+			log.Warnw("원시 샘플 기록 실패", "error", err)
+		}
+	}
+
+	// 실시간 지표 라벨
+	testID := metricsTestID(req.ID)
+	liveTestsRunning.Add(1)
+	liveTargetRPS.Set(float64(req.RPS), testID, req.Target)
+	defer func() {
+		liveTestsRunning.Add(-1)
+		liveTargetRPS.Set(0, testID, req.Target)
+		releaseLiveMetrics(testID)
+	}()
+
+	// 분산 추적 설정 (활성화된 경우에만 traceparent 헤더 주입)
+	var tracer *tracing.Tracer
+	if req.Tracing != nil && req.Tracing.Enabled {
+		tracer = tracing.NewTracer(traceExporter, req.Tracing.Ratio())
+		defer func() {
+			// 남은 span을 최대 5초 동안 내보낸 뒤 종료
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			tracer.Shutdown(ctx)
+			if dropped, exportErrors := tracer.Stats(); dropped > 0 || exportErrors > 0 {
+				log.Warnw("일부 trace span 전송 실패", "dropped", dropped, "exportErrors", exportErrors)
+			}
+		}()
+	}
+
+	// 중간 결과 전달 주기 (콜백이 없으면 nil 채널이라 선택되지 않음)
+	var progressC <-chan time.Time
+	if progress != nil && interval > 0 {
+		progressTicker := time.NewTicker(interval)
+		defer progressTicker.Stop()
+		progressC = progressTicker.C
+	}
+
 	// 상태 업데이트 고루틴
 	go func() {
 		for {
 			select {
+			case <-progressC:
+				mu.Lock()
+				snapshot := snapshotResult(&result, totalLatencySum, time.Since(testStart).Seconds())
+				mu.Unlock()
+				progress(snapshot)
 			case <-statusTicker.C:
 				mu.Lock()
 				log.Infow("테스트 진행 상황",
@@ -135,7 +260,7 @@ func RunLoadTest(req config.TestRequest) (config.TestResult, error) {
 					"실패", result.FailCount,
 				)
 				mu.Unlock()
-			case <-timeout:
+			case <-statusDone:
 				return
 			}
 		}
@@ -146,25 +271,43 @@ loop:
 		select {
 		case <-timeout:
 			log.Infow("테스트 시간 종료", "duration", req.Duration)
+			close(statusDone)
+			break loop
+		case <-stopRuns:
+			// 서버 종료: 새 요청은 보내지 않고 보낸 요청의 응답까지만 집계
+			log.Warnw("서버 종료로 테스트 중단, 부분 결과를 반환합니다", "target", req.Target, "elapsedSec", time.Since(testStart).Seconds())
+			result.Interrupted = true
+			close(statusDone)
 			break loop
-		case <-ticker.C:
+		case tick, ok := <-ticks:
+			if !ok {
+				log.Infow("재생 완료", "requests", len(req.Schedule))
+				close(statusDone)
+				break loop
+			}
+			// 예정된 틱 시각과 실제 처리 시각의 차이 (부하 생성기 과부하 지표)
+			liveSchedulerLag.Observe(time.Since(tick).Seconds(), testID)
+
+			// 요청 선택 (재생 순서, 요청 목록 가중치 또는 경로 랜덤)
+			planned := planner.next(req)
+
+			// 템플릿을 채운 뒤의 URL도 범위 안인지 다시 확인
+			if !sc.AllowsURL(planned.url) {
+				mu.Lock()
+				result.OutOfScope++
+				mu.Unlock()
+				continue
+			}
+
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
 
-				//경로 + 헤더 랜덤 선택
-				selectedPath := GetRandomPath(req.PathList)
-				url := strings.TrimRight(req.Target, "/") + "/" + strings.TrimLeft(selectedPath, "/")
-
-				headers := GetRandomHeaderSet(req.Headers)
+				// 헤더 랜덤 선택
+				url := planned.url
+				endpoint := planned.endpoint
 
-				// 요청 본문 설정
-				var bodyReader io.Reader = nil
-				if strings.ToUpper(req.Method) == "POST" && req.Body != "" {
-					bodyReader = bytes.NewBuffer([]byte(req.Body))
-				}
-
-				httpReq, err := http.NewRequest(req.Method, url, bodyReader)
+				httpReq, err := planned.newHTTPRequest(GetRandomHeaderSet(req.Headers))
 				if err != nil {
 					log.Errorw("요청 생성 실패",
 						"url", url,
@@ -173,24 +316,33 @@ loop:
 					return
 				}
 
-				//랜덤 헤더 적용
-				for k, vs := range headers {
-					for _, v := range vs {
-						httpReq.Header.Add(k, v)
-					}
+				// traceparent 헤더 주입
+				var span tracing.Span
+				if tracer != nil {
+					span.Context = tracer.Start()
+					httpReq.Header.Set("traceparent", span.Context.Traceparent())
 				}
 
 				startTime := time.Now()
 
+				// 원시 샘플을 기록할 때만 구간별 시간 측정
+				var phases *phaseTimer
+				if req.RecordSamples {
+					phases = &phaseTimer{start: startTime}
+					httpReq = httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), phases.trace()))
+				}
+
 				// 요청 보내기
-				timeoutDuration := 10 * time.Second
+				timeoutDuration := defaultTimeout
 				if req.Timeout > 0 {
 					timeoutDuration = time.Duration(req.Timeout) * time.Second
 				}
 
 				client := &http.Client{
-					Timeout: timeoutDuration,
+					Timeout:       timeoutDuration,
+					CheckRedirect: scopedRedirect(sc),
 					Transport: &http.Transport{
+						DialContext:         dialContext,
 						MaxIdleConns:        100,
 						MaxIdleConnsPerHost: 100,
 						IdleConnTimeout:     30 * time.Second,
@@ -198,14 +350,44 @@ loop:
 				}
 
 				// 타임아웃 발생 시 처리
+				liveRequestsSent.Inc(testID, req.Target)
+				liveInFlight.Add(1, testID)
 				resp, err := client.Do(httpReq)
+				liveInFlight.Add(-1, testID)
 				if err != nil {
 					mu.Lock()
 					result.TotalRequests++
 					result.FailCount++
 
 					// 타임아웃 오류 감지
-					if os.IsTimeout(err) || strings.Contains(err.Error(), "timeout") || strings.Contains(err.Error(), "deadline exceeded") {
+					isTimeout := os.IsTimeout(err) || strings.Contains(err.Error(), "timeout") || strings.Contains(err.Error(), "deadline exceeded")
+					recordEndpointError(endpointStats(&result, endpoint), isTimeout)
+					recordTimePointError(timePoint(&result, int(time.Since(testStart).Seconds())))
+					if tracer != nil {
+						finishSpan(tracer, span, httpReq, testID, endpoint, startTime, 0, err)
+					}
+					if isTimeout {
+						liveResponses.Inc(testID, endpoint, "timeout")
+					} else {
+						liveResponses.Inc(testID, endpoint, "error")
+					}
+					if req.RecordSamples {
+						sample := config.Sample{
+							Time:          startTime,
+							Endpoint:      endpoint,
+							Failed:        true,
+							ErrorClass:    classifyError(err, isTimeout),
+							Error:         err.Error(),
+							FailedAfterMs: float64(time.Since(startTime).Microseconds()) / 1000,
+							BytesSent:     max(httpReq.ContentLength, 0),
+						}
+						if isTimeout {
+							sample.Status = -1
+						}
+						phases.apply(&sample)
+						recordSample(sample)
+					}
+					if isTimeout {
 						result.TimeoutCount++
 						if result.StatusMap[-1] == 0 {
 							result.StatusMap[-1] = 1
@@ -227,15 +409,52 @@ loop:
 				defer resp.Body.Close()
 
 				latency := time.Since(startTime)
-				latencyMs := float64(latency.Milliseconds())
+				latencyMs := float64(latency.Microseconds()) / 1000
+				liveResponses.Inc(testID, endpoint, statusLabel(resp.StatusCode))
+				if tracer != nil {
+					finishSpan(tracer, span, httpReq, testID, endpoint, startTime, resp.StatusCode, nil)
+				}
+				liveLatency.Observe(latency.Seconds(), testID, endpoint)
+
+				// 원시 샘플 기록 시에는 응답 본문을 끝까지 읽어 크기와 다운로드 시간을 측정
+				var sample config.Sample
+				if req.RecordSamples {
+					sample = config.Sample{
+						Time:      startTime,
+						Endpoint:  endpoint,
+						Status:    resp.StatusCode,
+						LatencyMs: latencyMs,
+						BytesSent: max(httpReq.ContentLength, 0),
+					}
+					phases.apply(&sample)
+					readStart := time.Now()
+					n, err := io.Copy(io.Discard, resp.Body)
+					sample.BytesReceived = n
+					sample.DownloadMs = float64(time.Since(readStart).Microseconds()) / 1000
+					if err != nil {
+						sample.Error = err.Error()
+					}
+				}
 
 				// 응답 코드 저장
 				mu.Lock()
 				result.TotalRequests++
 				totalLatencySum += latencyMs
+				result.Latency.Add(latencyMs)
+				success := planned.spec.Expects(resp.StatusCode)
+				recordEndpointLatency(endpointStats(&result, endpoint), latencyMs, success)
+				recordTimePointLatency(timePoint(&result, int(time.Since(testStart).Seconds())), latencyMs, success)
+				result.StatusMap[resp.StatusCode]++
+				if req.RecordSamples {
+					if !success {
+						sample.Failed = true
+						sample.ErrorClass = ErrorClassStatus
+					}
+					recordSample(sample)
+				}
 
-				// 응답 코드 처리
-				if resp.StatusCode == 200 {
+				// 응답 코드 처리 (기대한 응답 코드면 성공, 기본은 200)
+				if success {
 					result.SuccessCount++
 					log.Debugw("요청 성공",
 						"url", url,
@@ -252,7 +471,6 @@ loop:
 							"latencyMs", latencyMs,
 						)
 					}
-					result.StatusMap[resp.StatusCode]++
 				}
 
 				// latency 통계 누적
@@ -284,11 +502,24 @@ loop:
 	log.Info("모든 요청 완료 대기 중...")
 	wg.Wait()
 
-	// 평균 응답 시간 계산
-	if result.TotalRequests > 0 {
-		result.AvgLatencyMs = totalLatencySum / float64(result.TotalRequests)
+	if sampleFile != nil {
+		if err := sampleFile.Close(); err != nil {
+			log.Warnw("샘플 파일 저장 실패", "error", err)
+		}
+		result.RecordedSamples = sampleFile.Count()
+	}
+
+	// 평균 응답 시간 계산 (응답을 받은 요청 기준)
+	if result.Latency.Count > 0 {
+		result.AvgLatencyMs = totalLatencySum / float64(result.Latency.Count)
 	}
 
+	// 분위수, 오류율, 처리량 계산
+	FinalizeResult(&result, time.Since(testStart).Seconds())
+
+	// 합격 기준 판정
+	applyThresholds(&result, req.Thresholds)
+
 	// 테스트 결과 요약 로깅
 	log.Infow("테스트 완료",
 		"총요청", result.TotalRequests,
@@ -296,6 +527,9 @@ loop:
 		"실패", result.FailCount,
 		"타임아웃", result.TimeoutCount,
 		"평균응답시간", fmt.Sprintf("%.2fms", result.AvgLatencyMs),
+		"p95", fmt.Sprintf("%.2fms", result.P95LatencyMs),
+		"처리량", fmt.Sprintf("%.2f/s", result.ThroughputRPS),
+		"합격", result.Passed,
 	)
 
 	return result, nil
@@ -0,0 +1,96 @@
+package loadtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/tracing"
+	"github.com/Mr-Muji/LoadTest/libs/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRunLoadTestLogsToContextLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := logger.WithContext(context.Background(), zap.New(core).Sugar())
+	req := config.TestRequest{ID: "run-1", Target: srv.URL, Method: "GET", RPS: 2, Duration: 1, PathList: []string{"/"}, Silent: true}
+	if _, err := RunLoadTestContext(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+
+	started := logs.FilterMessage("부하 테스트 시작").All()
+	if len(started) != 1 || started[0].ContextMap()["testId"] != "run-1" {
+		t.Errorf("start log = %+v, want one entry with testId", started)
+	}
+	if logs.FilterMessage("테스트 완료").Len() != 1 {
+		t.Error("completion was not logged to the context logger")
+	}
+
+	// 컨텍스트 없이 실행해도 넘겨받은 로거로 남김
+	core, logs = observer.New(zap.InfoLevel)
+	req.ID = "run-2"
+	if _, err := RunLoadTest(zap.New(core).Sugar(), req); err != nil {
+		t.Fatal(err)
+	}
+	if started := logs.FilterMessage("부하 테스트 시작").All(); len(started) != 1 || started[0].ContextMap()["testId"] != "run-2" {
+		t.Errorf("RunLoadTest start log = %+v, want one entry with testId", started)
+	}
+}
+
+// memExporter는 내보낸 span을 메모리에 모으는 Exporter
+type memExporter struct {
+	mu    sync.Mutex
+	spans []tracing.Span
+}
+
+func (e *memExporter) Export(ctx context.Context, spans []tracing.Span) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func TestRunLoadTestPropagatesTraceparent(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.Header.Get("traceparent")] = true
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	exp := &memExporter{}
+	SetTraceExporter(exp)
+	defer SetTraceExporter(nil)
+
+	req := config.TestRequest{
+		ID: "run-1", Target: srv.URL, Method: "GET", RPS: 5, Duration: 1, PathList: []string{"/"}, Silent: true,
+		Tracing: &config.TracingConfig{Enabled: true},
+	}
+	result, err := RunLoadTestContext(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 요청마다 새 trace를 시작하고, 내보낸 span은 대상이 받은 traceparent와 일치
+	if len(seen) != result.TotalRequests || len(exp.spans) != result.TotalRequests {
+		t.Fatalf("requests %d, distinct traceparents %d, exported spans %d", result.TotalRequests, len(seen), len(exp.spans))
+	}
+	for _, span := range exp.spans {
+		if !seen[span.Context.Traceparent()] {
+			t.Errorf("span %s was not propagated to the target", span.Context.Traceparent())
+		}
+		if !strings.HasSuffix(span.Context.Traceparent(), "-01") {
+			t.Errorf("span %s is not sampled", span.Context.Traceparent())
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package loadtest
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http/httptrace"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/samples"
+)
+
+// sampleStore는 원시 샘플 파일 저장소 (nil이면 샘플을 결과에 담아 반환)
+var sampleStore *samples.Store
+
+// SetSampleStore는 RecordSamples 실행의 원시 샘플을 기록할 저장소를 설정
+// 설정하면 샘플을 메모리에 모으지 않고 실행 ID별 파일에 바로 기록합니다.
+func SetSampleStore(s *samples.Store) {
+	sampleStore = s
+}
+
+// 실패 분류 (Sample.ErrorClass)
+const (
+	ErrorClassTimeout = "timeout" // 제한 시간 초과
+	ErrorClassDNS     = "dns"     // 호스트 이름 조회 실패
+	ErrorClassRefused = "refused" // 연결 거부
+	ErrorClassReset   = "reset"   // 연결 끊김
+	ErrorClassTLS     = "tls"     // 인증서/핸드셰이크 오류
+	ErrorClassEOF     = "eof"     // 응답 도중 연결 종료
+	ErrorClassStatus  = "status"  // 기대하지 않은 응답 코드
+	ErrorClassOther   = "other"   // 그 밖의 오류
+)
+
+// classifyError는 요청 오류를 실패 분류로 변환
+func classifyError(err error, timeout bool) string {
+	var dnsErr *net.DNSError
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	switch {
+	case timeout:
+		return ErrorClassTimeout
+	case errors.As(err, &dnsErr):
+		return ErrorClassDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ErrorClassRefused
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE):
+		return ErrorClassReset
+	case errors.As(err, &certErr), errors.As(err, &recordErr), errors.As(err, &unknownAuthority), errors.As(err, &hostnameErr):
+		return ErrorClassTLS
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrorClassEOF
+	}
+	return ErrorClassOther
+}
+
+// phaseTimer는 httptrace로 요청 구간(DNS, 연결, TLS, 첫 바이트)의 시각을 기록
+type phaseTimer struct {
+	mu                  sync.Mutex
+	start               time.Time
+	dnsStart, dnsDone   time.Time
+	connStart, connDone time.Time
+	tlsStart, tlsDone   time.Time
+	firstByte           time.Time
+}
+
+// trace는 구간 시각을 기록하는 ClientTrace를 반환
+// 연결을 동시에 여러 개 시도하는 경우가 있어 잠금으로 보호합니다.
+func (p *phaseTimer) trace() *httptrace.ClientTrace {
+	mark := func(t *time.Time) {
+		p.mu.Lock()
+		if t.IsZero() {
+			*t = time.Now()
+		}
+		p.mu.Unlock()
+	}
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { mark(&p.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { mark(&p.dnsDone) },
+		ConnectStart:         func(string, string) { mark(&p.connStart) },
+		ConnectDone:          func(string, string, error) { mark(&p.connDone) },
+		TLSHandshakeStart:    func() { mark(&p.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { mark(&p.tlsDone) },
+		GotFirstResponseByte: func() { mark(&p.firstByte) },
+	}
+}
+
+// apply는 기록된 구간 시간을 샘플에 채움
+func (p *phaseTimer) apply(s *config.Sample) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s.DNSMs = spanMs(p.dnsStart, p.dnsDone)
+	s.ConnectMs = spanMs(p.connStart, p.connDone)
+	s.TLSMs = spanMs(p.tlsStart, p.tlsDone)
+	s.TTFBMs = spanMs(p.start, p.firstByte)
+}
+
+// spanMs는 두 시각의 차이(ms), 어느 한쪽이 없으면 0
+func spanMs(from, to time.Time) float64 {
+	if from.IsZero() || to.IsZero() {
+		return 0
+	}
+	return float64(to.Sub(from).Microseconds()) / 1000
+}
+
+// Aggregator는 원시 샘플로 실행 결과를 다시 계산 (샘플 파일 재집계용)
+// 부하 테스트 실행과 같은 방식으로 엔드포인트 통계, 시계열, 분위수를 계산합니다.
+type Aggregator struct {
+	start      time.Time
+	result     config.TestResult
+	latencySum float64
+}
+
+// NewAggregator는 start(측정 시작 시각)를 시계열 기준으로 하는 재집계기를 생성
+func NewAggregator(start time.Time) *Aggregator {
+	return &Aggregator{
+		start:  start,
+		result: config.TestResult{StatusMap: make(map[int]int)},
+	}
+}
+
+// Add는 샘플 하나를 결과에 반영
+func (a *Aggregator) Add(s config.Sample) {
+	result := &a.result
+	result.TotalRequests++
+
+	// 응답을 받지 못한 요청 (타임아웃, 연결 실패)
+	if s.Status <= 0 {
+		timeout := s.Status == -1
+		result.FailCount++
+		recordEndpointError(endpointStats(result, s.Endpoint), timeout)
+		recordTimePointError(timePoint(result, a.second(s.Time, s.FailedAfterMs)))
+		if timeout {
+			result.TimeoutCount++
+			result.StatusMap[-1]++
+		}
+		return
+	}
+
+	success := !s.Failed
+	a.latencySum += s.LatencyMs
+	result.Latency.Add(s.LatencyMs)
+	recordEndpointLatency(endpointStats(result, s.Endpoint), s.LatencyMs, success)
+	recordTimePointLatency(timePoint(result, a.second(s.Time, s.LatencyMs)), s.LatencyMs, success)
+	result.StatusMap[s.Status]++
+	if success {
+		result.SuccessCount++
+	} else {
+		result.FailCount++
+	}
+	if s.LatencyMs > result.MaxLatencyMs {
+		result.MaxLatencyMs = s.LatencyMs
+	}
+	if s.LatencyMs > 500 {
+		result.SlowCountOver500++
+	}
+}
+
+// second는 요청이 끝난 시점의 경과 초 (실행 중 집계와 같은 기준)
+func (a *Aggregator) second(started time.Time, durationMs float64) int {
+	done := started.Add(time.Duration(durationMs * float64(time.Millisecond)))
+	return int(done.Sub(a.start).Seconds())
+}
+
+// Result는 elapsedSec(측정 시간) 기준으로 분위수, 처리량, 합격 기준을 계산한 결과를 반환
+func (a *Aggregator) Result(elapsedSec float64, thresholds []config.Threshold) config.TestResult {
+	result := a.result
+	if result.Latency.Count > 0 {
+		result.AvgLatencyMs = a.latencySum / float64(result.Latency.Count)
+	}
+	FinalizeResult(&result, elapsedSec)
+	applyThresholds(&result, thresholds)
+	return result
+}
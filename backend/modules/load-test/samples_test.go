@@ -0,0 +1,104 @@
+package loadtest
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+func TestAggregatorRecomputesResult(t *testing.T) {
+	start := time.Date(2025, 4, 12, 21, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	a := NewAggregator(start)
+	for _, s := range []config.Sample{
+		{Time: at(0), Endpoint: "GET /", Status: 200, LatencyMs: 100},
+		{Time: at(500), Endpoint: "GET /", Status: 200, LatencyMs: 700}, // 1.2초에 끝남
+		{Time: at(1200), Endpoint: "GET /api", Status: 500, LatencyMs: 50, Failed: true, ErrorClass: ErrorClassStatus},
+		{Time: at(1500), Endpoint: "GET /api", Status: -1, Failed: true, ErrorClass: ErrorClassTimeout, FailedAfterMs: 1000},
+		{Time: at(1600), Endpoint: "GET /", Status: 0, Failed: true, ErrorClass: ErrorClassRefused, FailedAfterMs: 5},
+	} {
+		a.Add(s)
+	}
+	result := a.Result(2, []config.Threshold{{Metric: "errorRate", Operator: "<", Value: 0.1}})
+
+	if result.TotalRequests != 5 || result.SuccessCount != 2 || result.FailCount != 3 || result.TimeoutCount != 1 {
+		t.Errorf("counts = total %d, success %d, fail %d, timeout %d",
+			result.TotalRequests, result.SuccessCount, result.FailCount, result.TimeoutCount)
+	}
+	if result.StatusMap[200] != 2 || result.StatusMap[500] != 1 || result.StatusMap[-1] != 1 || len(result.StatusMap) != 3 {
+		t.Errorf("StatusMap = %v", result.StatusMap)
+	}
+	// 평균과 히스토그램은 응답을 받은 요청만 반영
+	if math.Abs(result.AvgLatencyMs-850.0/3) > 1e-9 || result.MaxLatencyMs != 700 || result.Latency.Count != 3 {
+		t.Errorf("latency = avg %v, max %v, count %d", result.AvgLatencyMs, result.MaxLatencyMs, result.Latency.Count)
+	}
+	if result.SlowCountOver500 != 1 || result.ErrorRate != 0.6 || result.ThroughputRPS != 2.5 {
+		t.Errorf("slow %d, errorRate %v, throughput %v", result.SlowCountOver500, result.ErrorRate, result.ThroughputRPS)
+	}
+
+	root, api := result.Endpoints[endpointKey("GET", "/")], result.Endpoints[endpointKey("GET", "/api")]
+	if root == nil || api == nil || root.TotalRequests != 3 || root.FailCount != 1 || api.TimeoutCount != 1 || api.ErrorRate != 1 {
+		t.Errorf("endpoints = %+v, %+v", root, api)
+	}
+
+	// 시계열은 요청이 끝난 초에 집계
+	requests := map[int]int{}
+	errs := map[int]int{}
+	for _, p := range result.TimeSeries {
+		requests[p.Second] = p.Requests
+		errs[p.Second] = p.Errors
+	}
+	if requests[0] != 1 || requests[1] != 3 || requests[2] != 1 || errs[1] != 2 || errs[2] != 1 {
+		t.Errorf("time series requests = %v, errors = %v", requests, errs)
+	}
+
+	if result.Passed || len(result.Thresholds) != 1 || result.Thresholds[0].Passed {
+		t.Errorf("thresholds = %+v, passed = %v", result.Thresholds, result.Passed)
+	}
+}
+
+func TestAggregatorMatchesRunResult(t *testing.T) {
+	var n atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n.Add(1)%3 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	req := config.TestRequest{Target: srv.URL, Method: "GET", RPS: 20, Duration: 1, PathList: []string{"/"}, Silent: true, RecordSamples: true}
+	result, err := RunLoadTestContext(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Samples) != result.TotalRequests || result.TotalRequests == 0 {
+		t.Fatalf("recorded %d samples for %d requests", len(result.Samples), result.TotalRequests)
+	}
+
+	// 원시 샘플로 다시 계산한 결과는 실행 중 집계와 같아야 함
+	a := NewAggregator(result.Samples[0].Time)
+	for _, s := range result.Samples {
+		a.Add(s)
+	}
+	got := a.Result(result.ElapsedSec, nil)
+	if got.TotalRequests != result.TotalRequests || got.SuccessCount != result.SuccessCount || got.FailCount != result.FailCount {
+		t.Errorf("recomputed counts = %d/%d/%d, run = %d/%d/%d",
+			got.TotalRequests, got.SuccessCount, got.FailCount,
+			result.TotalRequests, result.SuccessCount, result.FailCount)
+	}
+	for status, count := range result.StatusMap {
+		if got.StatusMap[status] != count {
+			t.Errorf("StatusMap[%d] = %d, run = %d", status, got.StatusMap[status], count)
+		}
+	}
+	if got.MaxLatencyMs != result.MaxLatencyMs || got.Latency.Count != result.Latency.Count {
+		t.Errorf("recomputed max %v (n=%d), run max %v (n=%d)", got.MaxLatencyMs, got.Latency.Count, result.MaxLatencyMs, result.Latency.Count)
+	}
+}
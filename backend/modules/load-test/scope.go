@@ -0,0 +1,77 @@
+package loadtest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/scope"
+)
+
+// 범위 안에서 따라갈 최대 리다이렉트 수 (http.Client 기본값과 동일)
+const maxRedirects = 10
+
+// applyScope는 범위 밖의 경로/요청/재생 요청을 제외한 요청 설정을 반환
+// 제외된 요청 수를 함께 반환하며, 보낼 요청이 하나도 남지 않으면 오류를 반환합니다.
+func applyScope(req config.TestRequest, sc *scope.Scope) (config.TestRequest, int, error) {
+	target := strings.TrimRight(req.Target, "/")
+	dropped := 0
+
+	if len(req.Schedule) > 0 {
+		kept := make([]config.ScheduledRequest, 0, len(req.Schedule))
+		for _, s := range req.Schedule {
+			if sc.AllowsURL(requestURL(target, s.Path)) {
+				kept = append(kept, s)
+			} else {
+				dropped++
+			}
+		}
+		req.Schedule = kept
+		if len(kept) == 0 {
+			return req, dropped, fmt.Errorf("범위 안에 재생할 요청이 없습니다")
+		}
+		return req, dropped, nil
+	}
+
+	if len(req.Requests) > 0 {
+		kept := make([]config.RequestSpec, 0, len(req.Requests))
+		for _, spec := range req.Requests {
+			if sc.AllowsURL(requestURL(target, spec.Path)) {
+				kept = append(kept, spec)
+			} else {
+				dropped++
+			}
+		}
+		req.Requests = kept
+		if len(kept) == 0 {
+			return req, dropped, fmt.Errorf("범위 안에 보낼 요청이 없습니다")
+		}
+		return req, dropped, nil
+	}
+
+	kept := make([]string, 0, len(req.PathList))
+	for _, p := range req.PathList {
+		if sc.AllowsURL(requestURL(target, p)) {
+			kept = append(kept, p)
+		} else {
+			dropped++
+		}
+	}
+	req.PathList = kept
+	if len(kept) == 0 && dropped > 0 {
+		return req, dropped, fmt.Errorf("범위 안에 보낼 경로가 없습니다")
+	}
+	return req, dropped, nil
+}
+
+// scopedRedirect는 범위 밖으로 향하는 리다이렉트를 따라가지 않는 CheckRedirect 함수를 반환
+// 따라가지 않은 리다이렉트는 3xx 응답 그대로 기록됩니다.
+func scopedRedirect(sc *scope.Scope) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects || !sc.Allows(req.URL) {
+			return http.ErrUseLastResponse
+		}
+		return nil
+	}
+}
@@ -0,0 +1,300 @@
+package loadtest
+
+import (
+	"sort"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// endpointKey는 엔드포인트 통계에 사용할 키("METHOD /path")를 생성
+func endpointKey(method, path string) string {
+	if path == "" || path[0] != '/' {
+		path = "/" + path
+	}
+	return method + " " + path
+}
+
+// endpointStats는 키에 해당하는 엔드포인트 통계를 반환 (없으면 생성)
+func endpointStats(result *config.TestResult, key string) *config.EndpointStats {
+	if result.Endpoints == nil {
+		result.Endpoints = make(map[string]*config.EndpointStats)
+	}
+	stats, ok := result.Endpoints[key]
+	if !ok {
+		stats = &config.EndpointStats{}
+		result.Endpoints[key] = stats
+	}
+	return stats
+}
+
+// recordEndpointLatency는 응답을 받은 요청을 엔드포인트 통계에 반영
+func recordEndpointLatency(stats *config.EndpointStats, latencyMs float64, success bool) {
+	stats.TotalRequests++
+	if success {
+		stats.SuccessCount++
+	} else {
+		stats.FailCount++
+	}
+	// 평균은 히스토그램 샘플 수를 기준으로 누적 갱신
+	stats.AvgLatencyMs += (latencyMs - stats.AvgLatencyMs) / float64(stats.Latency.Count+1)
+	stats.Latency.Add(latencyMs)
+	if latencyMs > stats.MaxLatencyMs {
+		stats.MaxLatencyMs = latencyMs
+	}
+}
+
+// recordEndpointError는 응답을 받지 못한 요청(타임아웃, 연결 실패)을 엔드포인트 통계에 반영
+func recordEndpointError(stats *config.EndpointStats, timeout bool) {
+	stats.TotalRequests++
+	stats.FailCount++
+	if timeout {
+		stats.TimeoutCount++
+	}
+}
+
+// FinalizeResult는 누적된 카운트와 히스토그램으로 분위수, 오류율, 처리량을 계산
+// elapsedSec는 실제 측정 시간(초)이며 0이면 처리량을 계산하지 않습니다.
+func FinalizeResult(result *config.TestResult, elapsedSec float64) {
+	result.ElapsedSec = elapsedSec
+	result.P50LatencyMs = result.Latency.Quantile(0.50)
+	result.P90LatencyMs = result.Latency.Quantile(0.90)
+	result.P95LatencyMs = result.Latency.Quantile(0.95)
+	result.P99LatencyMs = result.Latency.Quantile(0.99)
+
+	if result.TotalRequests > 0 {
+		result.ErrorRate = float64(result.FailCount) / float64(result.TotalRequests)
+	}
+	if elapsedSec > 0 {
+		result.ThroughputRPS = float64(result.TotalRequests) / elapsedSec
+	}
+
+	for _, stats := range result.Endpoints {
+		stats.P50LatencyMs = stats.Latency.Quantile(0.50)
+		stats.P95LatencyMs = stats.Latency.Quantile(0.95)
+		stats.P99LatencyMs = stats.Latency.Quantile(0.99)
+		if stats.TotalRequests > 0 {
+			stats.ErrorRate = float64(stats.FailCount) / float64(stats.TotalRequests)
+		}
+	}
+}
+
+// timePoint는 경과 초에 해당하는 시계열 구간을 반환 (없으면 생성)
+func timePoint(result *config.TestResult, second int) *config.TimePoint {
+	if second < 0 {
+		second = 0
+	}
+	for len(result.TimeSeries) <= second {
+		result.TimeSeries = append(result.TimeSeries, config.TimePoint{Second: len(result.TimeSeries)})
+	}
+	return &result.TimeSeries[second]
+}
+
+// recordTimePointLatency는 응답을 받은 요청을 시계열에 반영
+func recordTimePointLatency(point *config.TimePoint, latencyMs float64, success bool) {
+	point.Requests++
+	if !success {
+		point.Errors++
+	}
+	point.Responses++
+	point.AvgLatencyMs += (latencyMs - point.AvgLatencyMs) / float64(point.Responses)
+	if latencyMs > point.MaxLatencyMs {
+		point.MaxLatencyMs = latencyMs
+	}
+}
+
+// recordTimePointError는 응답을 받지 못한 요청을 시계열에 반영
+func recordTimePointError(point *config.TimePoint) {
+	point.Requests++
+	point.Errors++
+}
+
+// metricValue는 합격 기준 지표 이름에 해당하는 결과 값을 반환
+func metricValue(result config.TestResult, metric string) (float64, bool) {
+	switch metric {
+	case "avg":
+		return result.AvgLatencyMs, true
+	case "max":
+		return result.MaxLatencyMs, true
+	case "p50":
+		return result.P50LatencyMs, true
+	case "p90":
+		return result.P90LatencyMs, true
+	case "p95":
+		return result.P95LatencyMs, true
+	case "p99":
+		return result.P99LatencyMs, true
+	case "errorRate":
+		return result.ErrorRate, true
+	case "throughput":
+		return result.ThroughputRPS, true
+	}
+	return 0, false
+}
+
+// EvaluateThresholds는 결과를 합격 기준과 비교하여 판정 목록을 반환
+// 알 수 없는 지표나 연산자는 불합격으로 처리합니다.
+func EvaluateThresholds(result config.TestResult, thresholds []config.Threshold) []config.ThresholdVerdict {
+	verdicts := make([]config.ThresholdVerdict, 0, len(thresholds))
+	for _, t := range thresholds {
+		actual, ok := metricValue(result, t.Metric)
+		passed := false
+		if ok {
+			switch t.Operator {
+			case "<":
+				passed = actual < t.Value
+			case "<=":
+				passed = actual <= t.Value
+			case ">":
+				passed = actual > t.Value
+			case ">=":
+				passed = actual >= t.Value
+			}
+		}
+		verdicts = append(verdicts, config.ThresholdVerdict{Threshold: t, Actual: actual, Passed: passed})
+	}
+	return verdicts
+}
+
+// Verdicts는 저장된 결과의 합격 기준 판정과 전체 합격 여부를 반환
+// 이전 버전 기록처럼 판정이 없으면 요청의 합격 기준(없으면 기본값)으로 다시 계산합니다.
+func Verdicts(result config.TestResult, thresholds []config.Threshold) ([]config.ThresholdVerdict, bool) {
+	verdicts := result.Thresholds
+	if len(verdicts) == 0 {
+		if len(thresholds) == 0 {
+			thresholds = config.DefaultThresholds()
+		}
+		verdicts = EvaluateThresholds(result, thresholds)
+	}
+	passed := true
+	for _, v := range verdicts {
+		if !v.Passed {
+			passed = false
+		}
+	}
+	return verdicts, passed
+}
+
+// applyThresholds는 요청의 합격 기준(없으면 기본값)을 평가하여 결과에 기록
+func applyThresholds(result *config.TestResult, thresholds []config.Threshold) {
+	if len(thresholds) == 0 {
+		thresholds = config.DefaultThresholds()
+	}
+	result.Thresholds = EvaluateThresholds(*result, thresholds)
+	result.Passed = true
+	for _, v := range result.Thresholds {
+		if !v.Passed {
+			result.Passed = false
+		}
+	}
+}
+
+// copyHistogram은 히스토그램의 깊은 복사본을 반환
+func copyHistogram(h config.Histogram) config.Histogram {
+	return config.Histogram{
+		Buckets: append([]config.HistogramBucket(nil), h.Buckets...),
+		Count:   h.Count,
+		MinMs:   h.MinMs,
+		MaxMs:   h.MaxMs,
+	}
+}
+
+// snapshotResult는 실행 중인 결과의 복사본을 만들어 분위수까지 계산 (원시 샘플 제외)
+// 호출하는 쪽에서 결과를 보호하는 mutex를 잡고 있어야 합니다.
+func snapshotResult(result *config.TestResult, totalLatencySum, elapsedSec float64) config.TestResult {
+	snap := *result
+	snap.Samples = nil
+	snap.Latency = copyHistogram(result.Latency)
+	snap.TimeSeries = append([]config.TimePoint(nil), result.TimeSeries...)
+
+	snap.StatusMap = make(map[int]int, len(result.StatusMap))
+	for code, count := range result.StatusMap {
+		snap.StatusMap[code] = count
+	}
+
+	if result.Endpoints != nil {
+		snap.Endpoints = make(map[string]*config.EndpointStats, len(result.Endpoints))
+		for key, stats := range result.Endpoints {
+			copied := *stats
+			copied.Latency = copyHistogram(stats.Latency)
+			snap.Endpoints[key] = &copied
+		}
+	}
+
+	if snap.Latency.Count > 0 {
+		snap.AvgLatencyMs = totalLatencySum / float64(snap.Latency.Count)
+	}
+	FinalizeResult(&snap, elapsedSec)
+	return snap
+}
+
+// weightedAvg는 샘플 수로 가중한 평균을 계산
+func weightedAvg(avgA float64, countA int, avgB float64, countB int) float64 {
+	if countA+countB == 0 {
+		return 0
+	}
+	return (avgA*float64(countA) + avgB*float64(countB)) / float64(countA+countB)
+}
+
+// MergeResults는 여러 부하 생성기(에이전트)의 결과를 하나로 합침
+// 히스토그램 버킷이 고정되어 있어 분위수도 합쳐진 분포에서 다시 계산됩니다.
+// 시계열은 모든 결과가 같은 시각에 시작했다고 가정하고 초 단위로 합칩니다.
+func MergeResults(results []config.TestResult, thresholds []config.Threshold) config.TestResult {
+	merged := config.TestResult{StatusMap: make(map[int]int)}
+
+	for _, r := range results {
+		merged.AvgLatencyMs = weightedAvg(merged.AvgLatencyMs, merged.Latency.Count, r.AvgLatencyMs, r.Latency.Count)
+		merged.Latency.Merge(r.Latency)
+
+		merged.TotalRequests += r.TotalRequests
+		merged.SuccessCount += r.SuccessCount
+		merged.FailCount += r.FailCount
+		merged.TimeoutCount += r.TimeoutCount
+		merged.SlowCountOver500 += r.SlowCountOver500
+		merged.OutOfScope += r.OutOfScope
+		merged.Interrupted = merged.Interrupted || r.Interrupted
+		for code, count := range r.StatusMap {
+			merged.StatusMap[code] += count
+		}
+		if r.MaxLatencyMs > merged.MaxLatencyMs {
+			merged.MaxLatencyMs = r.MaxLatencyMs
+		}
+		if r.ElapsedSec > merged.ElapsedSec {
+			merged.ElapsedSec = r.ElapsedSec
+		}
+
+		for key, stats := range r.Endpoints {
+			m := endpointStats(&merged, key)
+			m.AvgLatencyMs = weightedAvg(m.AvgLatencyMs, m.Latency.Count, stats.AvgLatencyMs, stats.Latency.Count)
+			m.Latency.Merge(stats.Latency)
+			m.TotalRequests += stats.TotalRequests
+			m.SuccessCount += stats.SuccessCount
+			m.FailCount += stats.FailCount
+			m.TimeoutCount += stats.TimeoutCount
+			if stats.MaxLatencyMs > m.MaxLatencyMs {
+				m.MaxLatencyMs = stats.MaxLatencyMs
+			}
+		}
+
+		for _, p := range r.TimeSeries {
+			m := timePoint(&merged, p.Second)
+			m.AvgLatencyMs = weightedAvg(m.AvgLatencyMs, m.Responses, p.AvgLatencyMs, p.Responses)
+			m.Requests += p.Requests
+			m.Errors += p.Errors
+			m.Responses += p.Responses
+			if p.MaxLatencyMs > m.MaxLatencyMs {
+				m.MaxLatencyMs = p.MaxLatencyMs
+			}
+		}
+
+		merged.Samples = append(merged.Samples, r.Samples...)
+		merged.RecordedSamples += r.RecordedSamples
+	}
+
+	sort.Slice(merged.Samples, func(i, j int) bool {
+		return merged.Samples[i].Time.Before(merged.Samples[j].Time)
+	})
+
+	FinalizeResult(&merged, merged.ElapsedSec)
+	applyThresholds(&merged, thresholds)
+	return merged
+}
@@ -0,0 +1,32 @@
+package loadtest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/tracing"
+)
+
+// finishSpan은 요청 하나에 대한 클라이언트 span을 완성하여 트레이서에 전달
+// OpenTelemetry HTTP 시맨틱 규약의 속성 이름을 사용합니다.
+func finishSpan(tracer *tracing.Tracer, span tracing.Span, httpReq *http.Request, testID, endpoint string, start time.Time, status int, err error) {
+	span.Name = httpReq.Method
+	span.Start = start
+	span.End = time.Now()
+	span.Attributes = []tracing.Attribute{
+		tracing.String("http.request.method", httpReq.Method),
+		tracing.String("url.full", httpReq.URL.String()),
+		tracing.String("server.address", httpReq.URL.Hostname()),
+		tracing.String("loadtest.test_id", testID),
+		tracing.String("loadtest.endpoint", endpoint),
+	}
+	if err != nil {
+		span.Error = err.Error()
+	} else {
+		span.Attributes = append(span.Attributes, tracing.Int("http.response.status_code", int64(status)))
+		if status >= 500 {
+			span.Error = http.StatusText(status)
+		}
+	}
+	tracer.Finish(span)
+}
@@ -0,0 +1,289 @@
+// package metrics는 Prometheus 텍스트 노출 형식의 실시간 지표 레지스트리를 제공합니다.
+// 외부 클라이언트 라이브러리 없이 카운터, 게이지, 히스토그램과 Go 런타임 지표를 지원합니다.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 지표 유형
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+)
+
+// DefaultBuckets는 응답 시간(초) 히스토그램의 기본 버킷
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// series는 라벨 값 조합 하나에 대한 지표 값
+type series struct {
+	labelValues []string
+	value       float64  // 카운터/게이지 값
+	buckets     []uint64 // 히스토그램 버킷별 개수 (누적 아님)
+	sum         float64  // 히스토그램 합계
+	count       uint64   // 히스토그램 샘플 수
+}
+
+// vec은 같은 이름을 가진 지표의 모든 라벨 조합을 관리
+type vec struct {
+	name    string
+	help    string
+	typ     string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+// get은 라벨 값에 해당하는 series를 반환 (없으면 생성, 호출 시 mu 보유 필요)
+func (v *vec) get(labelValues []string) *series {
+	if len(labelValues) != len(v.labels) {
+		panic(fmt.Sprintf("metrics: %s 라벨 개수 불일치 (필요 %d, 입력 %d)", v.name, len(v.labels), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	s, ok := v.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+		if v.typ == typeHistogram {
+			s.buckets = make([]uint64, len(v.buckets))
+		}
+		v.series[key] = s
+	}
+	return s
+}
+
+// DeleteMatching은 label 값이 value인 모든 series를 삭제
+// 테스트가 끝난 뒤 test_id 라벨 series를 정리할 때 사용합니다.
+func (v *vec) DeleteMatching(label, value string) {
+	index := -1
+	for i, l := range v.labels {
+		if l == label {
+			index = i
+		}
+	}
+	if index < 0 {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for key, s := range v.series {
+		if s.labelValues[index] == value {
+			delete(v.series, key)
+		}
+	}
+}
+
+// CounterVec은 증가만 하는 카운터
+type CounterVec struct{ *vec }
+
+// Add는 카운터에 delta를 더함 (음수는 무시)
+func (c CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		return
+	}
+	c.mu.Lock()
+	c.get(labelValues).value += delta
+	c.mu.Unlock()
+}
+
+// Inc는 카운터를 1 증가
+func (c CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// GaugeVec은 증감 가능한 게이지
+type GaugeVec struct{ *vec }
+
+// Set은 게이지 값을 설정
+func (g GaugeVec) Set(value float64, labelValues ...string) {
+	g.mu.Lock()
+	g.get(labelValues).value = value
+	g.mu.Unlock()
+}
+
+// Add는 게이지 값에 delta를 더함
+func (g GaugeVec) Add(delta float64, labelValues ...string) {
+	g.mu.Lock()
+	g.get(labelValues).value += delta
+	g.mu.Unlock()
+}
+
+// HistogramVec은 값의 분포를 버킷으로 집계
+type HistogramVec struct{ *vec }
+
+// Observe는 값 하나를 히스토그램에 추가
+func (h HistogramVec) Observe(value float64, labelValues ...string) {
+	h.mu.Lock()
+	s := h.get(labelValues)
+	i := sort.SearchFloat64s(h.buckets, value)
+	if i < len(s.buckets) {
+		s.buckets[i]++
+	}
+	s.sum += value
+	s.count++
+	h.mu.Unlock()
+}
+
+// Registry는 지표 목록을 보관하고 노출 형식으로 출력
+type Registry struct {
+	mu      sync.Mutex
+	vecs    []*vec
+	runtime bool // Go 런타임 지표 포함 여부
+}
+
+// NewRegistry는 빈 레지스트리를 생성 (withRuntime이면 Go 런타임 지표 포함)
+func NewRegistry(withRuntime bool) *Registry {
+	return &Registry{runtime: withRuntime}
+}
+
+// Default는 애플리케이션 전역 레지스트리 (/metrics 에서 노출)
+var Default = NewRegistry(true)
+
+// register는 새 지표를 레지스트리에 추가
+func (r *Registry) register(name, help, typ string, labels []string, buckets []float64) *vec {
+	v := &vec{
+		name:    name,
+		help:    help,
+		typ:     typ,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.vecs {
+		if existing.name == name {
+			panic("metrics: 중복 등록된 지표 " + name)
+		}
+	}
+	r.vecs = append(r.vecs, v)
+	return v
+}
+
+// NewCounterVec은 레지스트리에 카운터를 등록
+func (r *Registry) NewCounterVec(name, help string, labels ...string) CounterVec {
+	return CounterVec{r.register(name, help, typeCounter, labels, nil)}
+}
+
+// NewGaugeVec은 레지스트리에 게이지를 등록
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) GaugeVec {
+	return GaugeVec{r.register(name, help, typeGauge, labels, nil)}
+}
+
+// NewHistogramVec은 레지스트리에 히스토그램을 등록 (buckets가 nil이면 DefaultBuckets)
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return HistogramVec{r.register(name, help, typeHistogram, labels, buckets)}
+}
+
+// Write는 모든 지표를 Prometheus 텍스트 형식으로 출력
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	vecs := append([]*vec(nil), r.vecs...)
+	r.mu.Unlock()
+
+	sort.Slice(vecs, func(i, j int) bool { return vecs[i].name < vecs[j].name })
+
+	var b strings.Builder
+	for _, v := range vecs {
+		v.write(&b)
+	}
+	if r.runtime {
+		writeRuntime(&b)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Handler는 /metrics 에 연결할 HTTP 핸들러를 반환
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.Write(w)
+	})
+}
+
+// write는 지표 하나의 모든 series를 출력
+func (v *vec) write(b *strings.Builder) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", v.name, v.help, v.name, v.typ)
+
+	keys := make([]string, 0, len(v.series))
+	for key := range v.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := v.series[key]
+		labels := formatLabels(v.labels, s.labelValues)
+		if v.typ != typeHistogram {
+			fmt.Fprintf(b, "%s%s %s\n", v.name, braces(labels), formatValue(s.value))
+			continue
+		}
+
+		var cumulative uint64
+		for i, upper := range v.buckets {
+			cumulative += s.buckets[i]
+			fmt.Fprintf(b, "%s_bucket%s %d\n", v.name, braces(joinLabels(labels, `le="`+formatValue(upper)+`"`)), cumulative)
+		}
+		fmt.Fprintf(b, "%s_bucket%s %d\n", v.name, braces(joinLabels(labels, `le="+Inf"`)), s.count)
+		fmt.Fprintf(b, "%s_sum%s %s\n", v.name, braces(labels), formatValue(s.sum))
+		fmt.Fprintf(b, "%s_count%s %d\n", v.name, braces(labels), s.count)
+	}
+}
+
+// formatLabels는 라벨 이름/값을 name="value" 목록으로 변환
+func formatLabels(names, values []string) string {
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + `="` + escapeLabel(values[i]) + `"`
+	}
+	return strings.Join(parts, ",")
+}
+
+// joinLabels는 라벨 문자열 두 개를 합침
+func joinLabels(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + "," + b
+}
+
+// braces는 라벨이 있으면 중괄호로 감쌈
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+// escapeLabel은 라벨 값의 특수문자를 이스케이프
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// formatValue는 지표 값을 문자열로 변환
+func formatValue(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
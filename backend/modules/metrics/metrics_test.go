@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func render(t *testing.T, r *Registry) string {
+	t.Helper()
+	var b strings.Builder
+	if err := r.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestWriteCounterAndGauge(t *testing.T) {
+	r := NewRegistry(false)
+	requests := r.NewCounterVec("loadtest_requests_total", "전송한 요청 수", "test_id", "status")
+	active := r.NewGaugeVec("loadtest_active_tests", "실행 중인 테스트 수")
+
+	requests.Inc("run-1", "200")
+	requests.Add(2, "run-1", "200")
+	requests.Add(-5, "run-1", "200") // 카운터는 줄지 않음
+	requests.Inc("run-1", "500")
+	active.Set(3)
+	active.Add(-1.5)
+
+	want := `# HELP loadtest_active_tests 실행 중인 테스트 수
+# TYPE loadtest_active_tests gauge
+loadtest_active_tests 1.5
+# HELP loadtest_requests_total 전송한 요청 수
+# TYPE loadtest_requests_total counter
+loadtest_requests_total{test_id="run-1",status="200"} 3
+loadtest_requests_total{test_id="run-1",status="500"} 1
+`
+	if got := render(t, r); got != want {
+		t.Errorf("Write =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteHistogram(t *testing.T) {
+	r := NewRegistry(false)
+	latency := r.NewHistogramVec("loadtest_latency_seconds", "응답 시간", []float64{1, 0.1}, "endpoint")
+
+	for _, v := range []float64{0.05, 0.1, 0.5, 3} {
+		latency.Observe(v, "GET /")
+	}
+
+	// 버킷은 정렬되고 누적값으로 출력되며, 경계값은 해당 버킷에 포함 (le)
+	want := `# HELP loadtest_latency_seconds 응답 시간
+# TYPE loadtest_latency_seconds histogram
+loadtest_latency_seconds_bucket{endpoint="GET /",le="0.1"} 2
+loadtest_latency_seconds_bucket{endpoint="GET /",le="1"} 3
+loadtest_latency_seconds_bucket{endpoint="GET /",le="+Inf"} 4
+loadtest_latency_seconds_sum{endpoint="GET /"} 3.65
+loadtest_latency_seconds_count{endpoint="GET /"} 4
+`
+	if got := render(t, r); got != want {
+		t.Errorf("Write =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteEscapesLabelValues(t *testing.T) {
+	r := NewRegistry(false)
+	c := r.NewCounterVec("hits_total", "요청 수", "target")
+	c.Inc("https://example.com/\"q\"\\path\nnext")
+
+	want := `hits_total{target="https://example.com/\"q\"\\path\nnext"} 1` + "\n"
+	if got := render(t, r); !strings.HasSuffix(got, want) {
+		t.Errorf("Write =\n%s\nwant suffix\n%s", got, want)
+	}
+}
+
+func TestDeleteMatching(t *testing.T) {
+	r := NewRegistry(false)
+	c := r.NewCounterVec("hits_total", "요청 수", "test_id", "endpoint")
+	h := r.NewHistogramVec("latency_seconds", "응답 시간", []float64{1}, "test_id")
+	c.Inc("run-1", "GET /a")
+	c.Inc("run-1", "GET /b")
+	c.Inc("run-2", "GET /a")
+	h.Observe(0.5, "run-1")
+	h.Observe(0.5, "run-2")
+
+	c.DeleteMatching("test_id", "run-1")
+	h.DeleteMatching("test_id", "run-1")
+	c.DeleteMatching("unknown", "run-2") // 없는 라벨은 무시
+
+	got := render(t, r)
+	if strings.Contains(got, "run-1") {
+		t.Errorf("series for run-1 remain:\n%s", got)
+	}
+	for _, line := range []string{
+		`hits_total{test_id="run-2",endpoint="GET /a"} 1`,
+		`latency_seconds_count{test_id="run-2"} 1`,
+	} {
+		if !strings.Contains(got, line+"\n") {
+			t.Errorf("missing %q in\n%s", line, got)
+		}
+	}
+}
+
+func TestRegisterRejectsDuplicates(t *testing.T) {
+	r := NewRegistry(false)
+	r.NewCounterVec("hits_total", "요청 수")
+	defer func() {
+		if recover() == nil {
+			t.Error("registering the same name twice did not panic")
+		}
+	}()
+	r.NewGaugeVec("hits_total", "요청 수")
+}
+
+func TestHandlerIncludesRuntime(t *testing.T) {
+	r := NewRegistry(true)
+	r.NewCounterVec("hits_total", "요청 수").Inc()
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "hits_total 1\n") || !strings.Contains(body, "go_goroutines ") {
+		t.Errorf("body =\n%s", body)
+	}
+}
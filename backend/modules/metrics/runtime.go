@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// processStart는 프로세스 시작 시각 (uptime 계산용)
+var processStart = time.Now()
+
+// writeRuntime은 Go 런타임 지표를 출력
+// Prometheus 공식 클라이언트와 같은 이름을 사용하므로 기존 대시보드를 그대로 쓸 수 있습니다.
+func writeRuntime(b *strings.Builder) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	gauge := func(name, help string, value float64) {
+		fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n%s %s\n", name, help, name, name, formatValue(value))
+	}
+	counter := func(name, help string, value float64) {
+		fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n%s %s\n", name, help, name, name, formatValue(value))
+	}
+
+	gauge("go_goroutines", "Number of goroutines that currently exist.", float64(runtime.NumGoroutine()))
+	gauge("go_threads", "Number of OS threads created.", float64(threadCount()))
+	gauge("go_memstats_alloc_bytes", "Number of bytes allocated and still in use.", float64(m.Alloc))
+	counter("go_memstats_alloc_bytes_total", "Total number of bytes allocated, even if freed.", float64(m.TotalAlloc))
+	gauge("go_memstats_sys_bytes", "Number of bytes obtained from system.", float64(m.Sys))
+	gauge("go_memstats_heap_inuse_bytes", "Number of heap bytes that are in use.", float64(m.HeapInuse))
+	gauge("go_memstats_heap_objects", "Number of allocated objects.", float64(m.HeapObjects))
+	counter("go_memstats_mallocs_total", "Total number of mallocs.", float64(m.Mallocs))
+	counter("go_gc_cycles_total", "Number of completed GC cycles.", float64(m.NumGC))
+	counter("go_gc_pause_seconds_total", "Total GC pause time in seconds.", float64(m.PauseTotalNs)/1e9)
+	gauge("process_uptime_seconds", "Seconds since the process started.", time.Since(processStart).Seconds())
+}
+
+// threadCount는 런타임이 생성한 OS 스레드 수를 반환
+func threadCount() int {
+	n, _ := runtime.ThreadCreateProfile(nil)
+	return n
+}
@@ -0,0 +1,112 @@
+// Package notify는 테스트 실행이 끝나면 웹훅(JSON, Slack, Teams)으로 결과를 알림
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// 판정 값
+const (
+	VerdictPass      = "pass"      // 모든 합격 기준 충족
+	VerdictFail      = "fail"      // 합격 기준 미달
+	VerdictRegressed = "regressed" // 합격 기준은 충족했지만 직전 실행 대비 회귀
+	VerdictError     = "error"     // 실행 자체가 실패
+)
+
+// EventTestFinished는 테스트 실행 종료 이벤트 이름
+const EventTestFinished = "test.finished"
+
+// Summary는 알림에 담는 결과 요약
+type Summary struct {
+	TotalRequests int     `json:"totalRequests"`
+	ErrorRate     float64 `json:"errorRate"`
+	AvgLatencyMs  float64 `json:"avgLatencyMs"`
+	P95LatencyMs  float64 `json:"p95LatencyMs"`
+	P99LatencyMs  float64 `json:"p99LatencyMs"`
+	ThroughputRPS float64 `json:"throughputRps"`
+}
+
+// Event는 웹훅으로 보내는 JSON 페이로드
+type Event struct {
+	Event       string    `json:"event"`                 // 이벤트 이름 (test.finished)
+	RunID       string    `json:"runId"`                 // 실행 ID
+	Kind        string    `json:"kind"`                  // 테스트 종류 (basic, advanced, scheduled 등)
+	Target      string    `json:"target"`                // 대상 URL
+	Owner       string    `json:"owner,omitempty"`       // 실행을 시작한 API 키 이름
+	ScheduleID  string    `json:"scheduleId,omitempty"`  // 예약 실행이면 스케줄 ID
+	Verdict     string    `json:"verdict"`               // pass, fail, regressed, error
+	Summary     *Summary  `json:"summary,omitempty"`     // 결과 요약 (실행 실패 시 없음)
+	Interrupted bool      `json:"interrupted,omitempty"` // 서버 종료로 중간에 멈춘 부분 결과
+	Failed      []string  `json:"failed,omitempty"`      // 미달한 합격 기준 설명
+	Regressions []string  `json:"regressions,omitempty"` // 직전 실행 대비 회귀 항목
+	ReportURL   string    `json:"reportUrl,omitempty"`   // HTML 보고서 링크
+	ResultURL   string    `json:"resultUrl,omitempty"`   // JSON 실행 기록 링크
+	Error       string    `json:"error,omitempty"`       // 실행 오류
+	StartedAt   time.Time `json:"startedAt"`
+	FinishedAt  time.Time `json:"finishedAt"`
+	DurationSec float64   `json:"durationSec"`
+}
+
+// EventFor는 실행 기록으로 알림 이벤트를 생성
+// baseURL이 있으면 보고서/실행 기록 링크를 붙입니다 (예: https://loadtest.example.com).
+func EventFor(run *storage.TestRun, baseURL string) Event {
+	ev := Event{
+		Event:       EventTestFinished,
+		RunID:       run.ID,
+		Kind:        run.Kind,
+		Target:      run.Target,
+		Owner:       run.Owner,
+		ScheduleID:  run.ScheduleID,
+		Error:       run.Error,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+		DurationSec: run.FinishedAt.Sub(run.StartedAt).Seconds(),
+	}
+	if baseURL != "" {
+		base := strings.TrimRight(baseURL, "/")
+		ev.ReportURL = base + "/tests/" + run.ID + "/report"
+		ev.ResultURL = base + "/tests/" + run.ID
+	}
+
+	if run.Result != nil {
+		r := run.Result
+		ev.Interrupted = r.Interrupted
+		ev.Summary = &Summary{
+			TotalRequests: r.TotalRequests,
+			ErrorRate:     r.ErrorRate,
+			AvgLatencyMs:  r.AvgLatencyMs,
+			P95LatencyMs:  r.P95LatencyMs,
+			P99LatencyMs:  r.P99LatencyMs,
+			ThroughputRPS: r.ThroughputRPS,
+		}
+		for _, t := range r.Thresholds {
+			if !t.Passed {
+				ev.Failed = append(ev.Failed, fmt.Sprintf("%s = %g (기준: %s %g)", t.Metric, t.Actual, t.Operator, t.Value))
+			}
+		}
+	}
+	if run.Comparison != nil {
+		ev.Regressions = run.Comparison.Regressions
+	}
+
+	switch {
+	case run.Result == nil || run.Error != "":
+		ev.Verdict = VerdictError
+	case !run.Result.Passed:
+		ev.Verdict = VerdictFail
+	case run.Comparison != nil && run.Comparison.Regressed:
+		ev.Verdict = VerdictRegressed
+	default:
+		ev.Verdict = VerdictPass
+	}
+	return ev
+}
+
+// OK는 실패/회귀/오류가 없는 이벤트인지 반환
+func (e Event) OK() bool {
+	return e.Verdict == VerdictPass
+}
@@ -0,0 +1,150 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// 웹훅 메시지 형식
+const (
+	FormatJSON  = "json"  // Event 그대로 (기본값)
+	FormatSlack = "slack" // Slack Incoming Webhook
+	FormatTeams = "teams" // Microsoft Teams Workflows (Adaptive Card)
+)
+
+// verdictLabel은 판정을 사람이 읽는 문구로 변환
+var verdictLabel = map[string]string{
+	VerdictPass:      "✅ 통과",
+	VerdictFail:      "❌ 합격 기준 미달",
+	VerdictRegressed: "⚠️ 성능 회귀",
+	VerdictError:     "💥 실행 실패",
+}
+
+// payload는 대상 형식에 맞는 요청 본문을 생성
+func payload(format string, ev Event) ([]byte, error) {
+	switch format {
+	case "", FormatJSON:
+		return json.Marshal(ev)
+	case FormatSlack:
+		return json.Marshal(slackMessage(ev))
+	case FormatTeams:
+		return json.Marshal(teamsMessage(ev))
+	}
+	return nil, fmt.Errorf("지원하지 않는 웹훅 형식: %s", format)
+}
+
+// title은 메시지 제목 (예: ✅ 통과 · scheduled · https://example.com)
+func title(ev Event) string {
+	return fmt.Sprintf("%s · %s · %s", verdictLabel[ev.Verdict], ev.Kind, ev.Target)
+}
+
+// facts는 메시지 본문에 표시할 항목 (이름, 값) 목록
+func facts(ev Event) [][2]string {
+	rows := [][2]string{{"실행 ID", ev.RunID}}
+	if ev.Summary != nil {
+		s := ev.Summary
+		rows = append(rows,
+			[2]string{"요청 수", fmt.Sprint(s.TotalRequests)},
+			[2]string{"오류율", fmt.Sprintf("%.2f%%", s.ErrorRate*100)},
+			[2]string{"평균 / p95 / p99", fmt.Sprintf("%.0f / %.0f / %.0f ms", s.AvgLatencyMs, s.P95LatencyMs, s.P99LatencyMs)},
+			[2]string{"처리량", fmt.Sprintf("%.1f RPS", s.ThroughputRPS)},
+		)
+	}
+	duration := fmt.Sprintf("%.0f초", ev.DurationSec)
+	if ev.Interrupted {
+		duration += " (서버 종료로 중단, 부분 결과)"
+	}
+	rows = append(rows, [2]string{"소요 시간", duration})
+	if ev.Owner != "" {
+		rows = append(rows, [2]string{"실행한 키", ev.Owner})
+	}
+	if ev.ScheduleID != "" {
+		rows = append(rows, [2]string{"스케줄", ev.ScheduleID})
+	}
+	return rows
+}
+
+// details는 실패/회귀/오류 설명 줄 목록
+func details(ev Event) []string {
+	var lines []string
+	if ev.Error != "" {
+		lines = append(lines, "오류: "+ev.Error)
+	}
+	for _, f := range ev.Failed {
+		lines = append(lines, "미달: "+f)
+	}
+	for _, r := range ev.Regressions {
+		lines = append(lines, "회귀: "+r)
+	}
+	return lines
+}
+
+// slackMessage는 Slack Block Kit 메시지를 생성
+// text는 알림 미리보기와 블록을 표시할 수 없는 클라이언트에 사용됩니다.
+func slackMessage(ev Event) map[string]interface{} {
+	var fields []map[string]string
+	for _, f := range facts(ev) {
+		fields = append(fields, map[string]string{"type": "mrkdwn", "text": fmt.Sprintf("*%s*\n%s", f[0], f[1])})
+	}
+	blocks := []map[string]interface{}{
+		{"type": "header", "text": map[string]string{"type": "plain_text", "text": truncate(title(ev), 150)}},
+		{"type": "section", "fields": fields},
+	}
+	if lines := details(ev); len(lines) > 0 {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]string{"type": "mrkdwn", "text": truncate("• "+strings.Join(lines, "\n• "), 3000)},
+		})
+	}
+	if ev.ReportURL != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]string{"type": "mrkdwn", "text": fmt.Sprintf("<%s|보고서 보기>", ev.ReportURL)},
+		})
+	}
+	return map[string]interface{}{"text": title(ev), "blocks": blocks}
+}
+
+// teamsMessage는 Teams Workflows 웹훅이 받는 Adaptive Card 메시지를 생성
+func teamsMessage(ev Event) map[string]interface{} {
+	var factSet []map[string]string
+	for _, f := range facts(ev) {
+		factSet = append(factSet, map[string]string{"title": f[0], "value": f[1]})
+	}
+	color := "Good"
+	if !ev.OK() {
+		color = "Attention"
+	}
+	body := []map[string]interface{}{
+		{"type": "TextBlock", "text": title(ev), "weight": "Bolder", "size": "Medium", "color": color, "wrap": true},
+		{"type": "FactSet", "facts": factSet},
+	}
+	for _, line := range details(ev) {
+		body = append(body, map[string]interface{}{"type": "TextBlock", "text": line, "wrap": true, "spacing": "Small"})
+	}
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if ev.ReportURL != "" {
+		card["actions"] = []map[string]string{{"type": "Action.OpenUrl", "title": "보고서 보기", "url": ev.ReportURL}}
+	}
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{"contentType": "application/vnd.microsoft.card.adaptive", "content": card},
+		},
+	}
+}
+
+// truncate는 메시지 길이 제한에 맞게 문자열을 자름 (룬 단위)
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-1]) + "…"
+}
@@ -0,0 +1,302 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+	"go.uber.org/zap"
+)
+
+// 전송 기본값
+const (
+	defaultMaxAttempts = 5                // 최초 전송 포함 최대 시도 횟수
+	defaultBackoff     = 2 * time.Second  // 첫 재시도 대기 시간 (시도마다 두 배)
+	maxBackoff         = 5 * time.Minute  // 재시도 대기 시간 상한
+	requestTimeout     = 10 * time.Second // 요청 하나의 타임아웃
+)
+
+// 서명 헤더
+const (
+	HeaderEvent     = "X-LoadTest-Event"     // 이벤트 이름
+	HeaderDelivery  = "X-LoadTest-Delivery"  // 전송 ID (재시도해도 같음, 중복 처리 방지용)
+	HeaderTimestamp = "X-LoadTest-Timestamp" // 서명한 시각 (Unix 초)
+	HeaderSignature = "X-LoadTest-Signature" // sha256=<hex(HMAC-SHA256(secret, timestamp + "." + body))>
+)
+
+// Target은 알림을 보낼 웹훅 하나
+type Target struct {
+	URL    string // 웹훅 URL
+	Format string // json, slack, teams
+	Secret string // 서명 비밀값 (빈 값이면 서명하지 않음)
+}
+
+// Notifier는 실행 종료 이벤트를 등록된 웹훅으로 전송
+type Notifier struct {
+	Targets      []Target
+	BaseURL      string        // 보고서 링크에 사용할 서버 주소
+	OnlyFailures bool          // true면 통과한 실행은 알리지 않음
+	MaxAttempts  int           // 최대 시도 횟수 (0이면 5)
+	Backoff      time.Duration // 첫 재시도 대기 시간 (0이면 2초)
+	Log          *zap.SugaredLogger
+
+	client *http.Client
+	wg     sync.WaitGroup
+}
+
+// New는 Notifier를 생성
+func New(targets []Target, baseURL string, log *zap.SugaredLogger) *Notifier {
+	return &Notifier{
+		Targets: targets,
+		BaseURL: baseURL,
+		Log:     log,
+		client: &http.Client{
+			Timeout: requestTimeout,
+			// 웹훅 수신자가 리디렉션으로 요청을 다른 곳에 보내지 않도록 막음
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}
+}
+
+// Notify는 실행 기록으로 이벤트를 만들어 모든 웹훅에 비동기로 전송
+// 전송 실패는 재시도 후 로그로만 남기며 호출자를 막지 않습니다.
+func (n *Notifier) Notify(run *storage.TestRun) {
+	ev := EventFor(run, n.BaseURL)
+	if n.OnlyFailures && ev.OK() {
+		return
+	}
+	delivery := run.ID + "-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	for _, t := range n.Targets {
+		n.wg.Add(1)
+		go func(t Target) {
+			defer n.wg.Done()
+			if err := n.Send(context.Background(), t, ev, delivery); err != nil && n.Log != nil {
+				n.Log.Warnw("웹훅 전송 실패", "url", Redact(t.URL), "run", run.ID, "error", err)
+			}
+		}(t)
+	}
+}
+
+// Wait은 진행 중인 전송(재시도 포함)이 모두 끝날 때까지 기다림
+func (n *Notifier) Wait() {
+	n.wg.Wait()
+}
+
+// Send는 이벤트를 웹훅 하나에 전송하고, 실패하면 지수 백오프로 재시도
+// 네트워크 오류, 408, 429, 5xx만 재시도합니다. 429/503의 Retry-After는 존중합니다.
+func (n *Notifier) Send(ctx context.Context, t Target, ev Event, delivery string) error {
+	body, err := payload(t.Format, ev)
+	if err != nil {
+		return err
+	}
+
+	attempts := n.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultMaxAttempts
+	}
+	wait := n.Backoff
+	if wait <= 0 {
+		wait = defaultBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		retryAfter, err := n.post(ctx, t, ev.Event, delivery, body)
+		if err == nil {
+			return nil
+		}
+		var perm *permanentError
+		if errors.As(err, &perm) || attempt >= attempts {
+			return fmt.Errorf("%d번 시도 후 실패: %w", attempt, err)
+		}
+
+		delay := wait
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		if delay > maxBackoff {
+			delay = maxBackoff
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("전송 취소: %w (마지막 오류: %v)", ctx.Err(), err)
+		case <-time.After(delay):
+		}
+		wait *= 2
+	}
+}
+
+// permanentError는 재시도해도 성공하지 않을 실패 (4xx 응답, 잘못된 요청 등)
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error() + " (재시도하지 않음)"
+}
+
+// post는 요청 한 번을 보내고, 재시도 가능한 실패면 서버가 요구한 대기 시간을 함께 반환
+func (n *Notifier) post(ctx context.Context, t Target, event, delivery string, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, &permanentError{err: redactError(err, t.URL)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "LoadTest-Webhook/1.0")
+	req.Header.Set(HeaderEvent, event)
+	req.Header.Set(HeaderDelivery, delivery)
+	if t.Secret != "" {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(HeaderTimestamp, ts)
+		req.Header.Set(HeaderSignature, Sign(t.Secret, ts, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return 0, redactError(err, t.URL)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return 0, nil
+	case resp.StatusCode == http.StatusRequestTimeout, resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return parseRetryAfter(resp.Header.Get("Retry-After")), fmt.Errorf("웹훅 응답 %d", resp.StatusCode)
+	}
+	return 0, &permanentError{err: fmt.Errorf("웹훅 응답 %d", resp.StatusCode)}
+}
+
+// redactError는 오류 메시지에 들어 있는 웹훅 URL을 가림
+// *url.Error는 메시지에 전체 URL을 담으므로 그대로 로그나 응답에 남기면 Slack/Teams 비밀 경로가 노출됩니다.
+func redactError(err error, raw string) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	return fmt.Errorf("%s %s: %w", urlErr.Op, Redact(raw), urlErr.Err)
+}
+
+// parseRetryAfter는 Retry-After 헤더(초 또는 HTTP 날짜)를 대기 시간으로 변환
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if sec, err := strconv.Atoi(v); err == nil && sec > 0 {
+		return time.Duration(sec) * time.Second
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return time.Until(at)
+	}
+	return 0
+}
+
+// Sign은 수신자가 검증할 서명 값을 계산
+// 서명 대상은 "타임스탬프.본문"이며, 수신자는 오래된 타임스탬프를 거부해 재전송 공격을 막을 수 있습니다.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Redact는 로그/응답에 표시할 때 경로와 쿼리에 담긴 토큰을 가림
+// Slack/Teams 웹훅 URL은 경로 자체가 비밀값입니다.
+func Redact(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "(잘못된 URL)"
+	}
+	if u.Path == "" || u.Path == "/" {
+		return u.Scheme + "://" + u.Host
+	}
+	return u.Scheme + "://" + u.Host + "/…"
+}
+
+// ParseTargets는 "형식=URL" 목록을 파싱 (형식 생략 시 json)
+// 예: "slack=https://hooks.slack.com/services/T/B/X,https://ci.example.com/hooks/loadtest"
+// secret은 모든 웹훅의 서명 비밀값으로 사용합니다.
+func ParseTargets(s, secret string) ([]Target, error) {
+	var targets []Target
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		format := FormatJSON
+		if name, rest, ok := strings.Cut(item, "="); ok && !strings.Contains(name, "/") {
+			format, item = strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(rest)
+		}
+		switch format {
+		case FormatJSON, FormatSlack, FormatTeams:
+		default:
+			return nil, fmt.Errorf("지원하지 않는 웹훅 형식 %q (json, slack, teams)", format)
+		}
+		u, err := url.Parse(item)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("잘못된 웹훅 URL: %s", Redact(item))
+		}
+		targets = append(targets, Target{URL: item, Format: format, Secret: secret})
+	}
+	return targets, nil
+}
+
+// Delivery는 시험 전송 한 건의 결과
+type Delivery struct {
+	URL    string `json:"url"`             // 가린 웹훅 URL
+	Format string `json:"format"`          // 메시지 형식
+	OK     bool   `json:"ok"`              // 2xx 응답 여부
+	Error  string `json:"error,omitempty"` // 실패 사유
+}
+
+// Test는 예시 이벤트를 모든 웹훅에 재시도 없이 한 번씩 보내고 결과를 반환
+// 수신 서버나 Slack/Teams 설정을 확인할 때 사용합니다.
+func (n *Notifier) Test(ctx context.Context) []Delivery {
+	now := time.Now()
+	ev := Event{
+		Event:       EventTestFinished,
+		RunID:       "test-" + now.Format("20060102-150405"),
+		Kind:        "test",
+		Target:      "https://example.com",
+		Verdict:     VerdictPass,
+		Summary:     &Summary{TotalRequests: 300, ErrorRate: 0.01, AvgLatencyMs: 42, P95LatencyMs: 120, P99LatencyMs: 250, ThroughputRPS: 10},
+		StartedAt:   now.Add(-30 * time.Second),
+		FinishedAt:  now,
+		DurationSec: 30,
+	}
+	if n.BaseURL != "" {
+		ev.ReportURL = strings.TrimRight(n.BaseURL, "/") + "/tests"
+	}
+
+	results := make([]Delivery, len(n.Targets))
+	var wg sync.WaitGroup
+	for i, t := range n.Targets {
+		wg.Add(1)
+		go func(i int, t Target) {
+			defer wg.Done()
+			results[i] = Delivery{URL: Redact(t.URL), Format: t.Format, OK: true}
+			body, err := payload(t.Format, ev)
+			if err == nil {
+				_, err = n.post(ctx, t, ev.Event, ev.RunID, body)
+			}
+			if err != nil {
+				results[i].OK = false
+				results[i].Error = err.Error()
+			}
+		}(i, t)
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,258 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// received는 테스트 서버가 받은 요청 하나
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// hookServer는 statuses 순서대로 응답하고 받은 요청을 기록하는 웹훅 수신 서버 (남은 응답이 없으면 200)
+func hookServer(t *testing.T, statuses ...int) (*httptest.Server, func() []received) {
+	t.Helper()
+	var mu sync.Mutex
+	var got []received
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		got = append(got, received{header: r.Header.Clone(), body: body})
+		n := len(got)
+		mu.Unlock()
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []received {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]received(nil), got...)
+	}
+}
+
+func testEvent() Event {
+	return Event{Event: EventTestFinished, RunID: "run-1", Kind: "basic", Target: "https://example.com", Verdict: VerdictFail}
+}
+
+func fastNotifier(attempts int) *Notifier {
+	n := New(nil, "", nil)
+	n.MaxAttempts = attempts
+	n.Backoff = time.Millisecond
+	return n
+}
+
+func TestSendSignsBody(t *testing.T) {
+	srv, requests := hookServer(t)
+	n := fastNotifier(1)
+
+	before := time.Now().Unix()
+	if err := n.Send(context.Background(), Target{URL: srv.URL, Format: FormatJSON, Secret: "s3cr3t"}, testEvent(), "delivery-1"); err != nil {
+		t.Fatal(err)
+	}
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("received %d requests, want 1", len(got))
+	}
+	h := got[0].header
+	if h.Get(HeaderEvent) != EventTestFinished || h.Get(HeaderDelivery) != "delivery-1" {
+		t.Errorf("event headers = %q, %q", h.Get(HeaderEvent), h.Get(HeaderDelivery))
+	}
+
+	// 수신자 쪽 검증: HMAC-SHA256(secret, timestamp + "." + body)
+	ts := h.Get(HeaderTimestamp)
+	if sec, err := strconv.ParseInt(ts, 10, 64); err != nil || sec < before || sec > time.Now().Unix() {
+		t.Errorf("timestamp = %q", ts)
+	}
+	mac := hmac.New(sha256.New, []byte("s3cr3t"))
+	mac.Write([]byte(ts + "."))
+	mac.Write(got[0].body)
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if sig := h.Get(HeaderSignature); !hmac.Equal([]byte(sig), []byte(want)) {
+		t.Errorf("signature = %s, want %s", sig, want)
+	}
+	if Sign("other", ts, got[0].body) == want {
+		t.Error("signature does not depend on the secret")
+	}
+
+	var ev Event
+	if err := json.Unmarshal(got[0].body, &ev); err != nil || ev.RunID != "run-1" {
+		t.Errorf("body = %s (%v)", got[0].body, err)
+	}
+}
+
+func TestSendWithoutSecretIsUnsigned(t *testing.T) {
+	srv, requests := hookServer(t)
+	if err := fastNotifier(1).Send(context.Background(), Target{URL: srv.URL}, testEvent(), "d"); err != nil {
+		t.Fatal(err)
+	}
+	h := requests()[0].header
+	if h.Get(HeaderSignature) != "" || h.Get(HeaderTimestamp) != "" {
+		t.Errorf("unsigned target sent signature headers: %v", h)
+	}
+}
+
+func TestSendRetriesTransientFailures(t *testing.T) {
+	srv, requests := hookServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK)
+	if err := fastNotifier(5).Send(context.Background(), Target{URL: srv.URL, Secret: "s"}, testEvent(), "delivery-1"); err != nil {
+		t.Fatalf("Send = %v, want success on the third attempt", err)
+	}
+	got := requests()
+	if len(got) != 3 {
+		t.Fatalf("attempts = %d, want 3", len(got))
+	}
+	// 재시도해도 전송 ID는 같아 수신자가 중복을 거를 수 있음
+	for _, r := range got {
+		if r.header.Get(HeaderDelivery) != "delivery-1" {
+			t.Errorf("delivery header = %q", r.header.Get(HeaderDelivery))
+		}
+	}
+}
+
+func TestSendGivesUpAfterMaxAttempts(t *testing.T) {
+	srv, requests := hookServer(t, 500, 500, 500, 500)
+	err := fastNotifier(3).Send(context.Background(), Target{URL: srv.URL}, testEvent(), "d")
+	if err == nil || !strings.Contains(err.Error(), "3번") {
+		t.Errorf("Send = %v, want failure after 3 attempts", err)
+	}
+	if n := len(requests()); n != 3 {
+		t.Errorf("attempts = %d, want 3", n)
+	}
+}
+
+func TestSendDoesNotRetryClientErrors(t *testing.T) {
+	srv, requests := hookServer(t, http.StatusBadRequest)
+	err := fastNotifier(5).Send(context.Background(), Target{URL: srv.URL}, testEvent(), "d")
+	var perm *permanentError
+	if !errors.As(err, &perm) {
+		t.Errorf("Send = %v, want permanent error", err)
+	}
+	if n := len(requests()); n != 1 {
+		t.Errorf("attempts = %d, want 1", n)
+	}
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+	other, otherRequests := hookServer(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.URL, http.StatusTemporaryRedirect)
+	}))
+	defer srv.Close()
+
+	if err := fastNotifier(1).Send(context.Background(), Target{URL: srv.URL, Secret: "s"}, testEvent(), "d"); err == nil {
+		t.Error("Send through a redirect succeeded")
+	}
+	if n := len(otherRequests()); n != 0 {
+		t.Errorf("redirect target received %d requests", n)
+	}
+}
+
+func TestSendStopsRetryingWhenCanceled(t *testing.T) {
+	srv, requests := hookServer(t, 500, 500, 500)
+	n := fastNotifier(5)
+	n.Backoff = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := n.Send(ctx, Target{URL: srv.URL}, testEvent(), "d"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Send = %v, want deadline exceeded", err)
+	}
+	if n := len(requests()); n != 1 {
+		t.Errorf("attempts = %d, want 1", n)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("7"); got != 7*time.Second {
+		t.Errorf("parseRetryAfter(7) = %s", got)
+	}
+	date := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(date); got < 25*time.Second || got > 30*time.Second {
+		t.Errorf("parseRetryAfter(date) = %s", got)
+	}
+	for _, v := range []string{"", "0", "-1", "soon"} {
+		if got := parseRetryAfter(v); got != 0 {
+			t.Errorf("parseRetryAfter(%q) = %s, want 0", v, got)
+		}
+	}
+}
+
+func TestNotifyOnlyFailures(t *testing.T) {
+	srv, requests := hookServer(t)
+	n := fastNotifier(1)
+	n.Targets = []Target{{URL: srv.URL, Format: FormatSlack}}
+	n.OnlyFailures = true
+
+	n.Notify(&storage.TestRun{ID: "pass", Result: &config.TestResult{Passed: true}})
+	n.Notify(&storage.TestRun{ID: "fail", Result: &config.TestResult{Passed: false}})
+	n.Wait()
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("received %d notifications, want only the failure", len(got))
+	}
+	if !strings.HasPrefix(got[0].header.Get(HeaderDelivery), "fail-") {
+		t.Errorf("delivery = %q", got[0].header.Get(HeaderDelivery))
+	}
+}
+
+func TestParseTargetsAndRedact(t *testing.T) {
+	targets, err := ParseTargets("slack=https://hooks.slack.com/services/T/B/X, https://ci.example.com/hook?token=abc", "s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(targets) != 2 || targets[0].Format != FormatSlack || targets[1].Format != FormatJSON || targets[1].Secret != "s" {
+		t.Errorf("targets = %+v", targets)
+	}
+	for _, bad := range []string{"discord=https://x.example.com", "ftp://example.com/hook", "not a url"} {
+		if _, err := ParseTargets(bad, ""); err == nil {
+			t.Errorf("ParseTargets(%q) succeeded, want error", bad)
+		}
+	}
+	if got := Redact("https://hooks.slack.com/services/T/B/X"); got != "https://hooks.slack.com/…" {
+		t.Errorf("Redact = %s", got)
+	}
+}
+
+func TestSendErrorHidesWebhookPath(t *testing.T) {
+	// 닫힌 포트로 보내 연결 오류를 만듦
+	srv := httptest.NewServer(http.NotFoundHandler())
+	hook := srv.URL + "/services/T000/B000/secret-token"
+	srv.Close()
+
+	err := fastNotifier(1).Send(context.Background(), Target{URL: hook, Format: FormatSlack}, testEvent(), "d")
+	if err == nil {
+		t.Fatal("Send to a closed port succeeded")
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("Send error leaks the webhook path: %v", err)
+	}
+
+	n := fastNotifier(1)
+	n.Targets = []Target{{URL: hook, Format: FormatSlack}}
+	results := n.Test(context.Background())
+	if len(results) != 1 || results[0].OK || results[0].Error == "" {
+		t.Fatalf("Test = %+v, want one failed delivery", results)
+	}
+	if strings.Contains(results[0].Error, "secret-token") || strings.Contains(results[0].URL, "secret-token") {
+		t.Errorf("Test result leaks the webhook path: %+v", results[0])
+	}
+}
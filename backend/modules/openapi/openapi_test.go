@@ -0,0 +1,266 @@
+package openapi
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+const sampleUUID = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+
+func loadSpec(t *testing.T, name string) *Document {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func checkPlan(t *testing.T, got, want []config.RequestSpec) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("plan has %d requests, want %d:\n%+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("request %d =\n%+v\nwant\n%+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseRejectsUnsupportedDocuments(t *testing.T) {
+	for name, data := range map[string]string{
+		"yaml":        "openapi: 3.0.0\npaths: {}\n",
+		"version":     `{"swagger": "1.2", "paths": {"/": {}}}`,
+		"no paths":    `{"openapi": "3.1.0", "paths": {}}`,
+		"broken json": `{"openapi": "3.0.0", "paths": `,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("%s: Parse succeeded, want error", name)
+		}
+	}
+}
+
+func TestBuildPlanOpenAPI3(t *testing.T) {
+	doc := loadSpec(t, "openapi3.json")
+	specs, skipped := doc.BuildPlan(PlanOptions{Headers: map[string]string{"Authorization": "Bearer token"}})
+
+	checkPlan(t, specs, []config.RequestSpec{
+		{
+			Name:           "POST /v1/login",
+			Method:         "POST",
+			Path:           "/v1/login",
+			Headers:        map[string]string{"Content-Type": "application/x-www-form-urlencoded", "Authorization": "Bearer token"},
+			Body:           "password=P%40ssw0rd%21&username=sample",
+			ExpectedStatus: []int{200, 302},
+		},
+		{
+			// 필수 파라미터와 예시가 있는 선택 파라미터만 채움 (tag는 제외)
+			Name:           "GET /v1/pets",
+			Method:         "GET",
+			Path:           "/v1/pets?limit=5&sort=name",
+			Headers:        map[string]string{"X-Request-Id": sampleUUID, "Authorization": "Bearer token"},
+			ExpectedStatus: []int{200},
+		},
+		{
+			// JSON 본문을 우선 선택하고, readOnly(id)와 순환 지점(owner.pets[])은 생략
+			Name:           "POST /v1/pets",
+			Method:         "POST",
+			Path:           "/v1/pets",
+			Headers:        map[string]string{"Content-Type": "application/json", "Authorization": "Bearer token"},
+			Body:           `{"created":"2024-01-01T00:00:00Z","name":"Rex","owner":{"name":"sample","pets":[]},"tags":["sample"]}`,
+			ExpectedStatus: []int{201},
+		},
+		{
+			Name:           "GET /v1/pets/{petId}",
+			Method:         "GET",
+			Path:           "/v1/pets/10",
+			Headers:        map[string]string{"Authorization": "Bearer token"},
+			ExpectedStatus: []int{200},
+		},
+	})
+
+	want := []Skipped{
+		{Operation: "DELETE /v1/pets/{petId}", Reason: "deprecated"},
+		{Operation: "GET /v1/things/{thingId}", Reason: "정의되지 않은 경로 파라미터: /v1/things/{thingId}"},
+	}
+	if !reflect.DeepEqual(skipped, want) {
+		t.Errorf("skipped = %+v, want %+v", skipped, want)
+	}
+}
+
+func TestBuildPlanOptions(t *testing.T) {
+	doc := loadSpec(t, "openapi3.json")
+
+	specs, _ := doc.BuildPlan(PlanOptions{Methods: []string{"delete"}, IncludeDeprecated: true})
+	checkPlan(t, specs, []config.RequestSpec{
+		{Name: "DELETE /v1/pets/{petId}", Method: "DELETE", Path: "/v1/pets/10", ExpectedStatus: []int{204}},
+	})
+
+	specs, skipped := doc.BuildPlan(PlanOptions{Methods: []string{"get"}})
+	if len(specs) != 2 || len(skipped) != 1 {
+		t.Errorf("GET only: %d requests, %d skipped, want 2 and 1", len(specs), len(skipped))
+	}
+}
+
+func TestBuildPlanSwagger2(t *testing.T) {
+	doc := loadSpec(t, "swagger2.json")
+	specs, skipped := doc.BuildPlan(PlanOptions{})
+	if len(skipped) != 0 {
+		t.Errorf("skipped = %+v", skipped)
+	}
+
+	checkPlan(t, specs, []config.RequestSpec{
+		{
+			// 공통 파라미터 $ref의 default 값과 파라미터에 직접 선언된 타입 사용
+			Name:           "GET /api/nodes",
+			Method:         "GET",
+			Path:           "/api/nodes?page=1",
+			Headers:        map[string]string{"X-Trace": sampleUUID},
+			ExpectedStatus: []int{200},
+		},
+		{
+			// body 파라미터는 문서 consumes를 사용하고, 자기 참조(parent, children[])는 생략
+			Name:           "POST /api/nodes",
+			Method:         "POST",
+			Path:           "/api/nodes",
+			Headers:        map[string]string{"Content-Type": "application/json"},
+			Body:           `{"children":[],"value":"a"}`,
+			ExpectedStatus: []int{200, 201},
+		},
+		{
+			// 메서드 파라미터가 같은 이름의 경로 공통 파라미터를 덮어씀 (string → integer)
+			Name:           "GET /api/nodes/{id}",
+			Method:         "GET",
+			Path:           "/api/nodes/1",
+			ExpectedStatus: []int{200},
+		},
+		{
+			Name:           "POST /api/upload",
+			Method:         "POST",
+			Path:           "/api/upload",
+			Headers:        map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+			Body:           "file=sample&note=sample",
+			ExpectedStatus: []int{204},
+		},
+	})
+}
+
+func TestEndpoints(t *testing.T) {
+	doc := loadSpec(t, "openapi3.json")
+	endpoints := doc.Endpoints("https://api.example.com/")
+
+	byName := make(map[string]config.Endpoint)
+	for _, ep := range endpoints {
+		byName[ep.Method+" "+ep.Path] = ep
+	}
+	if len(byName) != 6 {
+		t.Fatalf("endpoints = %+v, want 6", endpoints)
+	}
+
+	list := byName["GET /v1/pets"]
+	if list.URL != "https://api.example.com/v1/pets" || list.Source != "openapi" {
+		t.Errorf("GET /v1/pets = %+v", list)
+	}
+	if !reflect.DeepEqual(list.QueryParams, []string{"limit", "sort", "tag"}) {
+		t.Errorf("query params = %v", list.QueryParams)
+	}
+	if _, ok := list.Headers["X-Request-Id"]; !ok {
+		t.Errorf("headers = %v", list.Headers)
+	}
+
+	login := byName["POST /v1/login"]
+	if login.RequestContentType != "application/x-www-form-urlencoded" || !reflect.DeepEqual(login.FormFields, []string{"password", "username"}) {
+		t.Errorf("POST /v1/login = %+v", login)
+	}
+}
+
+func TestSampleValue(t *testing.T) {
+	doc := &Document{}
+	schema := func(s string) *Schema {
+		var v Schema
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			t.Fatal(err)
+		}
+		return &v
+	}
+
+	cases := []struct {
+		schema string
+		want   any
+	}{
+		{`{"type": "string", "example": "hi", "default": "x"}`, "hi"},
+		{`{"type": "string", "default": "x", "enum": ["a"]}`, "x"},
+		{`{"type": "string", "enum": ["red", "blue"]}`, "red"},
+		{`{"type": "string", "format": "email"}`, "user@example.com"},
+		{`{"type": "string", "minLength": 10}`, "samplexxxx"},
+		{`{"type": "string", "format": "uuid", "maxLength": 8}`, "3fa85f64"},
+		{`{"type": "integer", "minimum": 18}`, int64(18)},
+		{`{"type": "number", "maximum": 0.5}`, 0.5},
+		{`{"type": ["integer", "null"]}`, int64(1)},
+		{`{"type": "boolean"}`, true},
+		{`{"type": "array", "minItems": 3, "items": {"type": "integer"}}`, []any{int64(1), int64(1), int64(1)}},
+		{`{"properties": {"a": {"type": "boolean"}}}`, map[string]any{"a": true}},
+		{`{"allOf": [{"properties": {"a": {"type": "integer"}}}, {"properties": {"b": {"type": "string"}}}]}`, map[string]any{"a": int64(1), "b": "sample"}},
+		{`{"oneOf": [{"type": "integer"}, {"type": "string"}]}`, int64(1)},
+		{`{"type": "object", "properties": {"id": {"type": "integer", "readOnly": true}, "name": {"type": "string"}}}`, map[string]any{"name": "sample"}},
+		{`{"$ref": "#/components/schemas/Missing"}`, nil},
+	}
+	for _, c := range cases {
+		if got := doc.SampleValue(schema(c.schema)); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SampleValue(%s) = %#v, want %#v", c.schema, got, c.want)
+		}
+	}
+}
+
+func TestSampleValueStopsAtCycles(t *testing.T) {
+	doc := loadSpec(t, "openapi3.json")
+	// Owner → pets[] → Pet → owner → Owner 로 순환
+	got := doc.SampleValue(&Schema{Ref: "#/components/schemas/Owner"})
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"sample","pets":[{"created":"2024-01-01T00:00:00Z","name":"Rex","tags":["sample"]}]}`
+	if string(data) != want {
+		t.Errorf("SampleValue(Owner) = %s, want %s", data, want)
+	}
+
+	if doc.ResolveSchema(&Schema{Ref: "#/components/schemas/Owner"}) == nil {
+		t.Error("ResolveSchema(Owner) = nil")
+	}
+	loop := &Document{}
+	loop.Components.Schemas = map[string]*Schema{
+		"A": {Ref: "#/components/schemas/B"},
+		"B": {Ref: "#/components/schemas/A"},
+	}
+	if s := loop.ResolveSchema(&Schema{Ref: "#/components/schemas/A"}); s != nil {
+		t.Errorf("ResolveSchema(A ↔ B) = %+v, want nil", s)
+	}
+	if v := loop.SampleValue(&Schema{Ref: "#/components/schemas/A"}); v != nil {
+		t.Errorf("SampleValue(A ↔ B) = %v, want nil", v)
+	}
+}
+
+func TestServerBasePath(t *testing.T) {
+	cases := map[string]Document{
+		"/v2":    {OpenAPI: "3.0.0", Servers: []Server{{URL: "https://api.example.com/v2/"}}},
+		"":       {OpenAPI: "3.0.0", Servers: []Server{{URL: "https://api.example.com"}}},
+		"/rel":   {OpenAPI: "3.0.0", Servers: []Server{{URL: "/rel"}}},
+		"/basic": {Swagger: "2.0", BasePath: "/basic"},
+	}
+	for want, doc := range cases {
+		if got := doc.ServerBasePath(); got != want {
+			t.Errorf("ServerBasePath(%+v) = %q, want %q", doc, got, want)
+		}
+	}
+}
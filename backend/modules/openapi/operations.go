@@ -0,0 +1,187 @@
+package openapi
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// Operation 메서드 순서 (결과 정렬용)
+var methodOrder = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// Op은 버전 차이를 정규화한 메서드+경로 하나
+type Op struct {
+	Method      string      // HTTP 메서드
+	Path        string      // 기본 경로를 포함한 경로 템플릿 (예: /v1/users/{id})
+	OperationID string      // operationId
+	Summary     string      // 요약
+	Deprecated  bool        // 폐기 예정 여부
+	Parameters  []Parameter // 경로 공통 + 메서드 파라미터 ($ref 해석, body/formData 제외)
+	ContentType string      // 요청 본문 Content-Type (본문이 없으면 빈 값)
+	BodySchema  *Schema     // 요청 본문 스키마
+	BodyExample any         // 명세에 선언된 본문 예시
+	FormFields  []string    // formData / form-urlencoded 필드 이름
+	Statuses    []int       // 명세에 선언된 응답 상태 코드 ("2XX" 같은 범위와 default 제외)
+}
+
+// Operations는 명세의 모든 Operation을 경로, 메서드 순서로 정규화하여 반환
+func (d *Document) Operations() []Op {
+	paths := make([]string, 0, len(d.Paths))
+	for p := range d.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	base := d.ServerBasePath()
+	var ops []Op
+	for _, p := range paths {
+		item := d.Paths[p]
+		byMethod := map[string]*Operation{
+			"GET": item.Get, "POST": item.Post, "PUT": item.Put, "PATCH": item.Patch,
+			"DELETE": item.Delete, "HEAD": item.Head, "OPTIONS": item.Options,
+		}
+		for _, method := range methodOrder {
+			op := byMethod[method]
+			if op == nil {
+				continue
+			}
+			ops = append(ops, d.normalize(method, base+p, item.Parameters, op))
+		}
+	}
+	return ops
+}
+
+// normalize는 Operation 하나를 Op로 변환
+func (d *Document) normalize(method, path string, shared []Parameter, op *Operation) Op {
+	result := Op{
+		Method:      method,
+		Path:        path,
+		OperationID: op.OperationID,
+		Summary:     op.Summary,
+		Deprecated:  op.Deprecated,
+	}
+
+	// 메서드 파라미터가 같은 이름/위치의 공통 파라미터를 덮어씀
+	params := make(map[string]Parameter)
+	var order []string
+	for _, list := range [][]Parameter{shared, op.Parameters} {
+		for _, raw := range list {
+			p, ok := d.resolveParameter(raw)
+			if !ok {
+				continue
+			}
+			key := p.In + ":" + p.Name
+			if _, exists := params[key]; !exists {
+				order = append(order, key)
+			}
+			params[key] = p
+		}
+	}
+
+	consumes := op.Consumes
+	if len(consumes) == 0 {
+		consumes = d.Consumes
+	}
+
+	for _, key := range order {
+		p := params[key]
+		switch p.In {
+		case "body":
+			// Swagger 2 본문 파라미터
+			result.BodySchema = p.Schema
+			result.BodyExample = p.Example
+			result.ContentType = firstOr(consumes, "application/json")
+		case "formData":
+			result.FormFields = append(result.FormFields, p.Name)
+			if result.ContentType == "" {
+				result.ContentType = firstOr(consumes, "application/x-www-form-urlencoded")
+			}
+		default:
+			result.Parameters = append(result.Parameters, p)
+		}
+	}
+
+	if body := d.resolveRequestBody(op.RequestBody); body != nil && len(body.Content) > 0 {
+		contentType, media := preferredMedia(body.Content)
+		result.ContentType = contentType
+		result.BodySchema = media.Schema
+		result.BodyExample = media.Example
+		if strings.Contains(contentType, "form") {
+			if s := d.ResolveSchema(media.Schema); s != nil {
+				result.FormFields = sortedKeys(s.Properties)
+			}
+		}
+	}
+
+	for code := range op.Responses {
+		if status, err := strconv.Atoi(code); err == nil {
+			result.Statuses = append(result.Statuses, status)
+		}
+	}
+	sort.Ints(result.Statuses)
+
+	return result
+}
+
+// Endpoints는 명세의 Operation을 탐색 결과 형식으로 변환
+// targetURL은 엔드포인트 URL을 만들 때 사용하는 대상 서비스 주소입니다.
+func (d *Document) Endpoints(targetURL string) []config.Endpoint {
+	targetURL = strings.TrimSuffix(targetURL, "/")
+	var endpoints []config.Endpoint
+	for _, op := range d.Operations() {
+		ep := config.Endpoint{
+			Method:             op.Method,
+			URL:                targetURL + op.Path,
+			Path:               op.Path,
+			FormFields:         op.FormFields,
+			RequestContentType: op.ContentType,
+			Source:             "openapi",
+		}
+		for _, p := range op.Parameters {
+			switch p.In {
+			case "query":
+				ep.QueryParams = append(ep.QueryParams, p.Name)
+			case "header":
+				if ep.Headers == nil {
+					ep.Headers = make(map[string]string)
+				}
+				ep.Headers[p.Name] = ""
+			}
+		}
+		endpoints = append(endpoints, ep)
+	}
+	return endpoints
+}
+
+// preferredMedia는 JSON 본문을 우선으로 Content-Type 하나를 선택
+func preferredMedia(content map[string]MediaType) (string, MediaType) {
+	types := make([]string, 0, len(content))
+	for t := range content {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		if strings.Contains(t, "json") {
+			return t, content[t]
+		}
+	}
+	return types[0], content[types[0]]
+}
+
+func firstOr(list []string, fallback string) string {
+	if len(list) > 0 {
+		return list[0]
+	}
+	return fallback
+}
+
+func sortedKeys(m map[string]*Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,160 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// PlanOptions는 명세에서 부하 테스트 요청 목록을 만들 때의 설정
+type PlanOptions struct {
+	IncludeDeprecated bool              // 폐기 예정(deprecated) Operation 포함 여부
+	Methods           []string          // 포함할 메서드 (비어 있으면 전체, 예: ["GET"])
+	Headers           map[string]string // 모든 요청에 추가할 헤더 (예: 인증 헤더)
+}
+
+// Skipped는 요청 목록에서 제외된 Operation과 사유
+type Skipped struct {
+	Operation string `json:"operation"` // "METHOD /path"
+	Reason    string `json:"reason"`
+}
+
+// BuildPlan은 명세의 모든 Operation을 실행 가능한 요청 목록으로 변환
+// 경로/쿼리/헤더 파라미터는 예시 값 또는 스키마 기반 생성 값으로 채우고,
+// 요청 본문은 스키마로부터 만들며, 명세의 성공 응답 코드(4xx 미만)를 기대 응답 코드로 사용합니다.
+func (d *Document) BuildPlan(opts PlanOptions) ([]config.RequestSpec, []Skipped) {
+	methods := make(map[string]bool, len(opts.Methods))
+	for _, m := range opts.Methods {
+		methods[strings.ToUpper(m)] = true
+	}
+
+	var specs []config.RequestSpec
+	var skipped []Skipped
+	for _, op := range d.Operations() {
+		name := op.Method + " " + op.Path
+		if len(methods) > 0 && !methods[op.Method] {
+			continue
+		}
+		if op.Deprecated && !opts.IncludeDeprecated {
+			skipped = append(skipped, Skipped{Operation: name, Reason: "deprecated"})
+			continue
+		}
+
+		spec, err := d.buildRequest(op, opts.Headers)
+		if err != nil {
+			skipped = append(skipped, Skipped{Operation: name, Reason: err.Error()})
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	return specs, skipped
+}
+
+// buildRequest는 Operation 하나를 요청으로 변환
+func (d *Document) buildRequest(op Op, extraHeaders map[string]string) (config.RequestSpec, error) {
+	spec := config.RequestSpec{
+		Name:   op.Method + " " + op.Path,
+		Method: op.Method,
+	}
+
+	path := op.Path
+	query := url.Values{}
+	headers := map[string]string{}
+	for _, p := range op.Parameters {
+		// 선택 쿼리/헤더 파라미터는 명세에 예시가 있을 때만 채움
+		if !p.Required && p.In != "path" && !hasExample(p) {
+			continue
+		}
+		value := d.parameterValue(p)
+		switch p.In {
+		case "path":
+			if value == "" {
+				return spec, fmt.Errorf("경로 파라미터 %s 값을 만들 수 없습니다", p.Name)
+			}
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", url.PathEscape(value))
+		case "query":
+			query.Set(p.Name, value)
+		case "header":
+			headers[p.Name] = value
+		}
+	}
+	if strings.Contains(path, "{") {
+		return spec, fmt.Errorf("정의되지 않은 경로 파라미터: %s", path)
+	}
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+	spec.Path = path
+
+	if op.ContentType != "" {
+		body, err := d.buildBody(op)
+		if err != nil {
+			return spec, err
+		}
+		spec.Body = body
+		headers["Content-Type"] = op.ContentType
+	}
+	for k, v := range extraHeaders {
+		headers[k] = v
+	}
+	if len(headers) > 0 {
+		spec.Headers = headers
+	}
+
+	for _, status := range op.Statuses {
+		if status < 400 {
+			spec.ExpectedStatus = append(spec.ExpectedStatus, status)
+		}
+	}
+	sort.Ints(spec.ExpectedStatus)
+	return spec, nil
+}
+
+// buildBody는 Content-Type에 맞게 요청 본문을 생성 (JSON, form, text 지원)
+func (d *Document) buildBody(op Op) (string, error) {
+	value := op.BodyExample
+	if value == nil {
+		value = d.SampleValue(op.BodySchema)
+	}
+
+	switch {
+	case strings.Contains(op.ContentType, "json"):
+		if value == nil {
+			value = map[string]any{}
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("본문 생성 실패: %v", err)
+		}
+		return string(data), nil
+	case strings.Contains(op.ContentType, "x-www-form-urlencoded"):
+		form := url.Values{}
+		if obj, ok := value.(map[string]any); ok {
+			for k, v := range obj {
+				form.Set(k, formatValue(v))
+			}
+		}
+		// Swagger 2 formData 파라미터는 스키마가 없으므로 이름만 채움
+		for _, field := range op.FormFields {
+			if !form.Has(field) {
+				form.Set(field, "sample")
+			}
+		}
+		return form.Encode(), nil
+	case strings.HasPrefix(op.ContentType, "text/"):
+		return formatValue(value), nil
+	default:
+		return "", fmt.Errorf("지원하지 않는 본문 형식: %s", op.ContentType)
+	}
+}
+
+func hasExample(p Parameter) bool {
+	if p.Example != nil || p.Default != nil {
+		return true
+	}
+	return p.Schema != nil && (p.Schema.Example != nil || p.Schema.Default != nil)
+}
@@ -0,0 +1,202 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 예시 값 생성 시 따라갈 최대 스키마 깊이 (순환 참조 방지)
+const maxSampleDepth = 8
+
+// SampleValue는 스키마에 맞는 예시 값을 생성
+// example → default → enum 첫 값 순으로 명세 값을 우선 사용하고, 없으면 타입/형식에 맞게 만듭니다.
+// 자기 자신을 참조하는 스키마는 순환 지점에서 값을 생략하고, readOnly 속성은 요청 본문에서 제외합니다.
+func (d *Document) SampleValue(s *Schema) any {
+	return d.sample(s, 0, map[string]bool{})
+}
+
+func (d *Document) sample(s *Schema, depth int, refs map[string]bool) any {
+	if s == nil || depth > maxSampleDepth {
+		return nil
+	}
+	if s.Ref != "" {
+		// 현재 경로에서 이미 펼친 참조면 순환이므로 중단
+		if refs[s.Ref] {
+			return nil
+		}
+		refs[s.Ref] = true
+		defer delete(refs, s.Ref)
+		return d.sample(d.schemaRef(s.Ref), depth+1, refs)
+	}
+	if s.Example != nil {
+		return s.Example
+	}
+	if s.Default != nil {
+		return s.Default
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+
+	// 조합 스키마: allOf는 객체 속성을 합치고, oneOf/anyOf는 첫 번째 후보 사용
+	if len(s.AllOf) > 0 {
+		merged := map[string]any{}
+		for _, part := range s.AllOf {
+			if obj, ok := d.sample(part, depth+1, refs).(map[string]any); ok {
+				for k, v := range obj {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+	if len(s.OneOf) > 0 {
+		return d.sample(s.OneOf[0], depth+1, refs)
+	}
+	if len(s.AnyOf) > 0 {
+		return d.sample(s.AnyOf[0], depth+1, refs)
+	}
+
+	switch s.Type {
+	case "object":
+		return d.sampleObject(s, depth, refs)
+	case "array":
+		count := 1
+		if s.MinItems != nil && *s.MinItems > count {
+			count = min(*s.MinItems, 10)
+		}
+		items := make([]any, 0, count)
+		for i := 0; i < count; i++ {
+			if item := d.sample(s.Items, depth+1, refs); item != nil {
+				items = append(items, item)
+			}
+		}
+		return items
+	case "integer":
+		return int64(sampleNumber(s))
+	case "number":
+		return sampleNumber(s)
+	case "boolean":
+		return true
+	case "string":
+		return sampleString(s)
+	case "":
+		// 타입이 없지만 속성이 있으면 객체로 취급
+		if len(s.Properties) > 0 {
+			return d.sampleObject(s, depth, refs)
+		}
+	}
+	return nil
+}
+
+// sampleObject는 객체 스키마의 모든 속성에 대한 예시를 생성
+func (d *Document) sampleObject(s *Schema, depth int, refs map[string]bool) map[string]any {
+	obj := make(map[string]any, len(s.Properties))
+	for _, name := range sortedKeys(s.Properties) {
+		prop := s.Properties[name]
+		if resolved := d.ResolveSchema(prop); resolved != nil && resolved.ReadOnly {
+			continue
+		}
+		if v := d.sample(prop, depth+1, refs); v != nil {
+			obj[name] = v
+		}
+	}
+	return obj
+}
+
+// sampleNumber는 최소/최대 범위 안의 숫자 예시 (범위가 없으면 1)
+func sampleNumber(s *Schema) float64 {
+	switch {
+	case s.Minimum != nil && *s.Minimum > 1:
+		return *s.Minimum
+	case s.Maximum != nil && *s.Maximum < 1:
+		return *s.Maximum
+	}
+	return 1
+}
+
+// sampleString은 형식(format)과 길이 제한에 맞는 문자열 예시
+func sampleString(s *Schema) string {
+	var v string
+	switch s.Format {
+	case "uuid":
+		v = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case "date":
+		v = "2024-01-01"
+	case "date-time":
+		v = "2024-01-01T00:00:00Z"
+	case "email":
+		v = "user@example.com"
+	case "uri", "url":
+		v = "https://example.com"
+	case "hostname":
+		v = "example.com"
+	case "ipv4":
+		v = "192.0.2.1"
+	case "ipv6":
+		v = "2001:db8::1"
+	case "byte":
+		v = "c2FtcGxl"
+	case "password":
+		v = "P@ssw0rd!"
+	default:
+		v = "sample"
+	}
+	if s.MinLength != nil && len(v) < *s.MinLength {
+		v += strings.Repeat("x", *s.MinLength-len(v))
+	}
+	if s.MaxLength != nil && *s.MaxLength > 0 && len(v) > *s.MaxLength {
+		v = v[:*s.MaxLength]
+	}
+	return v
+}
+
+// parameterValue는 파라미터 하나의 문자열 값 (경로/쿼리/헤더에 사용)
+func (d *Document) parameterValue(p Parameter) string {
+	var v any
+	switch {
+	case p.Example != nil:
+		v = p.Example
+	case p.Schema != nil:
+		v = d.SampleValue(p.Schema)
+	default:
+		// Swagger 2: 파라미터에 타입 정보가 직접 선언됨
+		v = d.SampleValue(&Schema{Type: p.Type, Format: p.Format, Enum: p.Enum, Default: p.Default, Items: p.Items})
+	}
+	return formatValue(v)
+}
+
+// formatValue는 예시 값을 URL/헤더용 문자열로 변환 (배열은 쉼표로 연결)
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		if val == float64(int64(val)) {
+			return fmt.Sprintf("%d", int64(val))
+		}
+		return fmt.Sprintf("%g", val)
+	case []any:
+		parts := make([]string, 0, len(val))
+		for _, item := range val {
+			parts = append(parts, formatValue(item))
+		}
+		return strings.Join(parts, ",")
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			parts = append(parts, k+","+formatValue(val[k]))
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(val)
+	}
+}
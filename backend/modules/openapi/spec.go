@@ -0,0 +1,245 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Document는 OpenAPI 3.x / Swagger 2.0 명세 중 부하 테스트에 필요한 부분
+// 두 버전의 필드를 함께 선언하고, Operations에서 같은 형태로 정규화합니다.
+type Document struct {
+	OpenAPI string          `json:"openapi"` // OpenAPI 3 버전 (예: 3.0.3)
+	Swagger string          `json:"swagger"` // Swagger 2 버전 (예: 2.0)
+	Info    Info            `json:"info"`
+	Servers []Server        `json:"servers"` // OpenAPI 3
+	Paths   map[string]Path `json:"paths"`
+
+	// OpenAPI 3 재사용 컴포넌트
+	Components struct {
+		Schemas       map[string]*Schema      `json:"schemas"`
+		Parameters    map[string]*Parameter   `json:"parameters"`
+		RequestBodies map[string]*RequestBody `json:"requestBodies"`
+	} `json:"components"`
+
+	// Swagger 2 전용 필드
+	Host        string                `json:"host"`
+	BasePath    string                `json:"basePath"`
+	Schemes     []string              `json:"schemes"`
+	Consumes    []string              `json:"consumes"`
+	Definitions map[string]*Schema    `json:"definitions"`
+	Parameters  map[string]*Parameter `json:"parameters"`
+}
+
+// Info는 명세 제목/버전
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Server는 OpenAPI 3 서버 정보
+type Server struct {
+	URL string `json:"url"`
+}
+
+// Path는 경로 하나에 정의된 메서드별 Operation
+type Path struct {
+	Get        *Operation  `json:"get"`
+	Put        *Operation  `json:"put"`
+	Post       *Operation  `json:"post"`
+	Delete     *Operation  `json:"delete"`
+	Options    *Operation  `json:"options"`
+	Head       *Operation  `json:"head"`
+	Patch      *Operation  `json:"patch"`
+	Parameters []Parameter `json:"parameters"` // 모든 메서드에 공통인 파라미터
+}
+
+// Operation은 메서드+경로 하나의 정의
+type Operation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary"`
+	Tags        []string                   `json:"tags"`
+	Deprecated  bool                       `json:"deprecated"`
+	Parameters  []Parameter                `json:"parameters"`
+	RequestBody *RequestBody               `json:"requestBody"` // OpenAPI 3
+	Consumes    []string                   `json:"consumes"`    // Swagger 2
+	Responses   map[string]json.RawMessage `json:"responses"`   // 상태 코드만 사용
+}
+
+// Parameter는 경로/쿼리/헤더/쿠키 파라미터 (Swagger 2의 body/formData 포함)
+type Parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"` // path, query, header, cookie, body(2.0), formData(2.0)
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+	Example  any     `json:"example"`
+
+	// Swagger 2는 body 외 파라미터의 타입 정보를 파라미터에 직접 선언
+	Type    SchemaType `json:"type"`
+	Format  string     `json:"format"`
+	Enum    []any      `json:"enum"`
+	Default any        `json:"default"`
+	Items   *Schema    `json:"items"`
+}
+
+// RequestBody는 OpenAPI 3 요청 본문 정의
+type RequestBody struct {
+	Ref      string               `json:"$ref"`
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// MediaType은 Content-Type별 본문 스키마
+type MediaType struct {
+	Schema  *Schema `json:"schema"`
+	Example any     `json:"example"`
+}
+
+// Schema는 JSON Schema 중 예시 값 생성에 필요한 부분
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       SchemaType         `json:"type"`
+	Format     string             `json:"format"`
+	Properties map[string]*Schema `json:"properties"`
+	Items      *Schema            `json:"items"`
+	Required   []string           `json:"required"`
+	Enum       []any              `json:"enum"`
+	Example    any                `json:"example"`
+	Default    any                `json:"default"`
+	AllOf      []*Schema          `json:"allOf"`
+	OneOf      []*Schema          `json:"oneOf"`
+	AnyOf      []*Schema          `json:"anyOf"`
+	Minimum    *float64           `json:"minimum"`
+	Maximum    *float64           `json:"maximum"`
+	MinLength  *int               `json:"minLength"`
+	MaxLength  *int               `json:"maxLength"`
+	MinItems   *int               `json:"minItems"`
+	Nullable   bool               `json:"nullable"`
+	ReadOnly   bool               `json:"readOnly"`
+}
+
+// SchemaType은 "string" 또는 ["string", "null"] (OpenAPI 3.1) 형태의 타입
+type SchemaType string
+
+// UnmarshalJSON은 배열 타입이면 null이 아닌 첫 타입을 사용
+func (t *SchemaType) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = SchemaType(single)
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = ""
+	for _, v := range list {
+		if v != "null" {
+			*t = SchemaType(v)
+			break
+		}
+	}
+	return nil
+}
+
+// Parse는 JSON 형식의 OpenAPI 3 / Swagger 2 명세를 해석
+func Parse(data []byte) (*Document, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return nil, fmt.Errorf("JSON 형식의 명세만 지원합니다 (YAML은 JSON으로 변환해 주세요)")
+	}
+
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("명세 해석 실패: %v", err)
+	}
+	switch {
+	case strings.HasPrefix(doc.OpenAPI, "3."):
+	case doc.Swagger == "2.0":
+	default:
+		return nil, fmt.Errorf("지원하지 않는 명세 버전 (openapi=%q, swagger=%q)", doc.OpenAPI, doc.Swagger)
+	}
+	if len(doc.Paths) == 0 {
+		return nil, fmt.Errorf("명세에 경로가 없습니다")
+	}
+	return &doc, nil
+}
+
+// IsSwagger2는 Swagger 2.0 명세인지 여부
+func (d *Document) IsSwagger2() bool {
+	return d.Swagger == "2.0"
+}
+
+// ServerBasePath는 경로 앞에 붙는 서버 기본 경로 (예: /v1)
+// OpenAPI 3은 첫 번째 서버 URL의 경로, Swagger 2는 basePath를 사용합니다.
+func (d *Document) ServerBasePath() string {
+	var base string
+	if d.IsSwagger2() {
+		base = d.BasePath
+	} else if len(d.Servers) > 0 {
+		base = d.Servers[0].URL
+		// 절대 URL이면 경로 부분만 사용
+		if i := strings.Index(base, "://"); i >= 0 {
+			rest := base[i+3:]
+			if j := strings.Index(rest, "/"); j >= 0 {
+				base = rest[j:]
+			} else {
+				base = ""
+			}
+		}
+	}
+	return strings.TrimSuffix(base, "/")
+}
+
+// schemaRef는 "#/components/schemas/User" 또는 "#/definitions/User" 참조를 해석
+func (d *Document) schemaRef(ref string) *Schema {
+	if name, ok := strings.CutPrefix(ref, "#/components/schemas/"); ok {
+		return d.Components.Schemas[name]
+	}
+	if name, ok := strings.CutPrefix(ref, "#/definitions/"); ok {
+		return d.Definitions[name]
+	}
+	return nil
+}
+
+// ResolveSchema는 최상위 $ref를 따라가 실제 스키마를 반환 (순환 참조는 최대 깊이에서 중단)
+func (d *Document) ResolveSchema(s *Schema) *Schema {
+	for i := 0; s != nil && s.Ref != "" && i < 16; i++ {
+		s = d.schemaRef(s.Ref)
+	}
+	if s != nil && s.Ref != "" {
+		return nil
+	}
+	return s
+}
+
+// resolveParameter는 파라미터 $ref를 해석
+func (d *Document) resolveParameter(p Parameter) (Parameter, bool) {
+	if p.Ref == "" {
+		return p, true
+	}
+	var resolved *Parameter
+	if name, ok := strings.CutPrefix(p.Ref, "#/components/parameters/"); ok {
+		resolved = d.Components.Parameters[name]
+	} else if name, ok := strings.CutPrefix(p.Ref, "#/parameters/"); ok {
+		resolved = d.Parameters[name]
+	}
+	if resolved == nil {
+		return Parameter{}, false
+	}
+	return *resolved, true
+}
+
+// resolveRequestBody는 요청 본문 $ref를 해석
+func (d *Document) resolveRequestBody(b *RequestBody) *RequestBody {
+	if b == nil || b.Ref == "" {
+		return b
+	}
+	name, ok := strings.CutPrefix(b.Ref, "#/components/requestBodies/")
+	if !ok {
+		return nil
+	}
+	return d.Components.RequestBodies[name]
+}
@@ -1,37 +1,153 @@
 package orchestrator
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
+	"net"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Mr-Muji/LoadTest/backend/config"
 	"github.com/Mr-Muji/LoadTest/backend/modules/ai"
+	"github.com/Mr-Muji/LoadTest/backend/modules/crawler"
 	"github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/modules/scope"
+	"github.com/Mr-Muji/LoadTest/libs/logger"
 )
 
+// 경로 추출 설정
+var (
+	scraperScript  string                  // Node.js 스크래퍼 경로 (빈 값이면 내장 크롤러 사용)
+	scraperCommand = DefaultScraperCommand // Node.js 스크래퍼 실행 명령
+	scraperTimeout = DefaultScraperTimeout // Node.js 스크래퍼 실행 제한 시간
+	crawlOptions   crawler.Options         // 내장 크롤러 설정
+)
+
+// 외부 문서(명세, HAR, sitemap, 경로 목록) 접근 설정
+var (
+	sourceDir  string                                                            // location으로 서버 파일을 읽을 수 있는 디렉토리 (빈 값이면 파일 읽기 금지)
+	fetchGuard func(ctx context.Context, rawURL string) error                    // 문서 URL을 내려받기 전 검사 (nil이면 검사하지 않음)
+	fetchDial  func(ctx context.Context, network, addr string) (net.Conn, error) // 문서와 크롤링 요청의 연결 함수 (nil이면 기본 Dialer)
+)
+
+// SetSourceDir는 탐색 소스의 location으로 읽을 수 있는 서버 디렉토리를 설정
+// 빈 문자열이면 서버 파일은 읽지 않고 URL이나 content로 전달한 문서만 사용합니다.
+func SetSourceDir(dir string) {
+	sourceDir = dir
+}
+
+// SetFetchGuard는 문서 URL(리다이렉트와 하위 sitemap 포함)을 내려받기 전에 호출할 검사를 설정
+// 안전 정책의 허용 목록 검사를 넘겨 탐색 단계도 허용된 대상에만 요청을 보내게 합니다.
+func SetFetchGuard(guard func(ctx context.Context, rawURL string) error) {
+	fetchGuard = guard
+}
+
+// SetFetchDialer는 문서 다운로드와 내장 크롤러 요청의 연결 함수를 설정
+// 안전 정책의 DialContext를 넘기면 fetchGuard 검사 뒤 DNS 응답이 바뀌어도 허용 대역 밖으로 연결하지 않습니다.
+func SetFetchDialer(dial func(ctx context.Context, network, addr string) (net.Conn, error)) {
+	fetchDial = dial
+}
+
+// fetchTransport는 fetchDial을 사용하는 전송 계층을 반환 (fetchDial이 없으면 nil, 즉 기본 전송 계층)
+// 연결 주소 검사가 의미 있도록 프록시는 사용하지 않습니다.
+func fetchTransport() http.RoundTripper {
+	if fetchDial == nil {
+		return nil
+	}
+	return &http.Transport{DialContext: fetchDial}
+}
+
+// 자동 테스트 1단계(GPT 추천 경로) 기본값
+var (
+	autoTestRPS      = 10
+	autoTestDuration = 10
+)
+
+// SetAutoTestDefaults는 자동 테스트 1단계의 초당 요청 수와 실행 시간(초)을 설정
+// GPT가 경로별 RPS를 추천하면 그 값이 우선합니다.
+func SetAutoTestDefaults(rps, duration int) {
+	autoTestRPS = rps
+	autoTestDuration = duration
+}
+
+// SetScraperCommand는 Node.js 스크래퍼 실행 명령을 설정 (예: node, xvfb-run node)
+func SetScraperCommand(command string) {
+	if strings.TrimSpace(command) == "" {
+		command = DefaultScraperCommand
+	}
+	scraperCommand = command
+}
+
+// UseNodeScraper는 경로 추출에 내장 크롤러 대신 Node.js(Puppeteer) 스크래퍼를 사용하도록 설정
+// 빈 문자열을 넘기면 내장 크롤러로 되돌립니다. timeout이 0 이하면 기본값을 사용합니다.
+func UseNodeScraper(script string, timeout time.Duration) {
+	scraperScript = script
+	if timeout <= 0 {
+		timeout = DefaultScraperTimeout
+	}
+	scraperTimeout = timeout
+}
+
+// SetCrawlOptions는 내장 크롤러의 깊이/페이지 제한 등을 설정
+func SetCrawlOptions(opts crawler.Options) {
+	crawlOptions = opts
+}
+
 // AutomatedTest는 URL 기반으로 전체 테스트 과정을 자동화하는 구조체
 type AutomatedTest struct {
-	TargetURL      string                // 테스트 대상 URL
-	ExtractedPaths []string              // 스크래퍼로 추출한 전체 경로
+	TargetURL      string                  // 테스트 대상 URL
+	ExtractedPaths []string                // 추출한 전체 경로
+	Endpoints      []config.Endpoint       // 추출한 엔드포인트 (메서드, 출처 페이지 등 포함)
+	OutOfScope     []config.Endpoint       // 범위 밖이라 제외한 엔드포인트 (외부 서비스, 제외 경로 등)
+	Scope          *config.Scope           // 탐색과 부하 테스트에 적용할 범위 (nil이면 대상 호스트만)
 	TopPaths       []ai.PathRecommendation // GPT 분석 후 우선순위 높은 경로들
-	TestResults    map[string]interface{} // 테스트 결과
+	TestResults    map[string]interface{}  // 테스트 결과
+	Owner          string                  // 실행을 시작한 API 키 이름 (대기열 표시용)
+	beforeLoad     func(req config.TestRequest) error
+}
+
+// FullTestOptions는 전체 자동 테스트의 선택 설정
+type FullTestOptions struct {
+	Scope *config.Scope // 탐색과 부하 테스트에 적용할 범위 (nil이면 대상 호스트만)
+	Owner string        // 실행을 시작한 API 키 이름
+	// BeforeLoad는 부하 테스트 구성이 정해진 뒤 실행 직전에 호출 (오류를 반환하면 실행하지 않음)
+	// API는 여기서 요청한 키의 요청 예산을 사용합니다.
+	BeforeLoad func(req config.TestRequest) error
 }
 
 // RunFullTest는 URL로부터 시작하여 전체 과정을 실행하는 메소드
-// 1. 스크래퍼로 경로 추출
+// 1. 기본 탐색 소스(크롤러 또는 스크래퍼)로 경로 추출
 // 2. GPT로 중요 경로 분석
 // 3. 부하 테스트 실행
 // 4. 결과 반환
 func RunFullTest(targetURL string) (*AutomatedTest, error) {
+	return RunFullTestWithSource(context.Background(), targetURL, DefaultSource(), FullTestOptions{})
+}
+
+// RunFullTestWithSource는 지정한 탐색 소스(OpenAPI 명세, HAR 등)로 경로를 추출하여 전체 과정을 실행
+// opts.Scope가 있으면 범위 밖의 엔드포인트는 분석과 부하 테스트에서 제외합니다.
+// ctx는 경로 탐색과 부하 테스트의 실행 대기에 사용됩니다 (취소되면 대기열에서 빠짐).
+// 로그는 ctx에 담긴 로거(logger.WithContext)로 남깁니다.
+func RunFullTestWithSource(ctx context.Context, targetURL string, source EndpointSource, opts FullTestOptions) (*AutomatedTest, error) {
+	log := logger.FromContext(ctx)
 	test := &AutomatedTest{
-		TargetURL: targetURL,
+		TargetURL:  targetURL,
+		Scope:      opts.Scope,
+		Owner:      opts.Owner,
+		beforeLoad: opts.BeforeLoad,
 	}
 
-	// 1. API 경로 추출 (Node.js 스크래퍼 실행)
-	if err := test.extractPaths(); err != nil {
-		return nil, fmt.Errorf("경로 추출 실패: %v", err)
+	// 1. API 경로 추출
+	if err := test.extractPaths(ctx, source); err != nil {
+		return nil, fmt.Errorf("경로 추출 실패 (%s): %w", source.Name(), err)
 	}
+	log.Infow("경로 추출 완료",
+		"target", targetURL,
+		"source", source.Name(),
+		"paths", len(test.ExtractedPaths),
+		"outOfScope", len(test.OutOfScope),
+	)
 
 	// 경로가 없으면 오류 반환
 	if len(test.ExtractedPaths) == 0 {
@@ -39,59 +155,51 @@ func RunFullTest(targetURL string) (*AutomatedTest, error) {
 	}
 
 	// 2. GPT 분석 - 부하 가능성 높은 경로 추천
-	if err := test.analyzePathsWithGPT(); err != nil {
+	if err := test.analyzePathsWithGPT(ctx); err != nil {
 		return nil, fmt.Errorf("GPT 분석 실패: %v", err)
 	}
+	log.Infow("GPT 경로 분석 완료", "target", targetURL, "recommended", len(test.TopPaths))
 
 	// 3. 테스트 구성 생성 및 실행
-	if err := test.runLoadTest(); err != nil {
-		return nil, fmt.Errorf("부하 테스트 실패: %v", err)
+	if err := test.runLoadTest(ctx); err != nil {
+		return nil, fmt.Errorf("부하 테스트 실패: %w", err)
 	}
 
 	return test, nil
 }
 
-// extractPaths는 Node.js 스크래퍼를 호출하여 API 경로를 추출하는 메소드
-func (t *AutomatedTest) extractPaths() error {
-	// Node.js 스크래퍼 실행 명령 구성 - 경로 업데이트
-	cmd := exec.Command("node", "../workers/scrappers/api-extractor.js", t.TargetURL)
-	output, err := cmd.CombinedOutput()
+// extractPaths는 탐색 소스로 엔드포인트를 찾아 경로 목록(템플릿)을 채우는 메소드
+func (t *AutomatedTest) extractPaths(ctx context.Context, source EndpointSource) error {
+	endpoints, err := source.Discover(ctx, t.TargetURL)
 	if err != nil {
-		return fmt.Errorf("스크래퍼 실행 오류: %v, 출력: %s", err, string(output))
+		return err
 	}
 
-	// 출력에서 경로 목록 추출
-	outputStr := string(output)
-	lines := strings.Split(outputStr, "\n")
-
-	// 발견된 API 엔드포인트 찾기
-	var extracting bool
-	for _, line := range lines {
-		if strings.Contains(line, "발견된 API 엔드포인트") {
-			extracting = true
-			continue
-		}
+	// 외부 서비스 호출 등 범위 밖의 엔드포인트 제외
+	sc, err := scope.New(t.TargetURL, t.Scope)
+	if err != nil {
+		return err
+	}
+	endpoints, t.OutOfScope = sc.Filter(endpoints)
 
-		if extracting && strings.HasPrefix(line, "- ") {
-			// "- GET /path" 형식에서 "/path" 부분 추출
-			parts := strings.SplitN(strings.TrimPrefix(line, "- "), " ", 2)
-			if len(parts) == 2 {
-				t.ExtractedPaths = append(t.ExtractedPaths, parts[1])
-			}
-		}
+	// 식별자만 다른 경로를 템플릿으로 묶어 GPT 프롬프트와 테스트 대상을 줄임
+	t.Endpoints = NormalizeEndpoints(endpoints)
 
-		if extracting && strings.Contains(line, "부하테스트 구성") {
-			break
+	seen := make(map[string]bool)
+	for _, ep := range t.Endpoints {
+		p := requestPath(t.TargetURL, ep)
+		if !seen[p] {
+			seen[p] = true
+			t.ExtractedPaths = append(t.ExtractedPaths, p)
 		}
 	}
-
 	return nil
 }
 
 // analyzePathsWithGPT는 GPT를 사용하여 추출된 경로들의 중요도를 분석
-func (t *AutomatedTest) analyzePathsWithGPT() error {
+func (t *AutomatedTest) analyzePathsWithGPT(ctx context.Context) error {
 	// GPT 분석 호출 (ai 모듈의 AnalyzeWebsite 함수 사용)
-	result, err := ai.AnalyzeWebsite(t.TargetURL, t.ExtractedPaths)
+	result, err := ai.AnalyzeWebsite(ctx, t.TargetURL, t.ExtractedPaths)
 	if err != nil {
 		return fmt.Errorf("GPT 분석 오류: %v", err)
 	}
@@ -103,15 +211,17 @@ func (t *AutomatedTest) analyzePathsWithGPT() error {
 }
 
 // runLoadTest는 분석된 경로들로 부하 테스트를 실행
-func (t *AutomatedTest) runLoadTest() error {
+func (t *AutomatedTest) runLoadTest(ctx context.Context) error {
 	// 테스트 요청 구성
 	testReq := config.TestRequest{
 		Target:   t.TargetURL,
 		Method:   "GET",
-		RPS:      10,
-		Duration: 10,
+		RPS:      autoTestRPS,
+		Duration: autoTestDuration,
 		PathList: make([]string, 0),
 		Silent:   true,
+		Scope:    t.Scope,
+		Owner:    t.Owner,
 	}
 
 	// GPT 추천 경로만 테스트 대상으로 설정
@@ -127,10 +237,22 @@ func (t *AutomatedTest) runLoadTest() error {
 		}
 	}
 
-	// load-test 모듈의 RunLoadTest 함수 호출
-	result, err := loadtest.RunLoadTest(testReq)
+	// 템플릿 경로는 관찰된 값으로 채워서 요청
+	if specs, ok := templatedRequests(t.TargetURL, testReq.Method, testReq.PathList, t.Endpoints); ok {
+		testReq.Requests = specs
+	}
+
+	// 강도가 정해졌으므로 실행 전에 예산 확인 등을 수행
+	if t.beforeLoad != nil {
+		if err := t.beforeLoad(testReq); err != nil {
+			return err
+		}
+	}
+
+	// load-test 모듈의 RunLoadTestContext 함수 호출
+	result, err := loadtest.RunLoadTestContext(ctx, testReq)
 	if err != nil {
-		return fmt.Errorf("부하 테스트 실행 오류: %v", err)
+		return fmt.Errorf("부하 테스트 실행 오류: %w", err)
 	}
 
 	// 결과 저장
@@ -141,9 +263,9 @@ func (t *AutomatedTest) runLoadTest() error {
 	return nil
 }
 
-// RunRecommendedLoadTest는 특정 테스트 권장사항에 따라 부하 테스트를 실행
-func RunRecommendedLoadTest(targetURL string, recommendation ai.TestRecommendation) (interface{}, error) {
-	// 테스트 요청 구성
+// BuildRecommendedRequest는 테스트 권장사항을 부하 테스트 요청으로 변환
+// 추천 경로가 템플릿(/users/{id})이면 endpoints에서 관찰된 값으로 채워 요청합니다.
+func BuildRecommendedRequest(targetURL string, recommendation ai.TestRecommendation, endpoints []config.Endpoint) config.TestRequest {
 	testReq := config.TestRequest{
 		Target:   targetURL,
 		Method:   recommendation.Method,
@@ -152,7 +274,17 @@ func RunRecommendedLoadTest(targetURL string, recommendation ai.TestRecommendati
 		PathList: recommendation.Paths,
 		Silent:   true,
 	}
+	if specs, ok := templatedRequests(targetURL, testReq.Method, testReq.PathList, endpoints); ok {
+		testReq.Requests = specs
+	}
+	return testReq
+}
+
+// RunRecommendedLoadTest는 특정 테스트 권장사항에 따라 부하 테스트를 실행
+func RunRecommendedLoadTest(ctx context.Context, targetURL string, recommendation ai.TestRecommendation, endpoints []config.Endpoint) (config.TestResult, error) {
+	// 테스트 요청 구성
+	testReq := BuildRecommendedRequest(targetURL, recommendation, endpoints)
 
 	// 부하 테스트 실행
-	return loadtest.RunLoadTest(testReq)
-}
\ No newline at end of file
+	return loadtest.RunLoadTestContext(ctx, testReq)
+}
@@ -0,0 +1,42 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/ai"
+)
+
+func TestRunLoadTestCallsBeforeLoadWithFinalRequest(t *testing.T) {
+	errBudget := errors.New("budget exceeded")
+	var got config.TestRequest
+	test := &AutomatedTest{
+		TargetURL: "http://127.0.0.1:1",
+		Owner:     "team-a",
+		TopPaths: []ai.PathRecommendation{
+			{Path: "/api/search", Method: "POST", RPS: 40},
+			{Path: "/api/items", Method: "GET"},
+		},
+		beforeLoad: func(req config.TestRequest) error {
+			got = req
+			return errBudget
+		},
+	}
+
+	// 예산 확인이 실패하면 부하를 보내지 않고 그 오류를 반환
+	if err := test.runLoadTest(context.Background()); !errors.Is(err, errBudget) {
+		t.Fatalf("runLoadTest = %v, want budget error", err)
+	}
+	if got.RPS != 40 || got.Duration != autoTestDuration || got.Method != "POST" || got.Owner != "team-a" {
+		t.Errorf("beforeLoad request = rps %d, duration %d, method %s, owner %q; want AI-chosen RPS 40",
+			got.RPS, got.Duration, got.Method, got.Owner)
+	}
+	if len(got.PathList) != 2 {
+		t.Errorf("beforeLoad paths = %v", got.PathList)
+	}
+	if test.TestResults != nil {
+		t.Error("load test ran although beforeLoad failed")
+	}
+}
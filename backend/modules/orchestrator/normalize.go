@@ -0,0 +1,144 @@
+package orchestrator
+
+import (
+	"maps"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/pathtemplate"
+)
+
+// 템플릿별로 보관할 최대 관찰 값 묶음 수
+const maxParamSamples = 20
+
+// NormalizeEndpoints는 식별자만 다른 경로들을 템플릿 하나로 묶음
+// 예: GET /api/users/123, GET /api/users/456 → GET /api/users/{id} (pathParams = [{id: 123}, {id: 456}])
+// 파라미터 값은 경로 하나에서 함께 관찰된 묶음으로 보관하므로 /users/{id}/orders/{id2} 에 관찰되지 않은 조합이 생기지 않습니다.
+// 묶인 엔드포인트의 쿼리/폼 필드는 합치고, 헤더/본문 예시는 처음 관찰된 것부터 사용합니다.
+func NormalizeEndpoints(endpoints []config.Endpoint) []config.Endpoint {
+	index := make(map[string]int)
+	var result []config.Endpoint
+
+	for _, ep := range endpoints {
+		template, values := pathtemplate.Normalize(ep.Path)
+		origin := strings.TrimSuffix(ep.URL, ep.Path)
+
+		key := ep.Method + " " + origin + template
+		i, ok := index[key]
+		if !ok {
+			merged := ep
+			merged.Path = template
+			merged.URL = origin + template
+			merged.PathParams = nil
+			merged.QueryParams = append([]string(nil), ep.QueryParams...)
+			merged.FormFields = append([]string(nil), ep.FormFields...)
+			merged.SampleBodies = append([]string(nil), ep.SampleBodies...)
+			merged.Variants = 0
+			i = len(result)
+			index[key] = i
+			result = append(result, merged)
+		} else {
+			mergeEndpoint(&result[i], ep)
+		}
+
+		target := &result[i]
+		target.Variants++
+		if len(values) > 0 && len(target.PathParams) < maxParamSamples && !containsValues(target.PathParams, values) {
+			target.PathParams = append(target.PathParams, values)
+		}
+	}
+
+	for i := range result {
+		sort.Strings(result[i].QueryParams)
+	}
+	return result
+}
+
+// mergeEndpoint는 같은 템플릿의 엔드포인트 정보를 합침
+func mergeEndpoint(dst *config.Endpoint, src config.Endpoint) {
+	for _, q := range src.QueryParams {
+		if !contains(dst.QueryParams, q) {
+			dst.QueryParams = append(dst.QueryParams, q)
+		}
+	}
+	for _, f := range src.FormFields {
+		if !contains(dst.FormFields, f) {
+			dst.FormFields = append(dst.FormFields, f)
+		}
+	}
+	for _, b := range src.SampleBodies {
+		if len(dst.SampleBodies) < 3 && !contains(dst.SampleBodies, b) {
+			dst.SampleBodies = append(dst.SampleBodies, b)
+		}
+	}
+	if dst.Headers == nil && src.Headers != nil {
+		dst.Headers = src.Headers
+	}
+	if dst.RequestContentType == "" {
+		dst.RequestContentType = src.RequestContentType
+	}
+	if dst.ResponseContentType == "" {
+		dst.ResponseContentType = src.ResponseContentType
+	}
+}
+
+// templatedRequests는 경로 목록에 템플릿이 있으면 관찰된 값으로 채워 보낼 요청 목록을 만듦
+// 템플릿 경로가 없으면 false를 반환하여 기존 PathList 방식을 그대로 사용하게 합니다.
+func templatedRequests(targetURL, method string, paths []string, endpoints []config.Endpoint) ([]config.RequestSpec, bool) {
+	params := make(map[string][]map[string]string)
+	for _, ep := range endpoints {
+		p := requestPath(targetURL, ep)
+		if len(ep.PathParams) > 0 && params[p] == nil {
+			params[p] = ep.PathParams
+		}
+	}
+
+	templated := false
+	specs := make([]config.RequestSpec, 0, len(paths))
+	for _, p := range paths {
+		spec := config.RequestSpec{Method: method, Path: p}
+		if values, ok := params[p]; ok {
+			spec.PathParams = values
+			templated = true
+		}
+		specs = append(specs, spec)
+	}
+	return specs, templated
+}
+
+// requestPath는 부하 테스트 요청에 쓸 엔드포인트 경로를 반환
+// 대상과 다른 호스트(범위에서 허용한 API 호스트 등)의 엔드포인트는 대상 URL에 붙이지 않도록 전체 URL을 사용합니다.
+func requestPath(targetURL string, ep config.Endpoint) string {
+	if ep.URL == "" {
+		return ep.Path
+	}
+	target, err := url.Parse(targetURL)
+	if err != nil {
+		return ep.Path
+	}
+	u, err := url.Parse(ep.URL)
+	if err != nil || (u.Scheme == target.Scheme && strings.EqualFold(u.Host, target.Host)) {
+		return ep.Path
+	}
+	return ep.URL
+}
+
+func containsValues(list []map[string]string, values map[string]string) bool {
+	for _, v := range list {
+		if maps.Equal(v, values) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package orchestrator
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+func TestNormalizeEndpointsGroupsTemplates(t *testing.T) {
+	endpoints := []config.Endpoint{
+		{Method: "GET", URL: "https://example.com/api/users/1/orders/10", Path: "/api/users/1/orders/10", QueryParams: []string{"page"}},
+		{Method: "GET", URL: "https://example.com/api/users/2/orders/20", Path: "/api/users/2/orders/20", QueryParams: []string{"expand", "page"}},
+		{Method: "GET", URL: "https://example.com/api/users/1/orders/10", Path: "/api/users/1/orders/10"}, // 같은 묶음은 한 번만
+		{Method: "POST", URL: "https://example.com/api/users/3/orders/30", Path: "/api/users/3/orders/30", FormFields: []string{"qty"}},
+		{Method: "GET", URL: "https://api.example.com/api/users/4/orders/40", Path: "/api/users/4/orders/40"},
+		{Method: "GET", URL: "https://example.com/about", Path: "/about"},
+	}
+	got := NormalizeEndpoints(endpoints)
+	if len(got) != 4 {
+		t.Fatalf("got %d endpoints, want 4 (GET, POST, other host, /about): %+v", len(got), got)
+	}
+
+	orders := got[0]
+	if orders.Path != "/api/users/{id}/orders/{id2}" || orders.URL != "https://example.com/api/users/{id}/orders/{id2}" {
+		t.Errorf("template = %s (%s)", orders.Path, orders.URL)
+	}
+	if orders.Variants != 3 {
+		t.Errorf("Variants = %d, want 3", orders.Variants)
+	}
+	// 값은 경로 하나에서 함께 관찰된 묶음으로 보관
+	want := []map[string]string{{"id": "1", "id2": "10"}, {"id": "2", "id2": "20"}}
+	if !slices.EqualFunc(orders.PathParams, want, maps.Equal) {
+		t.Errorf("PathParams = %v, want %v", orders.PathParams, want)
+	}
+	if !slices.Equal(orders.QueryParams, []string{"expand", "page"}) {
+		t.Errorf("QueryParams = %v, want merged and sorted", orders.QueryParams)
+	}
+
+	if got[1].Method != "POST" || !slices.Equal(got[1].FormFields, []string{"qty"}) || len(got[1].PathParams) != 1 {
+		t.Errorf("POST endpoint = %+v", got[1])
+	}
+	if got[2].URL != "https://api.example.com/api/users/{id}/orders/{id2}" {
+		t.Errorf("other host endpoint = %+v, want its own template", got[2])
+	}
+	if got[3].Path != "/about" || got[3].PathParams != nil || got[3].Variants != 1 {
+		t.Errorf("plain endpoint = %+v", got[3])
+	}
+}
+
+func TestNormalizeEndpointsCapsParamSamples(t *testing.T) {
+	var endpoints []config.Endpoint
+	for i := 0; i < maxParamSamples+5; i++ {
+		path := "/items/" + strconv.Itoa(i+1)
+		endpoints = append(endpoints, config.Endpoint{Method: "GET", URL: "https://example.com" + path, Path: path})
+	}
+	got := NormalizeEndpoints(endpoints)
+	if len(got) != 1 || len(got[0].PathParams) != maxParamSamples || got[0].Variants != maxParamSamples+5 {
+		t.Errorf("got %d endpoints, %d samples, %d variants", len(got), len(got[0].PathParams), got[0].Variants)
+	}
+}
+
+func TestTemplatedRequestsCarriesParamTuples(t *testing.T) {
+	endpoints := NormalizeEndpoints([]config.Endpoint{
+		{Method: "GET", URL: "https://example.com/users/1/orders/10", Path: "/users/1/orders/10"},
+		{Method: "GET", URL: "https://example.com/users/2/orders/20", Path: "/users/2/orders/20"},
+	})
+	specs, ok := templatedRequests("https://example.com", "GET", []string{"/users/{id}/orders/{id2}", "/about"}, endpoints)
+	if !ok || len(specs) != 2 {
+		t.Fatalf("templatedRequests = %+v, %v", specs, ok)
+	}
+	if len(specs[0].PathParams) != 2 || specs[0].PathParams[1]["id2"] != "20" || specs[1].PathParams != nil {
+		t.Errorf("specs = %+v", specs)
+	}
+}
@@ -0,0 +1,41 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/openapi"
+)
+
+// OpenAPIPlan은 명세에서 만든 부하 테스트 구성
+type OpenAPIPlan struct {
+	Title   string             `json:"title,omitempty"`   // 명세 제목
+	Request config.TestRequest `json:"request"`           // 실행할 테스트 설정 (Requests 포함)
+	Skipped []openapi.Skipped  `json:"skipped,omitempty"` // 제외된 Operation과 사유
+}
+
+// BuildOpenAPIPlan은 OpenAPI/Swagger 명세로 GPT 분석 없이 바로 실행할 수 있는 부하 테스트 구성을 생성
+// base의 RPS, Duration 등은 그대로 사용하고 Requests만 명세로부터 채웁니다.
+func BuildOpenAPIPlan(ctx context.Context, spec SourceConfig, base config.TestRequest, opts openapi.PlanOptions) (*OpenAPIPlan, error) {
+	doc := document{location: spec.Location, content: spec.Content}
+	if doc.empty() {
+		return nil, fmt.Errorf("명세 location 또는 content가 필요합니다")
+	}
+	data, err := doc.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := openapi.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, skipped := parsed.BuildPlan(opts)
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("명세에서 실행할 수 있는 요청을 만들지 못했습니다")
+	}
+
+	base.Requests = requests
+	return &OpenAPIPlan{Title: parsed.Info.Title, Request: base, Skipped: skipped}, nil
+}
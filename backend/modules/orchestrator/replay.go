@@ -0,0 +1,88 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/har"
+	"github.com/Mr-Muji/LoadTest/backend/modules/replay"
+)
+
+// 재생 방식
+const (
+	ReplayTimed = "timed" // 기록된 상대 시각대로 재생 (speed 배율 적용)
+	ReplayMix   = "mix"   // 기록의 요청 비율을 가중치로 RPS에 맞춰 실행
+)
+
+// SourceAccessLog는 nginx/Apache combined 형식 access log 재생 소스
+const SourceAccessLog = "accesslog"
+
+// ReplayPlan은 기록에서 만든 재생 테스트 구성
+type ReplayPlan struct {
+	Request      config.TestRequest `json:"request"`                // 실행할 테스트 설정
+	Events       int                `json:"events"`                 // 사용한 기록 요청 수
+	SkippedLines int                `json:"skippedLines,omitempty"` // 해석하지 못한 access log 줄 수
+	SpanSec      float64            `json:"spanSec,omitempty"`      // 재생에 걸리는 시간(초, speed 반영)
+}
+
+// BuildReplayPlan은 HAR 또는 access log를 읽어 재생 테스트 구성을 생성
+// timed 모드는 Schedule을, mix 모드는 가중치가 있는 Requests를 채우며 나머지 설정은 base를 따릅니다.
+func BuildReplayPlan(ctx context.Context, src SourceConfig, mode string, base config.TestRequest, opts replay.Options) (*ReplayPlan, error) {
+	doc := document{location: src.Location, content: src.Content}
+	if doc.empty() {
+		return nil, fmt.Errorf("기록 location 또는 content가 필요합니다")
+	}
+	data, err := doc.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ReplayPlan{}
+	var events []replay.Event
+	switch src.Type {
+	case SourceHAR:
+		h, err := har.Parse(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		target, err := url.Parse(base.Target)
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 대상 URL: %v", err)
+		}
+		events = replay.FromHAR(h, target.Host)
+	case SourceAccessLog:
+		events, plan.SkippedLines, err = replay.ParseAccessLog(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("재생할 수 없는 소스: %s (har, accesslog 지원)", src.Type)
+	}
+
+	switch mode {
+	case ReplayTimed, "":
+		base.Schedule = replay.Schedule(events, opts)
+		plan.Events = len(base.Schedule)
+		speed := base.Speed
+		if speed <= 0 {
+			speed = 1
+		}
+		plan.SpanSec = replay.Span(base.Schedule).Seconds() / speed
+	case ReplayMix:
+		base.Requests = replay.Mix(events, opts)
+		for _, r := range base.Requests {
+			plan.Events += r.Weight
+		}
+	default:
+		return nil, fmt.Errorf("알 수 없는 재생 방식: %s (timed, mix 지원)", mode)
+	}
+	if plan.Events == 0 {
+		return nil, fmt.Errorf("재생할 요청이 없습니다")
+	}
+
+	plan.Request = base
+	return plan, nil
+}
@@ -0,0 +1,89 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// ScraperProtocolVersion은 Node.js 스크래퍼의 --json 출력 형식 버전
+// workers/scrappers/api-extractor.js 의 PROTOCOL_VERSION 과 일치해야 합니다.
+const ScraperProtocolVersion = 1
+
+// DefaultScraperScript는 backend 디렉토리 기준 Node.js 스크래퍼 기본 경로
+const DefaultScraperScript = "../workers/scrappers/api-extractor.js"
+
+// DefaultScraperCommand는 Node.js 스크래퍼 기본 실행 명령
+const DefaultScraperCommand = "node"
+
+// DefaultScraperTimeout은 Node.js 스크래퍼 실행 기본 제한 시간
+const DefaultScraperTimeout = 2 * time.Minute
+
+// ScraperDocument는 스크래퍼가 stdout으로 출력하는 JSON 문서
+type ScraperDocument struct {
+	Version   int               `json:"version"`         // 프로토콜 버전
+	Target    string            `json:"target"`          // 분석한 URL
+	Endpoints []config.Endpoint `json:"endpoints"`       // 발견한 엔드포인트
+	Error     string            `json:"error,omitempty"` // 스크래퍼 내부 오류
+}
+
+// runScraper는 스크래퍼를 --json 모드로 실행하고 결과 문서를 해석
+// 진행 로그(stderr)는 오류 메시지에만 사용합니다.
+// command는 공백으로 나눠 실행 파일과 앞쪽 인자로 사용합니다 (예: "xvfb-run node").
+func runScraper(ctx context.Context, command, script, targetURL string) ([]config.Endpoint, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		args = []string{DefaultScraperCommand}
+	}
+	args = append(args, script, "--json", targetURL)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("스크래퍼 실행 시간 초과 (%v)", scraperTimeout)
+	}
+
+	var doc ScraperDocument
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &doc); err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("스크래퍼 실행 오류: %v, 출력: %s", runErr, tail(stderr.String(), 2000))
+		}
+		return nil, fmt.Errorf("스크래퍼 출력 해석 실패: %v", err)
+	}
+	if doc.Version != ScraperProtocolVersion {
+		return nil, fmt.Errorf("지원하지 않는 스크래퍼 프로토콜 버전: %d (필요: %d)", doc.Version, ScraperProtocolVersion)
+	}
+	if doc.Error != "" {
+		return nil, fmt.Errorf("스크래퍼 오류: %s", doc.Error)
+	}
+	if runErr != nil {
+		return nil, fmt.Errorf("스크래퍼 실행 오류: %v, 출력: %s", runErr, tail(stderr.String(), 2000))
+	}
+
+	for i := range doc.Endpoints {
+		if doc.Endpoints[i].Method == "" {
+			doc.Endpoints[i].Method = "GET"
+		}
+		doc.Endpoints[i].Method = strings.ToUpper(doc.Endpoints[i].Method)
+	}
+	return doc.Endpoints, nil
+}
+
+// tail은 긴 로그의 마지막 n바이트만 반환
+func tail(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= n {
+		return s
+	}
+	return "..." + s[len(s)-n:]
+}
@@ -0,0 +1,420 @@
+package orchestrator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/crawler"
+	"github.com/Mr-Muji/LoadTest/backend/modules/har"
+	"github.com/Mr-Muji/LoadTest/backend/modules/openapi"
+)
+
+// 탐색 소스 종류
+const (
+	SourceCrawler = "crawler" // 내장 크롤러
+	SourceScraper = "scraper" // Node.js(Puppeteer) 스크래퍼
+	SourceOpenAPI = "openapi" // OpenAPI 3 / Swagger 2 명세 (JSON)
+	SourceHAR     = "har"     // 브라우저 HAR 파일
+	SourceSitemap = "sitemap" // sitemap.xml
+	SourceList    = "list"    // 경로 목록 ("/path" 또는 "METHOD /path" 줄 단위)
+)
+
+// 외부 문서(명세, HAR, sitemap) 최대 크기와 내려받을 때 따라갈 최대 리다이렉트 수
+const (
+	maxSourceBytes     = 32 << 20
+	maxSourceRedirects = 10
+)
+
+// EndpointSource는 대상 서비스의 엔드포인트를 찾아내는 방법
+type EndpointSource interface {
+	// Name은 소스 종류 (로그/기록용)
+	Name() string
+	// Discover는 대상 URL의 엔드포인트 목록을 반환
+	Discover(ctx context.Context, targetURL string) ([]config.Endpoint, error)
+}
+
+// SourceConfig는 API 요청 등에서 탐색 소스를 지정하는 설정
+type SourceConfig struct {
+	Type     string   `json:"type"`               // crawler, scraper, openapi, har, sitemap, list
+	Location string   `json:"location,omitempty"` // 문서 URL 또는 SourceDir 기준 상대 파일 경로
+	Content  string   `json:"content,omitempty"`  // 문서 내용을 직접 전달할 때 사용
+	Paths    []string `json:"paths,omitempty"`    // list 소스의 경로 목록
+}
+
+// NewSource는 설정에 맞는 탐색 소스를 생성
+// Type이 비어 있으면 서버 기본값(내장 크롤러 또는 UseNodeScraper로 지정한 스크래퍼)을 사용합니다.
+func NewSource(cfg SourceConfig) (EndpointSource, error) {
+	doc := document{location: cfg.Location, content: cfg.Content}
+	switch cfg.Type {
+	case "":
+		return DefaultSource(), nil
+	case SourceCrawler:
+		return CrawlerSource{Options: crawlOptions}, nil
+	case SourceScraper:
+		script := scraperScript
+		if script == "" {
+			script = DefaultScraperScript
+		}
+		return ScraperSource{Command: scraperCommand, Script: script, Timeout: scraperTimeout}, nil
+	case SourceOpenAPI:
+		if doc.empty() {
+			return nil, fmt.Errorf("openapi 소스에는 location 또는 content가 필요합니다")
+		}
+		return OpenAPISource{document: doc}, nil
+	case SourceHAR:
+		if doc.empty() {
+			return nil, fmt.Errorf("har 소스에는 location 또는 content가 필요합니다")
+		}
+		return HARSource{document: doc}, nil
+	case SourceSitemap:
+		return SitemapSource{document: doc}, nil
+	case SourceList:
+		if doc.empty() && len(cfg.Paths) == 0 {
+			return nil, fmt.Errorf("list 소스에는 paths, location 또는 content가 필요합니다")
+		}
+		return ListSource{Paths: cfg.Paths, document: doc}, nil
+	default:
+		return nil, fmt.Errorf("알 수 없는 탐색 소스: %s", cfg.Type)
+	}
+}
+
+// DefaultSource는 서버 설정에 따른 기본 탐색 소스
+func DefaultSource() EndpointSource {
+	if scraperScript != "" {
+		return ScraperSource{Command: scraperCommand, Script: scraperScript, Timeout: scraperTimeout}
+	}
+	return CrawlerSource{Options: crawlOptions}
+}
+
+// CrawlerSource는 내장 크롤러로 엔드포인트를 찾는 소스
+type CrawlerSource struct {
+	Options crawler.Options
+}
+
+func (CrawlerSource) Name() string { return SourceCrawler }
+
+func (s CrawlerSource) Discover(ctx context.Context, targetURL string) ([]config.Endpoint, error) {
+	opts := s.Options
+	if opts.Client == nil && fetchDial != nil {
+		opts.Client = &http.Client{Transport: fetchTransport()}
+	}
+	result, err := crawler.Crawl(ctx, targetURL, opts)
+	if err != nil {
+		return nil, fmt.Errorf("크롤링 오류: %v", err)
+	}
+	return result.Endpoints, nil
+}
+
+// ScraperSource는 Node.js 스크래퍼로 엔드포인트를 찾는 소스
+type ScraperSource struct {
+	Command string // 실행 명령 (빈 값이면 node)
+	Script  string
+	Timeout time.Duration
+}
+
+func (ScraperSource) Name() string { return SourceScraper }
+
+func (s ScraperSource) Discover(ctx context.Context, targetURL string) ([]config.Endpoint, error) {
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = DefaultScraperTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return runScraper(ctx, s.Command, s.Script, targetURL)
+}
+
+// OpenAPISource는 OpenAPI/Swagger 명세에서 엔드포인트를 가져오는 소스
+type OpenAPISource struct {
+	document
+}
+
+func (OpenAPISource) Name() string { return SourceOpenAPI }
+
+func (s OpenAPISource) Discover(ctx context.Context, targetURL string) ([]config.Endpoint, error) {
+	data, err := s.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := openapi.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Endpoints(targetURL), nil
+}
+
+// HARSource는 브라우저 HAR 파일에 기록된 요청을 가져오는 소스 (대상이 아닌 호스트는 범위 필터에서 제외)
+type HARSource struct {
+	document
+}
+
+func (HARSource) Name() string { return SourceHAR }
+
+func (s HARSource) Discover(ctx context.Context, targetURL string) ([]config.Endpoint, error) {
+	data, err := s.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	h, err := har.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	// 다른 호스트의 요청도 모두 반환하고, 대상/허용 호스트 판단은 범위(Scope) 필터에 맡김
+	return h.Endpoints(""), nil
+}
+
+// SitemapSource는 sitemap.xml의 페이지 URL을 GET 엔드포인트로 가져오는 소스
+// 위치를 지정하지 않으면 대상 URL의 /sitemap.xml 을 사용합니다.
+type SitemapSource struct {
+	document
+}
+
+// sitemap 인덱스를 따라갈 최대 하위 sitemap 수
+const maxSitemaps = 20
+
+func (SitemapSource) Name() string { return SourceSitemap }
+
+func (s SitemapSource) Discover(ctx context.Context, targetURL string) ([]config.Endpoint, error) {
+	target, err := url.Parse(targetURL)
+	if err != nil {
+		return nil, fmt.Errorf("잘못된 대상 URL: %v", err)
+	}
+	if s.empty() {
+		s.location = target.Scheme + "://" + target.Host + "/sitemap.xml"
+	}
+
+	data, err := s.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []config.Endpoint
+	seen := make(map[string]bool)
+	pending := [][]byte{data}
+	fetched := 0
+	for len(pending) > 0 {
+		pages, children, err := parseSitemap(pending[0])
+		pending = pending[1:]
+		if err != nil {
+			return nil, err
+		}
+		for _, loc := range pages {
+			u, err := url.Parse(loc)
+			if err != nil || u.Host != target.Host || seen[u.Path] {
+				continue
+			}
+			seen[u.Path] = true
+			path := u.Path
+			if path == "" {
+				path = "/"
+			}
+			ep := config.Endpoint{Method: "GET", URL: u.Scheme + "://" + u.Host + path, Path: path, Source: SourceSitemap}
+			for name := range u.Query() {
+				ep.QueryParams = append(ep.QueryParams, name)
+			}
+			endpoints = append(endpoints, ep)
+		}
+		// sitemap 인덱스면 하위 sitemap을 이어서 읽음 (sitemap 프로토콜에 따라 대상과 같은 호스트만)
+		for _, child := range children {
+			if fetched >= maxSitemaps {
+				break
+			}
+			if u, err := url.Parse(child); err != nil || u.Host != target.Host {
+				continue
+			}
+			fetched++
+			body, err := document{location: child}.read(ctx)
+			if err != nil {
+				return nil, err
+			}
+			pending = append(pending, body)
+		}
+	}
+	return endpoints, nil
+}
+
+// parseSitemap은 <urlset>의 페이지 URL과 <sitemapindex>의 하위 sitemap URL을 반환
+func parseSitemap(data []byte) (pages, children []string, err error) {
+	var doc struct {
+		XMLName xml.Name
+		URLs    []struct {
+			Loc string `xml:"loc"`
+		} `xml:"url"`
+		Sitemaps []struct {
+			Loc string `xml:"loc"`
+		} `xml:"sitemap"`
+	}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("sitemap 해석 실패: %v", err)
+	}
+	for _, u := range doc.URLs {
+		pages = append(pages, strings.TrimSpace(u.Loc))
+	}
+	for _, s := range doc.Sitemaps {
+		children = append(children, strings.TrimSpace(s.Loc))
+	}
+	return pages, children, nil
+}
+
+// ListSource는 직접 지정한 경로 목록을 엔드포인트로 사용하는 소스
+// 각 줄은 "/path", "METHOD /path" 또는 전체 URL이며 #으로 시작하는 줄은 무시합니다.
+type ListSource struct {
+	Paths []string
+	document
+}
+
+func (ListSource) Name() string { return SourceList }
+
+func (s ListSource) Discover(ctx context.Context, targetURL string) ([]config.Endpoint, error) {
+	lines := s.Paths
+	if !s.empty() {
+		data, err := s.read(ctx)
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+	}
+
+	targetURL = strings.TrimSuffix(targetURL, "/")
+	var endpoints []config.Endpoint
+	seen := make(map[string]bool)
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		method := "GET"
+		if fields := strings.Fields(line); len(fields) == 2 {
+			method, line = strings.ToUpper(fields[0]), fields[1]
+		}
+		u, err := url.Parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 경로: %s", line)
+		}
+		path := u.Path
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		ep := config.Endpoint{Method: method, URL: targetURL + path, Path: path, Source: SourceList}
+		for name := range u.Query() {
+			ep.QueryParams = append(ep.QueryParams, name)
+		}
+		if !seen[ep.Key()] {
+			seen[ep.Key()] = true
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints, nil
+}
+
+// document는 URL, 파일 경로 또는 직접 전달한 내용으로 지정하는 외부 문서
+type document struct {
+	location string
+	content  string
+}
+
+func (d document) empty() bool {
+	return d.location == "" && d.content == ""
+}
+
+// read는 문서 내용을 반환
+// http/https URL은 안전 정책 검사 뒤 내려받고, 그 외는 SourceDir 안의 파일로 읽습니다.
+func (d document) read(ctx context.Context) ([]byte, error) {
+	if d.content != "" {
+		return []byte(d.content), nil
+	}
+	if strings.HasPrefix(d.location, "http://") || strings.HasPrefix(d.location, "https://") {
+		return fetchDocument(ctx, d.location)
+	}
+	return readSourceFile(d.location)
+}
+
+// fetchDocument는 URL의 문서를 내려받음
+// 첫 요청과 모든 리다이렉트 대상을 fetchGuard로 검사하고, 최대 크기를 넘으면 오류를 반환합니다.
+func fetchDocument(ctx context.Context, location string) ([]byte, error) {
+	if err := checkFetch(ctx, location); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", location, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: fetchTransport(),
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxSourceRedirects {
+				return fmt.Errorf("리다이렉트가 %d번을 넘음", maxSourceRedirects)
+			}
+			return checkFetch(req.Context(), req.URL.String())
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("문서 다운로드 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("문서 다운로드 실패: %s (상태 코드 %d)", location, resp.StatusCode)
+	}
+	if resp.ContentLength > maxSourceBytes {
+		return nil, fmt.Errorf("문서가 너무 큽니다: %s (최대 %dMB)", location, maxSourceBytes>>20)
+	}
+	return readLimited(resp.Body, location)
+}
+
+// checkFetch는 fetchGuard가 있으면 URL을 내려받아도 되는지 검사
+func checkFetch(ctx context.Context, rawURL string) error {
+	if fetchGuard == nil {
+		return nil
+	}
+	return fetchGuard(ctx, rawURL)
+}
+
+// readSourceFile은 SourceDir 안의 파일을 읽음
+// 요청으로 받은 경로로 서버의 임의 파일을 읽지 못하도록 절대 경로, .., 디렉토리 밖을 가리키는 심볼릭 링크는 거부합니다.
+func readSourceFile(name string) ([]byte, error) {
+	if sourceDir == "" {
+		return nil, fmt.Errorf("서버 파일 문서는 사용할 수 없습니다 (SOURCE_DIR 미설정): URL 또는 content로 전달하세요")
+	}
+	if !filepath.IsLocal(name) {
+		return nil, fmt.Errorf("문서 경로는 SOURCE_DIR 기준 상대 경로여야 합니다: %s", name)
+	}
+	root, err := os.OpenRoot(sourceDir)
+	if err != nil {
+		return nil, fmt.Errorf("문서 디렉토리 열기 실패: %v", err)
+	}
+	defer root.Close()
+	f, err := root.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("문서 읽기 실패: %v", err)
+	}
+	defer f.Close()
+	return readLimited(f, name)
+}
+
+// readLimited는 최대 크기까지 읽고, 넘으면 잘린 문서 대신 오류를 반환
+func readLimited(r io.Reader, name string) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxSourceBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("문서 읽기 실패: %v", err)
+	}
+	if len(data) > maxSourceBytes {
+		return nil, fmt.Errorf("문서가 너무 큽니다: %s (최대 %dMB)", name, maxSourceBytes>>20)
+	}
+	return data, nil
+}
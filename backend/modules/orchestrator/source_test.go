@@ -0,0 +1,144 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withSourceSettings는 테스트 동안 SourceDir와 fetchGuard를 바꾸고 끝나면 되돌림
+func withSourceSettings(t *testing.T, dir string, guard func(context.Context, string) error) {
+	t.Helper()
+	prevDir, prevGuard := sourceDir, fetchGuard
+	sourceDir, fetchGuard = dir, guard
+	t.Cleanup(func() { sourceDir, fetchGuard = prevDir, prevGuard })
+}
+
+func TestReadSourceFileDisabledWithoutSourceDir(t *testing.T) {
+	withSourceSettings(t, "", nil)
+	secret := filepath.Join(t.TempDir(), "secret.txt")
+	os.WriteFile(secret, []byte("/secret"), 0644)
+
+	src := ListSource{document: document{location: secret}}
+	if _, err := src.Discover(context.Background(), "http://example.com"); err == nil {
+		t.Fatal("reading a server file without SOURCE_DIR succeeded")
+	}
+}
+
+func TestReadSourceFileStaysInSourceDir(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "sources")
+	os.Mkdir(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "paths.txt"), []byte("/a\nPOST /b\n"), 0644)
+	os.WriteFile(filepath.Join(base, "secret.txt"), []byte("/secret"), 0644)
+	if err := os.Symlink(filepath.Join(base, "secret.txt"), filepath.Join(dir, "link.txt")); err != nil {
+		t.Fatal(err)
+	}
+	withSourceSettings(t, dir, nil)
+
+	endpoints, err := ListSource{document: document{location: "paths.txt"}}.Discover(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(endpoints) != 2 || endpoints[1].Method != "POST" || endpoints[1].Path != "/b" {
+		t.Fatalf("endpoints = %+v", endpoints)
+	}
+
+	for _, name := range []string{
+		filepath.Join(base, "secret.txt"),
+		"../secret.txt",
+		"link.txt",
+	} {
+		if _, err := (document{location: name}).read(context.Background()); err == nil {
+			t.Errorf("read(%q) succeeded, want error", name)
+		}
+	}
+}
+
+func TestReadLimitedRejectsOversizedDocument(t *testing.T) {
+	if _, err := readLimited(strings.NewReader(strings.Repeat("x", maxSourceBytes)), "ok"); err != nil {
+		t.Fatalf("document at the limit rejected: %v", err)
+	}
+	if _, err := readLimited(strings.NewReader(strings.Repeat("x", maxSourceBytes+1)), "big"); err == nil {
+		t.Fatal("oversized document accepted")
+	}
+}
+
+func TestFetchGuardAppliesToRedirects(t *testing.T) {
+	var blockedHits int
+	blocked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		blockedHits++
+		w.Write([]byte("/internal"))
+	}))
+	defer blocked.Close()
+
+	allowed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, blocked.URL+"/", http.StatusFound)
+			return
+		}
+		w.Write([]byte("/public"))
+	}))
+	defer allowed.Close()
+
+	errDenied := errors.New("denied")
+	withSourceSettings(t, "", func(ctx context.Context, rawURL string) error {
+		if strings.HasPrefix(rawURL, allowed.URL) {
+			return nil
+		}
+		return errDenied
+	})
+
+	if data, err := (document{location: allowed.URL + "/paths"}).read(context.Background()); err != nil || string(data) != "/public" {
+		t.Fatalf("allowed fetch = %q, %v", data, err)
+	}
+	if _, err := (document{location: blocked.URL + "/"}).read(context.Background()); !errors.Is(err, errDenied) {
+		t.Errorf("direct fetch of blocked URL: err = %v, want guard error", err)
+	}
+	if _, err := (document{location: allowed.URL + "/redirect"}).read(context.Background()); !errors.Is(err, errDenied) {
+		t.Errorf("redirect to blocked URL: err = %v, want guard error", err)
+	}
+	if blockedHits != 0 {
+		t.Errorf("blocked server was requested %d times, want 0", blockedHits)
+	}
+}
+
+func TestSitemapSkipsChildrenOnOtherHosts(t *testing.T) {
+	var otherHits int
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		otherHits++
+		w.Write([]byte(`<urlset><url><loc>http://example.invalid/x</loc></url></urlset>`))
+	}))
+	defer other.Close()
+
+	var target *httptest.Server
+	target = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sitemap.xml":
+			fmt.Fprintf(w, `<sitemapindex><sitemap><loc>%s/pages.xml</loc></sitemap><sitemap><loc>%s/evil.xml</loc></sitemap></sitemapindex>`, target.URL, other.URL)
+		case "/pages.xml":
+			fmt.Fprintf(w, `<urlset><url><loc>%s/about</loc></url></urlset>`, target.URL)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer target.Close()
+	withSourceSettings(t, "", nil)
+
+	endpoints, err := SitemapSource{}.Discover(context.Background(), target.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(endpoints) != 1 || endpoints[0].Path != "/about" {
+		t.Errorf("endpoints = %+v, want only /about", endpoints)
+	}
+	if otherHits != 0 {
+		t.Errorf("sitemap on another host was requested %d times, want 0", otherHits)
+	}
+}
@@ -0,0 +1,98 @@
+package pathtemplate
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 경로 세그먼트 종류 판별 패턴
+var (
+	numberPattern = regexp.MustCompile(`^\d+$`)
+	uuidPattern   = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hashPattern   = regexp.MustCompile(`^[0-9a-fA-F]{16,}$`)                     // MD5, SHA, MongoDB ObjectID 등
+	datePattern   = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)                    // 2024-01-31
+	tokenPattern  = regexp.MustCompile(`^[A-Za-z0-9_]{24,}$`)                    // 긴 토큰형 식별자 (하이픈이 있는 slug는 제외)
+	paramPattern  = regexp.MustCompile(`^(\{[^}/]+\}|:[A-Za-z_][A-Za-z0-9_]*)$`) // 이미 템플릿인 세그먼트 ({id}, :id)
+)
+
+// Kind는 세그먼트가 식별자라면 파라미터 이름(id, uuid, hash, date, token)을, 아니면 빈 값을 반환
+func Kind(segment string) string {
+	switch {
+	case numberPattern.MatchString(segment):
+		return "id"
+	case uuidPattern.MatchString(segment):
+		return "uuid"
+	case datePattern.MatchString(segment):
+		return "date"
+	case hashPattern.MatchString(segment) && strings.ContainsAny(segment, "0123456789"):
+		return "hash"
+	case tokenPattern.MatchString(segment) && strings.ContainsAny(segment, "0123456789") && hasLetter(segment):
+		return "token"
+	}
+	return ""
+}
+
+// Normalize는 경로의 식별자 세그먼트를 파라미터로 바꾼 템플릿과 파라미터별 실제 값을 반환
+// 예: /api/users/123/orders/9f1c...e2 → /api/users/{id}/orders/{hash}, {id: 123, hash: 9f1c...e2}
+// 같은 종류가 여러 번 나오면 {id}, {id2} 처럼 번호를 붙이고, 이미 템플릿인 세그먼트는 그대로 둡니다 (그 이름은 건너뜀).
+func Normalize(path string) (string, map[string]string) {
+	segments := strings.Split(path, "/")
+	var values map[string]string
+	seen := make(map[string]int)
+
+	// 이미 템플릿인 파라미터 이름은 새 파라미터에 다시 쓰지 않음
+	taken := make(map[string]bool)
+	for _, seg := range segments {
+		if paramPattern.MatchString(seg) {
+			taken[strings.Trim(seg, "{}:")] = true
+		}
+	}
+
+	for i, seg := range segments {
+		if seg == "" || paramPattern.MatchString(seg) {
+			continue
+		}
+		kind := Kind(seg)
+		if kind == "" {
+			continue
+		}
+		var name string
+		for {
+			seen[kind]++
+			name = kind
+			if seen[kind] > 1 {
+				name += strconv.Itoa(seen[kind])
+			}
+			if !taken[name] {
+				break
+			}
+		}
+		if values == nil {
+			values = make(map[string]string)
+		}
+		values[name] = seg
+		segments[i] = "{" + name + "}"
+	}
+	return strings.Join(segments, "/"), values
+}
+
+// Expand는 템플릿의 {name} 파라미터를 values의 값으로 바꿈 (값이 없는 파라미터는 그대로 둠)
+func Expand(template string, values map[string]string) string {
+	if len(values) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+	for name, value := range values {
+		template = strings.ReplaceAll(template, "{"+name+"}", value)
+	}
+	return template
+}
+
+func hasLetter(s string) bool {
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return true
+		}
+	}
+	return false
+}
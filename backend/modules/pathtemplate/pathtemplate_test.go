@@ -0,0 +1,71 @@
+package pathtemplate
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestKind(t *testing.T) {
+	cases := map[string]string{
+		"123":                                  "id",
+		"550e8400-e29b-41d4-a716-446655440000": "uuid",
+		"2024-01-31":                           "date",
+		"507f1f77bcf86cd799439011":             "hash", // MongoDB ObjectID
+		"d41d8cd98f00b204e9800998ecf8427e":     "hash", // MD5
+		"aB3dE5fG7hJ9kL1mN2pQ4rS6":             "token",
+		"users":                                "",
+		"v2":                                   "",
+		"my-long-article-slug-2024-edition":    "", // 하이픈이 있는 slug는 식별자가 아님
+		"deadbeefdeadbeefdeadbeef":             "", // 숫자가 없는 16진수는 단어일 수 있음
+		"abcdefghijklmnopqrstuvwxyz":           "", // 숫자가 없는 긴 단어
+		"1234567890123456789012345":            "id",
+	}
+	for segment, want := range cases {
+		if got := Kind(segment); got != want {
+			t.Errorf("Kind(%q) = %q, want %q", segment, got, want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		path     string
+		template string
+		values   map[string]string
+	}{
+		{"/api/users", "/api/users", nil},
+		{"/api/users/123", "/api/users/{id}", map[string]string{"id": "123"}},
+		{"/users/1/orders/42", "/users/{id}/orders/{id2}", map[string]string{"id": "1", "id2": "42"}},
+		{"/a/1/b/2/c/3", "/a/{id}/b/{id2}/c/{id3}", map[string]string{"id": "1", "id2": "2", "id3": "3"}},
+		{
+			"/files/550e8400-e29b-41d4-a716-446655440000/v/7",
+			"/files/{uuid}/v/{id}",
+			map[string]string{"uuid": "550e8400-e29b-41d4-a716-446655440000", "id": "7"},
+		},
+		{"/reports/2024-01-31/", "/reports/{date}/", map[string]string{"date": "2024-01-31"}},
+		// 이미 템플릿인 세그먼트는 그대로 두고, 새 파라미터는 그 이름을 피해 번호를 붙임
+		{"/users/{id}/orders/99", "/users/{id}/orders/{id2}", map[string]string{"id2": "99"}},
+		{"/users/{id}", "/users/{id}", nil},
+		{"/users/:userId/posts/5", "/users/:userId/posts/{id}", map[string]string{"id": "5"}},
+	}
+	for _, c := range cases {
+		template, values := Normalize(c.path)
+		if template != c.template || !maps.Equal(values, c.values) {
+			t.Errorf("Normalize(%q) = %q, %v; want %q, %v", c.path, template, values, c.template, c.values)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	got := Expand("/users/{id}/orders/{id2}", map[string]string{"id": "1", "id2": "42"})
+	if got != "/users/1/orders/42" {
+		t.Errorf("Expand = %q", got)
+	}
+	// 값이 없는 파라미터는 그대로 남김
+	if got := Expand("/users/{id}/orders/{id2}", map[string]string{"id": "1"}); got != "/users/1/orders/{id2}" {
+		t.Errorf("Expand with a missing value = %q", got)
+	}
+	if got := Expand("/users/{id}", nil); got != "/users/{id}" {
+		t.Errorf("Expand without values = %q", got)
+	}
+}
@@ -0,0 +1,257 @@
+// Package queue는 서버에서 동시에 실행하는 테스트 수와 전체 RPS를 제한하고 초과분을 대기열로 관리
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Options는 대기열 설정
+type Options struct {
+	MaxConcurrent int  // 동시에 실행할 최대 테스트 수 (0이면 제한 없음)
+	MaxRPS        int  // 실행 중인 테스트 RPS 합계 한도 (0이면 제한 없음)
+	MaxWaiting    int  // 대기열 최대 길이 (0이면 제한 없음)
+	Reject        bool // true면 대기하지 않고 바로 거부 (API는 429)
+}
+
+// Job은 실행을 기다리거나 실행 중인 테스트
+type Job struct {
+	ID         string     `json:"id"`                  // 실행 ID
+	Owner      string     `json:"owner,omitempty"`     // 실행을 시작한 API 키 이름
+	Target     string     `json:"target"`              // 대상 URL
+	RPS        int        `json:"rps"`                 // 차지하는 RPS
+	Priority   int        `json:"priority"`            // 우선순위 (클수록 먼저)
+	State      string     `json:"state"`               // queued 또는 running
+	Position   int        `json:"position,omitempty"`  // 대기 순서 (1부터, 실행 중이면 0)
+	EnqueuedAt time.Time  `json:"enqueuedAt"`          // 대기열에 들어온 시각
+	StartedAt  *time.Time `json:"startedAt,omitempty"` // 실행 시작 시각
+}
+
+// 작업 상태
+const (
+	StateQueued  = "queued"
+	StateRunning = "running"
+)
+
+// Status는 대기열 전체 상태
+type Status struct {
+	MaxConcurrent int   `json:"maxConcurrent"`
+	MaxRPS        int   `json:"maxRps"`
+	UsedRPS       int   `json:"usedRps"` // 실행 중인 테스트 RPS 합계
+	Running       []Job `json:"running"`
+	Waiting       []Job `json:"waiting"` // 실행 순서대로
+}
+
+// BusyError는 Reject 모드에서 바로 실행할 수 없어 거부된 경우 (API는 429)
+type BusyError struct {
+	Running int
+	Waiting int
+}
+
+func (e *BusyError) Error() string {
+	return fmt.Sprintf("서버가 바쁩니다 (실행 중 %d개, 대기 %d개). 잠시 후 다시 시도하세요", e.Running, e.Waiting)
+}
+
+// LimitError는 테스트 하나가 서버 전체 한도를 넘어 실행할 수 없는 경우 (API는 400)
+type LimitError struct {
+	Reason string
+}
+
+func (e *LimitError) Error() string {
+	return e.Reason
+}
+
+// ErrClosed는 대기열이 닫혀(서버 종료) 더 이상 실행하지 않을 때 반환되는 오류 (API는 503)
+var ErrClosed = errors.New("서버가 종료 중이라 대기 중인 테스트를 실행하지 않습니다")
+
+type entry struct {
+	Job
+	seq   uint64
+	ready chan struct{}
+	err   error // 실행 대신 대기가 끝난 이유 (대기열 닫힘)
+}
+
+// Queue는 우선순위가 있는 FIFO 실행 대기열
+// 우선순위가 높은 작업이 먼저, 같으면 먼저 들어온 작업이 먼저 실행됩니다.
+// 맨 앞 작업이 들어갈 자리가 없으면 뒤의 작은 작업도 기다립니다 (순서 보장).
+type Queue struct {
+	opts Options
+
+	mu      sync.Mutex
+	seq     uint64
+	used    int
+	running map[*entry]bool
+	waiting []*entry
+	closed  bool
+}
+
+// New는 대기열을 생성
+func New(opts Options) *Queue {
+	return &Queue{opts: opts, running: make(map[*entry]bool)}
+}
+
+// Acquire는 실행 자리가 날 때까지 기다렸다가 작업을 실행 상태로 만듦
+// 기다리는 동안 ctx가 취소되면(클라이언트 연결 종료 등) 작업을 대기열에서 빼고 ctx.Err()를 반환합니다.
+// 반환된 release는 실행이 끝나면 반드시 호출해야 합니다.
+func (q *Queue) Acquire(ctx context.Context, job Job) (release func(), err error) {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil, ErrClosed
+	}
+	if q.opts.MaxRPS > 0 && job.RPS > q.opts.MaxRPS {
+		q.mu.Unlock()
+		return nil, &LimitError{Reason: fmt.Sprintf("요청한 RPS %d가 서버 전체 한도 %d를 넘습니다", job.RPS, q.opts.MaxRPS)}
+	}
+	if q.opts.Reject && (len(q.waiting) > 0 || !q.fits(job.RPS)) {
+		err := &BusyError{Running: len(q.running), Waiting: len(q.waiting)}
+		q.mu.Unlock()
+		return nil, err
+	}
+	if q.opts.MaxWaiting > 0 && len(q.waiting) >= q.opts.MaxWaiting {
+		err := &BusyError{Running: len(q.running), Waiting: len(q.waiting)}
+		q.mu.Unlock()
+		return nil, err
+	}
+
+	q.seq++
+	e := &entry{Job: job, seq: q.seq, ready: make(chan struct{})}
+	e.State = StateQueued
+	e.EnqueuedAt = time.Now()
+	q.waiting = append(q.waiting, e)
+	sort.SliceStable(q.waiting, func(i, j int) bool {
+		if q.waiting[i].Priority != q.waiting[j].Priority {
+			return q.waiting[i].Priority > q.waiting[j].Priority
+		}
+		return q.waiting[i].seq < q.waiting[j].seq
+	})
+	q.dispatch()
+	q.mu.Unlock()
+
+	select {
+	case <-e.ready:
+	case <-ctx.Done():
+		if q.cancel(e) {
+			return nil, ctx.Err()
+		}
+		// 취소와 동시에 실행 상태가 되었으면 자리를 바로 반환
+		if e.err == nil {
+			q.release(e)
+		}
+		return nil, ctx.Err()
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { q.release(e) })
+	}, nil
+}
+
+// cancel은 아직 대기 중인 작업을 대기열에서 뺌 (이미 실행 상태가 되었거나 대기열이 닫혔으면 false)
+func (q *Queue) cancel(e *entry) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, w := range q.waiting {
+		if w == e {
+			q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+			// 맨 앞 작업이 빠지면 뒤의 작업이 들어갈 수 있음
+			q.dispatch()
+			return true
+		}
+	}
+	return false
+}
+
+// release는 실행 중인 작업의 자리를 반환하고 다음 작업을 실행
+func (q *Queue) release(e *entry) {
+	q.mu.Lock()
+	delete(q.running, e)
+	q.used -= e.RPS
+	q.dispatch()
+	q.mu.Unlock()
+}
+
+// Close는 새 작업을 거부하고 대기 중인 작업을 모두 ErrClosed로 끝냄 (실행 중인 작업은 유지)
+func (q *Queue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	for _, e := range q.waiting {
+		e.err = ErrClosed
+		close(e.ready)
+	}
+	q.waiting = nil
+}
+
+// fits는 지금 rps만큼의 작업을 실행할 수 있는지 반환 (잠금 상태에서 호출)
+func (q *Queue) fits(rps int) bool {
+	if q.opts.MaxConcurrent > 0 && len(q.running) >= q.opts.MaxConcurrent {
+		return false
+	}
+	return q.opts.MaxRPS <= 0 || q.used+rps <= q.opts.MaxRPS
+}
+
+// dispatch는 맨 앞부터 실행할 수 있는 작업을 실행 상태로 옮김 (잠금 상태에서 호출)
+func (q *Queue) dispatch() {
+	for len(q.waiting) > 0 && q.fits(q.waiting[0].RPS) {
+		e := q.waiting[0]
+		q.waiting = q.waiting[1:]
+		e.State = StateRunning
+		now := time.Now()
+		e.StartedAt = &now
+		q.running[e] = true
+		q.used += e.RPS
+		close(e.ready)
+	}
+}
+
+// Status는 대기열 전체 상태를 반환
+func (q *Queue) Status() Status {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	status := Status{
+		MaxConcurrent: q.opts.MaxConcurrent,
+		MaxRPS:        q.opts.MaxRPS,
+		UsedRPS:       q.used,
+		Running:       make([]Job, 0, len(q.running)),
+		Waiting:       make([]Job, 0, len(q.waiting)),
+	}
+	for e := range q.running {
+		status.Running = append(status.Running, e.Job)
+	}
+	sort.Slice(status.Running, func(i, j int) bool { return status.Running[i].StartedAt.Before(*status.Running[j].StartedAt) })
+	for i, e := range q.waiting {
+		job := e.Job
+		job.Position = i + 1
+		status.Waiting = append(status.Waiting, job)
+	}
+	return status
+}
+
+// Find는 ID로 실행 중이거나 대기 중인 작업을 찾음 (대기 중이면 Position 포함)
+func (q *Queue) Find(id string) (Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for e := range q.running {
+		if e.ID == id {
+			return e.Job, true
+		}
+	}
+	for i, e := range q.waiting {
+		if e.ID == id {
+			job := e.Job
+			job.Position = i + 1
+			return job, true
+		}
+	}
+	return Job{}, false
+}
@@ -0,0 +1,194 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// acquireAsync는 Acquire를 고루틴에서 호출하고 결과를 채널로 전달
+func acquireAsync(ctx context.Context, q *Queue, job Job) <-chan func() {
+	done := make(chan func(), 1)
+	go func() {
+		release, err := q.Acquire(ctx, job)
+		if err != nil {
+			release = nil
+		}
+		done <- release
+	}()
+	return done
+}
+
+// waitQueued는 id 작업이 대기열에 들어갈 때까지 기다림
+func waitQueued(t *testing.T, q *Queue, id string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if job, ok := q.Find(id); ok && job.State == StateQueued {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%s did not enter the queue", id)
+}
+
+func TestAcquireRunsByPriorityThenFIFO(t *testing.T) {
+	q := New(Options{MaxConcurrent: 1})
+	ctx := context.Background()
+
+	first, err := q.Acquire(ctx, Job{ID: "first"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	low := acquireAsync(ctx, q, Job{ID: "low"})
+	waitQueued(t, q, "low")
+	high := acquireAsync(ctx, q, Job{ID: "high", Priority: 5})
+	waitQueued(t, q, "high")
+
+	status := q.Status()
+	if len(status.Waiting) != 2 || status.Waiting[0].ID != "high" || status.Waiting[0].Position != 1 {
+		t.Fatalf("waiting = %+v, want high first", status.Waiting)
+	}
+
+	first()
+	release := <-high
+	if release == nil {
+		t.Fatal("high priority job was not admitted")
+	}
+	if job, _ := q.Find("low"); job.State != StateQueued {
+		t.Errorf("low = %+v, want still queued", job)
+	}
+	release()
+	if release := <-low; release == nil {
+		t.Fatal("low priority job was not admitted")
+	} else {
+		release()
+	}
+}
+
+func TestAcquireCancelRemovesWaitingJob(t *testing.T) {
+	q := New(Options{MaxConcurrent: 1})
+	running, err := q.Acquire(context.Background(), Job{ID: "running"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := q.Acquire(ctx, Job{ID: "gone"})
+		errc <- err
+	}()
+	waitQueued(t, q, "gone")
+	next := acquireAsync(context.Background(), q, Job{ID: "next"})
+	waitQueued(t, q, "next")
+
+	// 연결이 끊긴 클라이언트의 작업은 대기열에서 빠지고 실행되지 않음
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Acquire after cancel = %v, want context.Canceled", err)
+	}
+	if _, ok := q.Find("gone"); ok {
+		t.Error("canceled job is still in the queue")
+	}
+	if job, _ := q.Find("next"); job.Position != 1 {
+		t.Errorf("next position = %d, want 1", job.Position)
+	}
+
+	running()
+	release := <-next
+	if release == nil {
+		t.Fatal("next job was not admitted after the canceled one left")
+	}
+	release()
+	if status := q.Status(); len(status.Running) != 0 || status.UsedRPS != 0 {
+		t.Errorf("status after release = %+v", status)
+	}
+}
+
+func TestAcquireRPSLimit(t *testing.T) {
+	q := New(Options{MaxRPS: 100})
+	ctx := context.Background()
+
+	var limit *LimitError
+	if _, err := q.Acquire(ctx, Job{ID: "huge", RPS: 101}); !errors.As(err, &limit) {
+		t.Fatalf("Acquire over server limit = %v, want LimitError", err)
+	}
+
+	a, err := q.Acquire(ctx, Job{ID: "a", RPS: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 맨 앞 작업이 들어갈 자리가 없으면 뒤의 작은 작업도 기다림
+	big := acquireAsync(ctx, q, Job{ID: "big", RPS: 50})
+	waitQueued(t, q, "big")
+	small := acquireAsync(ctx, q, Job{ID: "small", RPS: 10})
+	waitQueued(t, q, "small")
+
+	a()
+	for _, done := range []<-chan func(){big, small} {
+		release := <-done
+		if release == nil {
+			t.Fatal("job was not admitted")
+		}
+		defer release()
+	}
+	if used := q.Status().UsedRPS; used != 60 {
+		t.Errorf("used RPS = %d, want 60", used)
+	}
+}
+
+func TestRejectAndMaxWaiting(t *testing.T) {
+	ctx := context.Background()
+	var busy *BusyError
+
+	reject := New(Options{MaxConcurrent: 1, Reject: true})
+	release, err := reject.Acquire(ctx, Job{ID: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reject.Acquire(ctx, Job{ID: "b"}); !errors.As(err, &busy) {
+		t.Errorf("Reject mode Acquire = %v, want BusyError", err)
+	}
+	release()
+
+	limited := New(Options{MaxConcurrent: 1, MaxWaiting: 1})
+	release, err = limited.Acquire(ctx, Job{ID: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	waiting, cancel := context.WithCancel(ctx)
+	defer cancel()
+	acquireAsync(waiting, limited, Job{ID: "b"})
+	waitQueued(t, limited, "b")
+	if _, err := limited.Acquire(ctx, Job{ID: "c"}); !errors.As(err, &busy) {
+		t.Errorf("full queue Acquire = %v, want BusyError", err)
+	}
+}
+
+func TestCloseEndsWaitingJobs(t *testing.T) {
+	q := New(Options{MaxConcurrent: 1})
+	ctx := context.Background()
+	release, err := q.Acquire(ctx, Job{ID: "running"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := q.Acquire(ctx, Job{ID: "waiting"})
+		errc <- err
+	}()
+	waitQueued(t, q, "waiting")
+
+	q.Close()
+	if err := <-errc; !errors.Is(err, ErrClosed) {
+		t.Errorf("waiting Acquire after Close = %v, want ErrClosed", err)
+	}
+	if _, err := q.Acquire(ctx, Job{ID: "new"}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Acquire after Close = %v, want ErrClosed", err)
+	}
+}
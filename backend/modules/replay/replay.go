@@ -0,0 +1,134 @@
+package replay
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/crawler"
+	"github.com/Mr-Muji/LoadTest/backend/modules/pathtemplate"
+)
+
+// DefaultMaxEvents는 재생에 사용하는 최대 요청 수 (실행 기록 크기 제한)
+const DefaultMaxEvents = 100000
+
+// Event는 기록된 요청 하나와 기록 시각
+type Event struct {
+	Time    time.Time          // 원래 요청 시각
+	Status  int                // 원래 응답 코드 (알 수 없으면 0)
+	Request config.RequestSpec // 다시 보낼 요청 (Path는 쿼리 포함)
+}
+
+// Options는 기록을 부하 테스트 요청으로 바꿀 때의 설정
+type Options struct {
+	IncludeStatic bool // 정적 리소스(이미지, css, js 등) 요청 포함 여부
+	MaxEvents     int  // timed 재생의 최대 요청 수, mix의 최대 요청 종류 수 (0이면 DefaultMaxEvents)
+}
+
+// maxEvents는 적용할 최대 개수
+func (o Options) maxEvents() int {
+	if o.MaxEvents <= 0 {
+		return DefaultMaxEvents
+	}
+	return o.MaxEvents
+}
+
+// filter는 정적 리소스를 제외하고 시각 순으로 정렬
+func filter(events []Event, opts Options) []Event {
+	kept := events[:0]
+	for _, e := range events {
+		path, _, _ := strings.Cut(e.Request.Path, "?")
+		if !opts.IncludeStatic && crawler.IsStaticPath(path) {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].Time.Before(kept[j].Time) })
+	return kept
+}
+
+// Schedule은 기록을 원래 상대 시각 그대로 재생하는 요청 목록으로 변환
+func Schedule(events []Event, opts Options) []config.ScheduledRequest {
+	events = filter(events, opts)
+	if len(events) == 0 {
+		return nil
+	}
+	if limit := opts.maxEvents(); len(events) > limit {
+		events = events[:limit]
+	}
+	start := events[0].Time
+	schedule := make([]config.ScheduledRequest, 0, len(events))
+	for _, e := range events {
+		spec := e.Request
+		spec.ExpectedStatus = expected(e.Status)
+		schedule = append(schedule, config.ScheduledRequest{
+			OffsetMs:    float64(e.Time.Sub(start).Microseconds()) / 1000,
+			RequestSpec: spec,
+		})
+	}
+	return schedule
+}
+
+// Mix는 기록의 요청 비율을 가중치로 하는 요청 목록으로 변환 (요청 수가 많은 순)
+// 같은 메서드+경로(쿼리 포함)는 하나로 묶고, 본문/헤더는 처음 기록된 것을 사용합니다.
+// 비율은 기록 전체로 세고, 요청 종류가 MaxEvents보다 많으면 요청 수가 많은 것부터 남깁니다.
+func Mix(events []Event, opts Options) []config.RequestSpec {
+	events = filter(events, opts)
+	index := make(map[string]int)
+	var specs []config.RequestSpec
+	for _, e := range events {
+		key := e.Request.Method + " " + e.Request.Path
+		if i, ok := index[key]; ok {
+			specs[i].Weight++
+			continue
+		}
+		spec := e.Request
+		spec.Weight = 1
+		spec.ExpectedStatus = expected(e.Status)
+		index[key] = len(specs)
+		specs = append(specs, spec)
+	}
+	sort.SliceStable(specs, func(i, j int) bool { return specs[i].Weight > specs[j].Weight })
+	if limit := opts.maxEvents(); len(specs) > limit {
+		specs = specs[:limit]
+	}
+	return specs
+}
+
+// Span은 기록의 첫 요청부터 마지막 요청까지의 시간
+func Span(schedule []config.ScheduledRequest) time.Duration {
+	if len(schedule) == 0 {
+		return 0
+	}
+	return time.Duration(schedule[len(schedule)-1].OffsetMs * float64(time.Millisecond))
+}
+
+// expected는 기록된 응답 코드로 기대 응답 코드를 정함
+// 원래 성공(4xx 미만)한 요청은 200과 원래 코드를 모두 성공으로 보고, 그 외는 기본값(200)을 사용합니다.
+func expected(status int) []int {
+	if status <= 0 || status >= 400 || status == 200 {
+		return nil
+	}
+	return []int{200, status}
+}
+
+// pathWithQuery는 URL의 경로와 쿼리 부분
+func pathWithQuery(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return path
+}
+
+// templateName은 통계 이름을 경로 템플릿으로 묶음 (예: GET /users/{id})
+// 식별자마다 통계가 따로 쌓여 결과가 비대해지는 것을 막습니다.
+func templateName(method, path string) string {
+	template, _ := pathtemplate.Normalize(path)
+	return method + " " + template
+}
@@ -0,0 +1,173 @@
+package replay
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/har"
+)
+
+const accessLog = `10.0.0.1 - - [10/Oct/2024:13:55:36 +0900] "GET /api/items?page=2 HTTP/1.1" 200 2326 "-" "Mozilla/5.0"
+10.0.0.2 - - [10/Oct/2024:13:55:36 +0900] "POST /api/items/42 HTTP/1.1" 201 10 "-" "curl/8.0"
+
+garbage line
+10.0.0.3 - - [10/Oct/2024:13:55:36 +0900] "GET http://proxy.example.com/api/items/43?x=1 HTTP/1.1" 404 0
+10.0.0.1 - - [10/Oct/2024:13:55:37 +0900] "GET /static/app.js HTTP/1.1" 200 100 "-" "-"
+10.0.0.1 - - [yesterday] "GET / HTTP/1.1" 200 1
+`
+
+func TestParseAccessLog(t *testing.T) {
+	events, skipped, err := ParseAccessLog(strings.NewReader(accessLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 2 {
+		t.Errorf("skipped = %d, want 2 (unparsable line and bad time)", skipped)
+	}
+
+	base := time.Date(2024, 10, 10, 13, 55, 36, 0, time.FixedZone("", 9*3600))
+	want := []Event{
+		{Time: base, Status: 200, Request: config.RequestSpec{Name: "GET /api/items", Method: "GET", Path: "/api/items?page=2", Headers: map[string]string{"User-Agent": "Mozilla/5.0"}}},
+		{Time: base.Add(time.Second / 3), Status: 201, Request: config.RequestSpec{Name: "POST /api/items/{id}", Method: "POST", Path: "/api/items/42", Headers: map[string]string{"User-Agent": "curl/8.0"}}},
+		// 프록시 로그의 절대 URL은 경로와 쿼리만 사용
+		{Time: base.Add(2 * time.Second / 3), Status: 404, Request: config.RequestSpec{Name: "GET /api/items/{id}", Method: "GET", Path: "/api/items/43?x=1"}},
+		{Time: base.Add(time.Second), Status: 200, Request: config.RequestSpec{Name: "GET /static/app.js", Method: "GET", Path: "/static/app.js"}},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v, want %d", events, len(want))
+	}
+	for i := range want {
+		if !events[i].Time.Equal(want[i].Time) || events[i].Status != want[i].Status || !reflect.DeepEqual(events[i].Request, want[i].Request) {
+			t.Errorf("event %d = %+v, want %+v", i, events[i], want[i])
+		}
+	}
+
+	if _, skipped, err := ParseAccessLog(strings.NewReader("garbage\n\n")); err == nil || skipped != 1 {
+		t.Errorf("ParseAccessLog(garbage) = %d skipped, %v, want error", skipped, err)
+	}
+}
+
+func TestSpreadWithinSecond(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Second)
+	events := []Event{{Time: t1}, {Time: t0}, {Time: t0}, {Time: t1}, {Time: t0}, {Time: t0}}
+	spreadWithinSecond(events)
+
+	offsets := []time.Duration{0, 250 * time.Millisecond, 500 * time.Millisecond, 750 * time.Millisecond, time.Second, 1500 * time.Millisecond}
+	for i, want := range offsets {
+		if got := events[i].Time.Sub(t0); got != want {
+			t.Errorf("event %d at +%s, want +%s", i, got, want)
+		}
+	}
+}
+
+func TestFromHAR(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(at time.Duration, method, rawURL string, status int) har.Entry {
+		e := har.Entry{StartedDateTime: t0.Add(at), Request: har.Request{Method: method, URL: rawURL}}
+		e.Response.Status = status
+		return e
+	}
+	get := entry(0, "get", "https://example.com/api/users/7?expand=1", 200)
+	get.Request.Headers = []har.NameValue{
+		{Name: ":authority", Value: "example.com"},
+		{Name: "Cookie", Value: "session=secret"},
+		{Name: "Authorization", Value: "Bearer secret"},
+		{Name: "Accept", Value: "application/json"},
+	}
+	post := entry(time.Second, "POST", "https://example.com/api/users", 201)
+	post.Request.PostData = &har.PostData{MimeType: "application/json", Text: `{"name":"a"}`}
+	script := entry(2*time.Second, "GET", "https://example.com/app.js", 200)
+	script.ResourceType = "script"
+	other := entry(3*time.Second, "GET", "https://other.example.org/api", 200)
+
+	h := &har.HAR{Log: har.Log{Entries: []har.Entry{get, post, script, other}}}
+	got := FromHAR(h, "example.com")
+	want := []Event{
+		{Time: t0, Status: 200, Request: config.RequestSpec{Name: "GET /api/users/{id}", Method: "GET", Path: "/api/users/7?expand=1", Headers: map[string]string{"Accept": "application/json"}}},
+		{Time: t0.Add(time.Second), Status: 201, Request: config.RequestSpec{Name: "POST /api/users", Method: "POST", Path: "/api/users", Body: `{"name":"a"}`}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromHAR =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if all := FromHAR(h, ""); len(all) != 3 {
+		t.Errorf("FromHAR(\"\") = %d events, want 3 (static excluded)", len(all))
+	}
+}
+
+// events는 paths를 1초 간격으로 기록한 이벤트 목록 (상태 코드는 200)
+func events(paths ...string) []Event {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	list := make([]Event, len(paths))
+	for i, p := range paths {
+		list[i] = Event{Time: t0.Add(time.Duration(i) * time.Second), Status: 200, Request: config.RequestSpec{Method: "GET", Path: p}}
+	}
+	return list
+}
+
+func TestSchedule(t *testing.T) {
+	log, _, err := ParseAccessLog(strings.NewReader(accessLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schedule := Schedule(log, Options{})
+	if len(schedule) != 3 {
+		t.Fatalf("schedule = %+v, want 3 requests (static excluded)", schedule)
+	}
+	for i, want := range []float64{0, 333.333, 666.666} {
+		if schedule[i].OffsetMs != want {
+			t.Errorf("request %d offset = %v ms, want %v", i, schedule[i].OffsetMs, want)
+		}
+	}
+	// 200이 아닌 성공 응답은 200과 원래 코드를 모두 기대, 실패한 요청은 기본값
+	if got := schedule[1].ExpectedStatus; !reflect.DeepEqual(got, []int{200, 201}) {
+		t.Errorf("expected status of 201 = %v", got)
+	}
+	if got := schedule[2].ExpectedStatus; got != nil {
+		t.Errorf("expected status of 404 = %v, want default", got)
+	}
+
+	limited := Schedule(events("/a", "/b", "/c", "/d.png"), Options{IncludeStatic: true, MaxEvents: 2})
+	if len(limited) != 2 || limited[1].Path != "/b" || Span(limited) != time.Second {
+		t.Errorf("Schedule(MaxEvents: 2) = %+v", limited)
+	}
+	if Schedule(events("/logo.png"), Options{}) != nil {
+		t.Error("Schedule of only static requests is not empty")
+	}
+}
+
+func TestMixCountsWholeLog(t *testing.T) {
+	// 앞부분에는 /a만 있지만 기록 전체로는 /b가 가장 많음
+	log := events("/a", "/a", "/b", "/b", "/b", "/b", "/c", "/style.css")
+
+	got := Mix(log, Options{MaxEvents: 2})
+	want := []config.RequestSpec{
+		{Method: "GET", Path: "/b", Weight: 4},
+		{Method: "GET", Path: "/a", Weight: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Mix(MaxEvents: 2) = %+v, want %+v", got, want)
+	}
+
+	all := Mix(log, Options{})
+	if len(all) != 3 || all[2].Path != "/c" || all[2].Weight != 1 {
+		t.Errorf("Mix = %+v", all)
+	}
+}
+
+func TestMixKeepsFirstRequest(t *testing.T) {
+	log := events("/items", "/items")
+	log[0].Status = 201
+	log[0].Request.Body = "first"
+	log[1].Request.Body = "second"
+
+	got := Mix(log, Options{})
+	if len(got) != 1 || got[0].Body != "first" || got[0].Weight != 2 || !reflect.DeepEqual(got[0].ExpectedStatus, []int{200, 201}) {
+		t.Errorf("Mix = %+v", got)
+	}
+}
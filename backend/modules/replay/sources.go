@@ -0,0 +1,124 @@
+package replay
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/har"
+)
+
+// 재생 시 다시 보내지 않는 헤더 (민감 정보 또는 클라이언트가 자동으로 붙이는 헤더)
+var skippedHeaders = map[string]bool{
+	"cookie": true, "authorization": true, "proxy-authorization": true,
+	"host": true, "content-length": true, "connection": true, "accept-encoding": true,
+}
+
+// FromHAR은 HAR의 요청을 재생 이벤트로 변환 (host가 빈 값이 아니면 해당 호스트 요청만)
+func FromHAR(h *har.HAR, host string) []Event {
+	var events []Event
+	for _, e := range h.Log.Entries {
+		u, err := url.Parse(e.Request.URL)
+		if err != nil || e.IsStatic() || (host != "" && u.Host != host) {
+			continue
+		}
+		spec := config.RequestSpec{
+			Method: strings.ToUpper(e.Request.Method),
+			Path:   pathWithQuery(u),
+		}
+		spec.Name = templateName(spec.Method, u.Path)
+		for _, hdr := range e.Request.Headers {
+			name := strings.ToLower(hdr.Name)
+			if skippedHeaders[name] || strings.HasPrefix(name, ":") {
+				continue
+			}
+			if spec.Headers == nil {
+				spec.Headers = make(map[string]string)
+			}
+			spec.Headers[hdr.Name] = hdr.Value
+		}
+		if pd := e.Request.PostData; pd != nil {
+			spec.Body = pd.Text
+		}
+		events = append(events, Event{Time: e.StartedDateTime, Status: e.Response.Status, Request: spec})
+	}
+	return events
+}
+
+// combinedLog는 nginx/Apache combined(또는 common) 로그 한 줄
+// 예: 127.0.0.1 - - [10/Oct/2024:13:55:36 +0900] "GET /api/items?page=2 HTTP/1.1" 200 2326 "-" "Mozilla/5.0"
+var combinedLog = regexp.MustCompile(`^\S+ \S+ \S+ \[([^\]]+)\] "([A-Z]+) (\S+)[^"]*" (\d{3}) \S+(?: "[^"]*" "([^"]*)")?`)
+
+// accessLogTimeLayout은 access log 시각 형식
+const accessLogTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// ParseAccessLog는 combined/common 형식의 access log를 재생 이벤트로 변환
+// 형식이 맞지 않는 줄은 건너뛰고 그 수를 함께 반환합니다.
+func ParseAccessLog(r io.Reader) ([]Event, int, error) {
+	var events []Event
+	skipped := 0
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		m := combinedLog.FindStringSubmatch(line)
+		if m == nil {
+			skipped++
+			continue
+		}
+		at, err := time.Parse(accessLogTimeLayout, m[1])
+		if err != nil {
+			skipped++
+			continue
+		}
+		status, _ := strconv.Atoi(m[4])
+
+		target := m[3]
+		// 프록시 로그의 절대 URL은 경로만 사용
+		if u, err := url.Parse(target); err == nil && u.IsAbs() {
+			target = pathWithQuery(u)
+		}
+		spec := config.RequestSpec{Method: m[2], Path: target}
+		spec.Name = templateName(spec.Method, strings.SplitN(target, "?", 2)[0])
+		if ua := m[5]; ua != "" && ua != "-" {
+			spec.Headers = map[string]string{"User-Agent": ua}
+		}
+		events = append(events, Event{Time: at, Status: status, Request: spec})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, skipped, fmt.Errorf("access log 읽기 실패: %v", err)
+	}
+	if len(events) == 0 {
+		return nil, skipped, fmt.Errorf("해석할 수 있는 access log 줄이 없습니다")
+	}
+	spreadWithinSecond(events)
+	return events, skipped, nil
+}
+
+// spreadWithinSecond는 같은 초에 기록된 요청들을 그 1초 안에 고르게 분산
+// access log는 초 단위라 그대로 재생하면 매초 시작 시점에 요청이 몰립니다.
+func spreadWithinSecond(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	for start := 0; start < len(events); {
+		end := start
+		for end < len(events) && events[end].Time.Equal(events[start].Time) {
+			end++
+		}
+		n := end - start
+		for i := start; i < end; i++ {
+			events[i].Time = events[i].Time.Add(time.Duration(i-start) * time.Second / time.Duration(n))
+		}
+		start = end
+	}
+}
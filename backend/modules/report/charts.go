@@ -0,0 +1,159 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strings"
+)
+
+// 차트 크기 (SVG 좌표계)
+const (
+	chartWidth    = 760
+	chartHeight   = 240
+	chartPadLeft  = 56
+	chartPadBot   = 28
+	chartPadTop   = 12
+	chartPadRight = 12
+)
+
+// series는 선형 차트에 그릴 데이터 계열 하나
+type series struct {
+	Name   string
+	Color  string
+	Values []float64
+}
+
+// niceMax는 축 최대값을 보기 좋은 값으로 올림
+func niceMax(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	exp := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if v <= m*exp {
+			return m * exp
+		}
+	}
+	return 10 * exp
+}
+
+// axes는 Y축 눈금과 격자선을 그림
+func axes(b *strings.Builder, yMax float64, unit string) {
+	plotH := float64(chartHeight - chartPadTop - chartPadBot)
+	for i := 0; i <= 4; i++ {
+		v := yMax * float64(i) / 4
+		y := float64(chartHeight-chartPadBot) - plotH*float64(i)/4
+		fmt.Fprintf(b, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" class="grid"/>`, chartPadLeft, y, chartWidth-chartPadRight, y)
+		fmt.Fprintf(b, `<text x="%d" y="%.1f" class="axis" text-anchor="end">%s%s</text>`, chartPadLeft-6, y+4, formatNumber(v), unit)
+	}
+}
+
+// lineChart는 시계열 데이터를 SVG 선형 차트로 렌더링
+func lineChart(xLabel string, unit string, data []series) template.HTML {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg viewBox="0 0 %d %d" class="chart" role="img">`, chartWidth, chartHeight)
+
+	points := 0
+	yMax := 0.0
+	for _, s := range data {
+		if len(s.Values) > points {
+			points = len(s.Values)
+		}
+		for _, v := range s.Values {
+			yMax = math.Max(yMax, v)
+		}
+	}
+	yMax = niceMax(yMax)
+	axes(&b, yMax, unit)
+
+	plotW := float64(chartWidth - chartPadLeft - chartPadRight)
+	plotH := float64(chartHeight - chartPadTop - chartPadBot)
+	x := func(i int) float64 {
+		if points <= 1 {
+			return float64(chartPadLeft)
+		}
+		return float64(chartPadLeft) + plotW*float64(i)/float64(points-1)
+	}
+	y := func(v float64) float64 {
+		return float64(chartHeight-chartPadBot) - plotH*v/yMax
+	}
+
+	for _, s := range data {
+		coords := make([]string, 0, len(s.Values))
+		for i, v := range s.Values {
+			coords = append(coords, fmt.Sprintf("%.1f,%.1f", x(i), y(v)))
+		}
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2" points="%s"><title>%s</title></polyline>`,
+			s.Color, strings.Join(coords, " "), template.HTMLEscapeString(s.Name))
+	}
+
+	// X축 라벨 (시작, 중간, 끝)
+	if points > 0 {
+		for _, i := range []int{0, (points - 1) / 2, points - 1} {
+			fmt.Fprintf(&b, `<text x="%.1f" y="%d" class="axis" text-anchor="middle">%d%s</text>`, x(i), chartHeight-8, i, xLabel)
+		}
+	}
+
+	// 범례
+	for i, s := range data {
+		lx := chartPadLeft + 8 + i*140
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="10" height="10" fill="%s"/>`, lx, chartPadTop, s.Color)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" class="legend">%s</text>`, lx+14, chartPadTop+9, template.HTMLEscapeString(s.Name))
+	}
+
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// bar는 막대 차트의 막대 하나
+type bar struct {
+	Label string
+	Value float64
+}
+
+// barChart는 막대 데이터를 SVG 막대 차트로 렌더링
+func barChart(color string, bars []bar) template.HTML {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg viewBox="0 0 %d %d" class="chart" role="img">`, chartWidth, chartHeight)
+
+	yMax := 0.0
+	for _, br := range bars {
+		yMax = math.Max(yMax, br.Value)
+	}
+	yMax = niceMax(yMax)
+	axes(&b, yMax, "")
+
+	if len(bars) > 0 {
+		plotW := float64(chartWidth - chartPadLeft - chartPadRight)
+		plotH := float64(chartHeight - chartPadTop - chartPadBot)
+		slot := plotW / float64(len(bars))
+		labelEvery := int(math.Ceil(float64(len(bars)) / 10))
+		for i, br := range bars {
+			h := plotH * br.Value / yMax
+			bx := float64(chartPadLeft) + slot*float64(i)
+			fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %s</title></rect>`,
+				bx+1, float64(chartHeight-chartPadBot)-h, math.Max(slot-2, 1), h, color,
+				template.HTMLEscapeString(br.Label), formatNumber(br.Value))
+			if i%labelEvery == 0 {
+				fmt.Fprintf(&b, `<text x="%.1f" y="%d" class="axis" text-anchor="middle">%s</text>`,
+					bx+slot/2, chartHeight-8, template.HTMLEscapeString(br.Label))
+			}
+		}
+	}
+
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// formatNumber는 축/툴팁용 숫자 포맷
+func formatNumber(v float64) string {
+	switch {
+	case v >= 1000:
+		return fmt.Sprintf("%.0f", v)
+	case v >= 10:
+		return fmt.Sprintf("%.1f", v)
+	default:
+		return fmt.Sprintf("%.2f", v)
+	}
+}
@@ -0,0 +1,162 @@
+// package report는 완료된 테스트 실행으로부터 단일 HTML 보고서를 생성합니다.
+// 생성된 보고서는 CSS/JS/차트를 모두 내장하므로 네트워크 없이 열 수 있습니다.
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+//go:embed report.html
+var reportTemplate string
+
+// tmpl은 보고서 템플릿 (패키지 로드 시 한 번만 파싱)
+var tmpl = template.Must(template.New("report").Funcs(template.FuncMap{
+	"ms":      func(v float64) string { return fmt.Sprintf("%.1f ms", v) },
+	"pct":     func(v float64) string { return fmt.Sprintf("%.2f%%", v*100) },
+	"num":     func(v float64) string { return fmt.Sprintf("%.2f", v) },
+	"time":    func(t time.Time) string { return t.Local().Format("2006-01-02 15:04:05") },
+	"seconds": func(d time.Duration) string { return d.Round(time.Second).String() },
+}).Parse(reportTemplate))
+
+// statusRow는 응답 코드 표의 한 행
+type statusRow struct {
+	Code    string
+	Count   int
+	Percent float64
+}
+
+// endpointRow는 엔드포인트 표의 한 행
+type endpointRow struct {
+	Name string
+	*config.EndpointStats
+}
+
+// view는 템플릿에 전달하는 데이터
+type view struct {
+	Run         *storage.TestRun
+	Result      config.TestResult
+	HasResult   bool
+	Duration    time.Duration
+	Thresholds  []config.ThresholdVerdict
+	Passed      bool
+	Statuses    []statusRow
+	Endpoints   []endpointRow
+	LatencyOver template.HTML
+	RateOver    template.HTML
+	Histogram   template.HTML
+	GeneratedAt time.Time
+}
+
+// Render는 실행 기록을 HTML 보고서로 렌더링하여 w에 씀
+func Render(w io.Writer, run *storage.TestRun) error {
+	v := view{
+		Run:         run,
+		Duration:    run.FinishedAt.Sub(run.StartedAt),
+		GeneratedAt: time.Now(),
+	}
+
+	if run.Result != nil {
+		v.HasResult = true
+		v.Result = *run.Result
+
+		// 합격 기준 (이전 버전 기록에는 판정이 없으므로 다시 계산)
+		v.Thresholds, v.Passed = loadtest.Verdicts(v.Result, run.Request.Thresholds)
+
+		v.Statuses = statusRows(v.Result)
+		v.Endpoints = endpointRows(v.Result)
+		v.LatencyOver, v.RateOver = timeSeriesCharts(v.Result)
+		v.Histogram = histogramChart(v.Result)
+	}
+
+	if err := tmpl.Execute(w, v); err != nil {
+		return fmt.Errorf("보고서 렌더링 실패: %v", err)
+	}
+	return nil
+}
+
+// statusRows는 응답 코드별 개수를 코드 순으로 정렬 (-1은 타임아웃)
+func statusRows(result config.TestResult) []statusRow {
+	codes := make([]int, 0, len(result.StatusMap))
+	for code := range result.StatusMap {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	rows := make([]statusRow, 0, len(codes))
+	for _, code := range codes {
+		label := strconv.Itoa(code)
+		if code == -1 {
+			label = "타임아웃"
+		}
+		row := statusRow{Code: label, Count: result.StatusMap[code]}
+		if result.TotalRequests > 0 {
+			row.Percent = float64(row.Count) / float64(result.TotalRequests)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// endpointRows는 엔드포인트를 요청 수 내림차순으로 정렬
+func endpointRows(result config.TestResult) []endpointRow {
+	rows := make([]endpointRow, 0, len(result.Endpoints))
+	for name, stats := range result.Endpoints {
+		rows = append(rows, endpointRow{Name: name, EndpointStats: stats})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].TotalRequests != rows[j].TotalRequests {
+			return rows[i].TotalRequests > rows[j].TotalRequests
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
+
+// timeSeriesCharts는 응답 시간 추이와 초당 요청/오류 추이 차트를 생성
+func timeSeriesCharts(result config.TestResult) (template.HTML, template.HTML) {
+	if len(result.TimeSeries) == 0 {
+		return "", ""
+	}
+	avg := make([]float64, len(result.TimeSeries))
+	max := make([]float64, len(result.TimeSeries))
+	requests := make([]float64, len(result.TimeSeries))
+	errors := make([]float64, len(result.TimeSeries))
+	for i, p := range result.TimeSeries {
+		avg[i] = p.AvgLatencyMs
+		max[i] = p.MaxLatencyMs
+		requests[i] = float64(p.Requests)
+		errors[i] = float64(p.Errors)
+	}
+
+	latency := lineChart("s", "ms", []series{
+		{Name: "평균 응답 시간", Color: "#2563eb", Values: avg},
+		{Name: "최대 응답 시간", Color: "#f59e0b", Values: max},
+	})
+	rate := lineChart("s", "", []series{
+		{Name: "초당 요청", Color: "#16a34a", Values: requests},
+		{Name: "초당 오류", Color: "#dc2626", Values: errors},
+	})
+	return latency, rate
+}
+
+// histogramChart는 응답 시간 분포 막대 차트를 생성
+func histogramChart(result config.TestResult) template.HTML {
+	if len(result.Latency.Buckets) == 0 {
+		return ""
+	}
+	bars := make([]bar, 0, len(result.Latency.Buckets))
+	for _, b := range result.Latency.Buckets {
+		bars = append(bars, bar{Label: "≤" + formatNumber(b.UpperMs), Value: float64(b.Count)})
+	}
+	return barChart("#6366f1", bars)
+}
@@ -0,0 +1,143 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/modules/storage"
+)
+
+// loadRun은 testdata의 저장된 실행 기록을 읽음
+func loadRun(t *testing.T) *storage.TestRun {
+	t.Helper()
+	data, err := os.ReadFile("testdata/run.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var run storage.TestRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		t.Fatal(err)
+	}
+	return &run
+}
+
+func render(t *testing.T, run *storage.TestRun) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Render(&buf, run); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// rowPattern은 표의 한 행에서 앞 칸들이 순서대로 나오는지 검사하는 정규식을 만듦
+func rowPattern(cells ...string) *regexp.Regexp {
+	quoted := make([]string, len(cells))
+	for i, c := range cells {
+		quoted[i] = regexp.QuoteMeta(c)
+	}
+	return regexp.MustCompile(`<tr><td>` + strings.Join(quoted, `</td>\s*<td>`) + `</td>`)
+}
+
+func TestRenderFixtureRun(t *testing.T) {
+	html := render(t, loadRun(t))
+
+	for _, want := range []string{
+		"부하 테스트 보고서 - https://shop.example.com",
+		"https://shop.example.com · advanced · ID 20250412-210000-abcd",
+		`<span class="badge fail">불합격</span>`,
+		`<div class="label">총 요청</div><div class="value">60</div>`,
+		`<div class="label">실패 / 타임아웃</div><div class="value">6 / 2</div>`,
+		`<div class="label">오류율</div><div class="value">10.00%</div>`,
+		`<div class="label">처리량</div><div class="value">19.80/s</div>`,
+		`<div class="label">p95</div><div class="value">640.0 ms</div>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report is missing %q", want)
+		}
+	}
+
+	rows := map[string]*regexp.Regexp{
+		"status 200":       rowPattern("200", "54", "90.00%"),
+		"status 503":       rowPattern("503", "4", "6.67%"),
+		"timeout":          rowPattern("타임아웃", "2", "3.33%"),
+		"endpoint /":       rowPattern("GET /", "40", "0", "0.00%", "80.0 ms"),
+		"endpoint cart":    rowPattern("GET /api/cart", "20", "6", "30.00%", "230.0 ms", "180.0 ms", "870.0 ms", "905.0 ms", "910.0 ms"),
+		"threshold p95":    regexp.MustCompile(`<tr><td>p95</td><td>&lt; 500.00</td><td>640.00</td>\s*<td><span class="badge fail">실패</span>`),
+		"threshold errors": regexp.MustCompile(`<tr><td>errorRate</td><td>&lt; 0.20</td><td>0.10</td>\s*<td><span class="badge pass">통과</span>`),
+		"recommended test": rowPattern("load", "POST", "/api/cart, /api/checkout", "50", "60", "결제 흐름 부하"),
+		"recommended path": rowPattern("/api/cart", "GET", "1", "30", "오류율이 높음"),
+	}
+	for name, pattern := range rows {
+		if !pattern.MatchString(html) {
+			t.Errorf("report is missing the %s row (%s)", name, pattern)
+		}
+	}
+
+	// 응답 코드는 코드 순, 엔드포인트는 요청 수 내림차순
+	if strings.Index(html, "<tr><td>타임아웃</td>") > strings.Index(html, "<tr><td>200</td>") {
+		t.Error("status rows are not sorted by code")
+	}
+	if strings.Index(html, "<tr><td>GET /</td>") > strings.Index(html, "<tr><td>GET /api/cart</td>") {
+		t.Error("endpoint rows are not sorted by request count")
+	}
+
+	// GPT 분석은 이스케이프해서 그대로 표시
+	if !strings.Contains(html, "장바구니 API가 병목입니다. &lt;script&gt;alert(1)&lt;/script&gt;") {
+		t.Error("analysis text is missing or not escaped")
+	}
+
+	// 시계열 두 개와 분포 하나, 차트는 SVG로 내장
+	if n := strings.Count(html, "<svg"); n != 3 {
+		t.Errorf("report has %d inline SVG charts, want 3", n)
+	}
+	for _, legend := range []string{"평균 응답 시간", "초당 오류", "≤250"} {
+		if !strings.Contains(html, legend) {
+			t.Errorf("charts are missing %q", legend)
+		}
+	}
+}
+
+func TestRenderHasNoExternalResources(t *testing.T) {
+	html := render(t, loadRun(t))
+	// 네트워크 없이 열 수 있어야 하므로 외부 스크립트, 스타일, 이미지, 링크를 참조하지 않음
+	external := regexp.MustCompile(`(?i)\b(src|href)\s*=\s*["']?\s*(https?:|//)|@import|url\(\s*["']?(https?:|//)`)
+	if m := external.FindString(html); m != "" {
+		t.Errorf("report references an external resource: %s", m)
+	}
+	if strings.Contains(html, "<link") || regexp.MustCompile(`<script[^>]+src`).MatchString(html) {
+		t.Error("report loads an external stylesheet or script")
+	}
+}
+
+func TestRenderRecomputesMissingThresholds(t *testing.T) {
+	run := loadRun(t)
+	// 이전 버전 기록에는 판정이 없으므로 기본 기준(p95 < 500, errorRate < 0.05)으로 다시 판정
+	run.Result.Thresholds = nil
+	run.Result.Passed = true
+	html := render(t, run)
+	if !strings.Contains(html, `<span class="badge fail">불합격</span>`) {
+		t.Error("report passed a run that fails the default thresholds")
+	}
+	if !regexp.MustCompile(`<tr><td>errorRate</td><td>&lt; 0.05</td><td>0.10</td>`).MatchString(html) {
+		t.Error("report is missing the recomputed default errorRate threshold")
+	}
+}
+
+func TestRenderRunWithoutResult(t *testing.T) {
+	run := loadRun(t)
+	run.Result = nil
+	run.Analysis = nil
+	run.Error = "대상에 연결할 수 없습니다"
+	html := render(t, run)
+	if !strings.Contains(html, `<div class="error">대상에 연결할 수 없습니다</div>`) {
+		t.Error("report is missing the run error")
+	}
+	if strings.Contains(html, "<svg") || strings.Contains(html, "합격 기준") {
+		t.Error("report without a result shows result sections")
+	}
+}
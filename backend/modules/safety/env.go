@@ -0,0 +1,56 @@
+package safety
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ParseAllowlist는 쉼표로 구분한 허용 목록을 호스트 패턴과 IP 대역으로 나눔
+// 예: "example.com, *.staging.example.com, 10.0.0.0/8, 127.0.0.1"
+func ParseAllowlist(s string) (hosts []string, nets []*net.IPNet, err error) {
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if strings.Contains(item, "/") {
+			_, n, err := net.ParseCIDR(item)
+			if err != nil {
+				return nil, nil, fmt.Errorf("잘못된 IP 대역: %s", item)
+			}
+			nets = append(nets, n)
+			continue
+		}
+		if ip := net.ParseIP(item); ip != nil {
+			bits := 32
+			if ip.To4() == nil {
+				bits = 128
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		hosts = append(hosts, strings.ToLower(item))
+	}
+	return hosts, nets, nil
+}
+
+// ParseLimits는 "호스트=RPS" 목록을 대상별 RPS 한도로 변환
+// 예: "example.com=200, *.staging.example.com=50"
+func ParseLimits(s string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		host, value, ok := strings.Cut(item, "=")
+		rps, err := strconv.Atoi(strings.TrimSpace(value))
+		if !ok || err != nil || rps <= 0 {
+			return nil, fmt.Errorf("잘못된 RPS 한도: %s", item)
+		}
+		limits[strings.ToLower(strings.TrimSpace(host))] = rps
+	}
+	return limits, nil
+}
@@ -0,0 +1,297 @@
+// Package safety는 허가되지 않은 대상에 부하를 보내지 않도록 서버 측 안전 정책을 적용
+package safety
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// 기본 설정값
+const (
+	DefaultVerifyTTL = time.Hour       // 소유 확인 결과를 재사용하는 시간
+	verifyTimeout    = 5 * time.Second // 소유 확인 요청 하나의 제한 시간
+)
+
+// Violation은 안전 정책 위반
+// API 핸들러는 이 오류를 403으로 응답합니다.
+type Violation struct {
+	Host   string // 위반한 대상 호스트
+	Reason string // 거부 사유
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("안전 정책 위반 (%s): %s", v.Host, v.Reason)
+}
+
+// Policy는 부하 테스트 대상과 강도에 대한 안전 정책
+// 허용 목록이 비어 있으면 대상 제한 없이 RPS 한도와 소유 확인만 적용합니다.
+type Policy struct {
+	AllowedHosts   []string       // 허용 호스트 (example.com, *.example.com)
+	AllowedNets    []*net.IPNet   // 허용 IP 대역 (호스트가 해석되는 모든 IP가 대역 안이어야 함)
+	MaxRPS         int            // 대상별 한도가 없을 때 최대 RPS (0이면 제한 없음)
+	TargetMaxRPS   map[string]int // 호스트 패턴별 최대 RPS
+	VerifyAboveRPS int            // 이 RPS를 넘는 테스트는 소유 확인 필요 (0이면 확인하지 않음)
+	Secret         string         // 확인 토큰 생성용 비밀값
+	VerifyTTL      time.Duration  // 소유 확인 결과 재사용 시간 (0이면 DefaultVerifyTTL)
+
+	Resolver *net.Resolver // DNS 조회 (nil이면 net.DefaultResolver)
+	Client   *http.Client  // /.well-known 조회 (nil이면 기본 클라이언트)
+
+	mu       sync.Mutex
+	verified map[string]time.Time // 호스트 → 소유 확인 만료 시각
+}
+
+// Check는 요청을 실행해도 되는지 검사
+// 요청이 닿을 수 있는 모든 호스트가 허용 목록 안이어야 하고, RPS는 대상 한도 이하여야 하며,
+// 확인 기준을 넘는 RPS면 대상 호스트의 소유 확인(DNS TXT 또는 /.well-known 파일)이 끝나 있어야 합니다.
+func (p *Policy) Check(ctx context.Context, req config.TestRequest) error {
+	target, err := url.Parse(req.Target)
+	if err != nil || target.Host == "" {
+		return &Violation{Host: req.Target, Reason: "대상 URL을 해석할 수 없습니다"}
+	}
+
+	hosts := requestHosts(target, req)
+	for _, h := range hosts {
+		if err := p.checkHost(ctx, h); err != nil {
+			return err
+		}
+	}
+
+	rps := PeakRPS(req)
+	for _, h := range hosts {
+		if limit := p.limitFor(h); limit > 0 && rps > limit {
+			return &Violation{Host: hostname(h), Reason: fmt.Sprintf("요청한 RPS %d가 대상 한도 %d를 넘습니다", rps, limit)}
+		}
+	}
+
+	if p.VerifyAboveRPS > 0 && rps > p.VerifyAboveRPS {
+		for _, h := range hosts {
+			if strings.HasPrefix(h, "*.") {
+				return &Violation{Host: h, Reason: fmt.Sprintf("RPS %d 초과 테스트에서는 와일드카드 허용 호스트를 사용할 수 없습니다", p.VerifyAboveRPS)}
+			}
+			origin := target.Scheme + "://" + h
+			if err := p.verify(ctx, origin); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CheckTarget은 대상 URL이 허용 목록 안인지만 검사 (탐색 전에 사용)
+func (p *Policy) CheckTarget(ctx context.Context, targetURL string) error {
+	target, err := url.Parse(targetURL)
+	if err != nil || target.Host == "" {
+		return &Violation{Host: targetURL, Reason: "대상 URL을 해석할 수 없습니다"}
+	}
+	return p.checkHost(ctx, target.Host)
+}
+
+// checkHost는 호스트(포트 포함 가능)가 허용 호스트 또는 허용 대역 안인지 검사
+func (p *Policy) checkHost(ctx context.Context, host string) error {
+	if len(p.AllowedHosts) == 0 && len(p.AllowedNets) == 0 {
+		return nil
+	}
+	name := hostname(host)
+	if p.allowedByName(name) {
+		return nil
+	}
+	if len(p.AllowedNets) == 0 || strings.HasPrefix(name, "*.") {
+		return &Violation{Host: name, Reason: "허용 목록에 없는 대상입니다"}
+	}
+
+	ips, err := p.lookupIP(ctx, name)
+	if err != nil {
+		return &Violation{Host: name, Reason: fmt.Sprintf("대상 주소를 확인할 수 없습니다: %v", err)}
+	}
+	for _, ip := range ips {
+		if !p.inAllowedNets(ip) {
+			return &Violation{Host: name, Reason: fmt.Sprintf("허용 목록에 없는 대상입니다 (%s)", ip)}
+		}
+	}
+	return nil
+}
+
+// DialContext는 연결할 때마다 실제 연결 주소가 허용 대역 안인지 검사하는 dial 함수를 반환
+// Check는 실행 전에 주소를 한 번만 확인하므로, 이후 DNS 응답이 허용 대역 밖을 가리키게 바뀌면(DNS 리바인딩)
+// 전송 계층이 다시 해석한 주소로 부하가 갈 수 있습니다. http.Transport.DialContext에 넣어 사용합니다.
+// 허용 호스트 이름에 일치하는 대상은 이름으로 허용된 것이므로 주소를 검사하지 않습니다. base가 nil이면 기본 Dialer를 사용합니다.
+func (p *Policy) DialContext(base *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	var d net.Dialer
+	if base != nil {
+		d = *base
+	}
+	if d.Resolver == nil {
+		d.Resolver = p.Resolver
+	}
+	if len(p.AllowedNets) == 0 {
+		return d.DialContext
+	}
+
+	guarded := d
+	next := d.Control
+	guarded.Control = func(network, address string, c syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+		if ip := net.ParseIP(host); ip == nil || !p.inAllowedNets(ip) {
+			return &Violation{Host: host, Reason: "허용 대역 밖의 주소로 연결할 수 없습니다 (DNS 응답이 바뀌었을 수 있습니다)"}
+		}
+		if next != nil {
+			return next(network, address, c)
+		}
+		return nil
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if host, _, err := net.SplitHostPort(addr); err == nil && p.allowedByName(hostname(host)) {
+			return d.DialContext(ctx, network, addr)
+		}
+		return guarded.DialContext(ctx, network, addr)
+	}
+}
+
+// allowedByName은 호스트 이름이 허용 호스트 패턴에 일치하는지 반환
+func (p *Policy) allowedByName(name string) bool {
+	for _, pattern := range p.AllowedHosts {
+		if matchHost(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) lookupIP(ctx context.Context, name string) ([]net.IP, error) {
+	if ip := net.ParseIP(strings.Trim(name, "[]")); ip != nil {
+		return []net.IP{ip}, nil
+	}
+	resolver := p.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	addrs, err := resolver.LookupIPAddr(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	ips := make([]net.IP, 0, len(addrs))
+	for _, a := range addrs {
+		ips = append(ips, a.IP)
+	}
+	return ips, nil
+}
+
+func (p *Policy) inAllowedNets(ip net.IP) bool {
+	for _, n := range p.AllowedNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// limitFor는 호스트에 적용할 최대 RPS를 반환 (가장 구체적인 패턴 우선)
+func (p *Policy) limitFor(host string) int {
+	name := hostname(host)
+	best, bestLen := 0, -1
+	for pattern, limit := range p.TargetMaxRPS {
+		if matchHost(pattern, name) && len(pattern) > bestLen {
+			best, bestLen = limit, len(pattern)
+		}
+	}
+	if bestLen >= 0 {
+		return best
+	}
+	return p.MaxRPS
+}
+
+// requestHosts는 요청이 닿을 수 있는 호스트 목록을 반환
+// 대상 호스트, 절대 URL 경로의 호스트, 범위(Scope)의 추가 허용 호스트를 포함합니다.
+func requestHosts(target *url.URL, req config.TestRequest) []string {
+	seen := map[string]bool{strings.ToLower(target.Host): true}
+	hosts := []string{strings.ToLower(target.Host)}
+	add := func(h string) {
+		h = strings.ToLower(h)
+		if h != "" && !seen[h] {
+			seen[h] = true
+			hosts = append(hosts, h)
+		}
+	}
+	addURL := func(p string) {
+		if strings.HasPrefix(p, "http://") || strings.HasPrefix(p, "https://") {
+			if u, err := url.Parse(p); err == nil {
+				add(u.Host)
+			}
+		}
+	}
+
+	for _, p := range req.PathList {
+		addURL(p)
+	}
+	for _, spec := range req.Requests {
+		addURL(spec.Path)
+	}
+	for _, s := range req.Schedule {
+		addURL(s.Path)
+	}
+	if req.Scope != nil {
+		for _, h := range req.Scope.AllowedHosts {
+			add(strings.TrimSpace(h))
+		}
+	}
+	return hosts
+}
+
+// PeakRPS는 요청이 만들 수 있는 최대 초당 요청 수를 반환
+// 재생 모드는 속도 배율을 적용한 1초 구간의 최대 요청 수로 계산합니다.
+func PeakRPS(req config.TestRequest) int {
+	if len(req.Schedule) == 0 {
+		return req.RPS
+	}
+	speed := req.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+	offsets := make([]float64, len(req.Schedule))
+	for i, s := range req.Schedule {
+		offsets[i] = s.OffsetMs / speed
+	}
+	sort.Float64s(offsets)
+
+	peak, start := 0, 0
+	for end := range offsets {
+		for offsets[end]-offsets[start] >= 1000 {
+			start++
+		}
+		peak = max(peak, end-start+1)
+	}
+	return peak
+}
+
+// hostname은 호스트에서 포트를 제외한 이름을 소문자로 반환
+func hostname(host string) string {
+	host = strings.ToLower(host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
+
+// matchHost는 호스트가 패턴에 일치하는지 반환
+// *.example.com 은 하위 도메인(와일드카드 호스트 포함)에만 일치합니다.
+func matchHost(pattern, name string) bool {
+	pattern = hostname(strings.TrimSpace(pattern))
+	if strings.HasPrefix(pattern, "*.") {
+		return strings.HasSuffix(name, pattern[1:])
+	}
+	return name == pattern
+}
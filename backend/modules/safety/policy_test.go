@@ -0,0 +1,295 @@
+package safety
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+func mustPolicy(t *testing.T, allowlist, limits string) *Policy {
+	t.Helper()
+	hosts, nets, err := ParseAllowlist(allowlist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetLimits, err := ParseLimits(limits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Policy{AllowedHosts: hosts, AllowedNets: nets, TargetMaxRPS: targetLimits}
+}
+
+func TestParseAllowlist(t *testing.T) {
+	hosts, nets, err := ParseAllowlist(" Example.com, *.staging.example.com, 10.0.0.0/8, 127.0.0.1, ::1 ,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 2 || hosts[0] != "example.com" || hosts[1] != "*.staging.example.com" {
+		t.Errorf("hosts = %v", hosts)
+	}
+	if len(nets) != 3 || nets[1].String() != "127.0.0.1/32" || nets[2].String() != "::1/128" {
+		t.Errorf("nets = %v", nets)
+	}
+	if _, _, err := ParseAllowlist("10.0.0.0/99"); err == nil {
+		t.Error("invalid CIDR accepted")
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ParseLimits("Example.com=200, *.staging.example.com = 50")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limits["example.com"] != 200 || limits["*.staging.example.com"] != 50 {
+		t.Errorf("limits = %v", limits)
+	}
+	for _, bad := range []string{"example.com", "example.com=abc", "example.com=0", "example.com=-5"} {
+		if _, err := ParseLimits(bad); err == nil {
+			t.Errorf("ParseLimits(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestCheckAllowlist(t *testing.T) {
+	p := mustPolicy(t, "example.com,*.staging.example.com,10.0.0.0/8", "")
+	ctx := context.Background()
+
+	for _, target := range []string{
+		"https://example.com",
+		"https://example.com:8443/path",
+		"https://api.staging.example.com",
+		"http://10.1.2.3:8080",
+	} {
+		if err := p.Check(ctx, config.TestRequest{Target: target, RPS: 1}); err != nil {
+			t.Errorf("Check(%s) = %v, want allowed", target, err)
+		}
+	}
+	for _, target := range []string{
+		"https://evil.com",
+		"https://example.com.evil.com",
+		"https://staging.example.com", // *.staging.example.com 은 하위 도메인에만 일치
+		"http://192.168.0.1",
+		"not a url",
+	} {
+		err := p.Check(ctx, config.TestRequest{Target: target, RPS: 1})
+		var violation *Violation
+		if !errors.As(err, &violation) {
+			t.Errorf("Check(%s) = %v, want violation", target, err)
+		}
+	}
+}
+
+func TestCheckCoversEveryReachableHost(t *testing.T) {
+	p := mustPolicy(t, "example.com", "")
+	ctx := context.Background()
+
+	requests := map[string]config.TestRequest{
+		"absolute path":   {Target: "https://example.com", RPS: 1, PathList: []string{"/ok", "https://evil.com/x"}},
+		"request spec":    {Target: "https://example.com", RPS: 1, Requests: []config.RequestSpec{{Method: "GET", Path: "https://evil.com/api"}}},
+		"schedule":        {Target: "https://example.com", Schedule: []config.ScheduledRequest{{RequestSpec: config.RequestSpec{Method: "GET", Path: "https://evil.com/"}}}},
+		"scope allowlist": {Target: "https://example.com", RPS: 1, Scope: &config.Scope{AllowedHosts: []string{"evil.com"}}},
+	}
+	for name, req := range requests {
+		if err := p.Check(ctx, req); err == nil {
+			t.Errorf("%s: request reaching evil.com was allowed", name)
+		}
+	}
+}
+
+func TestCheckTargetLimits(t *testing.T) {
+	p := mustPolicy(t, "", "*.example.com=100,api.example.com=10")
+	p.MaxRPS = 50
+	ctx := context.Background()
+
+	cases := []struct {
+		target string
+		rps    int
+		ok     bool
+	}{
+		{"https://api.example.com", 10, true},
+		{"https://api.example.com", 11, false}, // 가장 구체적인 패턴이 우선
+		{"https://www.example.com", 100, true},
+		{"https://www.example.com", 101, false},
+		{"https://other.org", 50, true}, // 대상별 한도가 없으면 MaxRPS
+		{"https://other.org", 51, false},
+	}
+	for _, c := range cases {
+		err := p.Check(ctx, config.TestRequest{Target: c.target, RPS: c.rps})
+		if (err == nil) != c.ok {
+			t.Errorf("Check(%s, rps=%d) = %v, want ok=%v", c.target, c.rps, err, c.ok)
+		}
+	}
+}
+
+func TestPeakRPS(t *testing.T) {
+	if got := PeakRPS(config.TestRequest{RPS: 25}); got != 25 {
+		t.Errorf("PeakRPS(rps) = %d, want 25", got)
+	}
+	// 0~900ms에 4개, 2000ms에 1개 → 1초 구간 최대 4개
+	schedule := []config.ScheduledRequest{{OffsetMs: 0}, {OffsetMs: 300}, {OffsetMs: 600}, {OffsetMs: 900}, {OffsetMs: 2000}}
+	if got := PeakRPS(config.TestRequest{Schedule: schedule}); got != 4 {
+		t.Errorf("PeakRPS(schedule) = %d, want 4", got)
+	}
+	// 4배속이면 0, 75, 150, 225, 500ms로 모두 1초 안
+	if got := PeakRPS(config.TestRequest{Schedule: schedule, Speed: 4}); got != 5 {
+		t.Errorf("PeakRPS(schedule, speed 4) = %d, want 5", got)
+	}
+}
+
+func TestCheckVerifiesOwnershipAboveThreshold(t *testing.T) {
+	p := &Policy{VerifyAboveRPS: 100, Secret: "secret"}
+	token := p.Token("127.0.0.1")
+
+	served := token
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != WellKnownPath || served == "" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(served + "\n"))
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	// 기준 이하는 확인하지 않음
+	if err := p.Check(ctx, config.TestRequest{Target: "http://192.0.2.1", RPS: 100}); err != nil {
+		t.Fatalf("Check below threshold = %v", err)
+	}
+
+	// 토큰이 맞지 않으면 거부
+	served = "wrong"
+	if err := p.Check(ctx, config.TestRequest{Target: srv.URL, RPS: 101}); err == nil {
+		t.Fatal("Check with wrong well-known token succeeded")
+	}
+
+	served = token
+	if err := p.Check(ctx, config.TestRequest{Target: srv.URL, RPS: 101}); err != nil {
+		t.Fatalf("Check with valid well-known token = %v", err)
+	}
+
+	// 확인 결과는 VerifyTTL 동안 재사용
+	served = ""
+	if err := p.Check(ctx, config.TestRequest{Target: srv.URL, RPS: 101}); err != nil {
+		t.Fatalf("Check after verification = %v, want cached success", err)
+	}
+}
+
+func TestVerifyDoesNotFollowRedirects(t *testing.T) {
+	p := &Policy{VerifyAboveRPS: 1, Secret: "secret"}
+	token := p.Token("127.0.0.1")
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(token))
+	}))
+	defer other.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.URL+WellKnownPath, http.StatusFound)
+	}))
+	defer srv.Close()
+
+	if err := p.Check(context.Background(), config.TestRequest{Target: srv.URL, RPS: 2}); err == nil {
+		t.Fatal("verification passed through a redirect")
+	}
+}
+
+// sequenceResolver는 A 질의에 answers를 순서대로 하나씩 응답하는 가짜 DNS (AAAA는 빈 응답)
+// 마지막 응답은 계속 반복합니다.
+func sequenceResolver(answers ...net.IP) *net.Resolver {
+	var mu sync.Mutex
+	next := 0
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			client, server := net.Pipe()
+			go func() {
+				defer server.Close()
+				// net.Pipe는 PacketConn이 아니므로 TCP 형식(2바이트 길이 + 메시지)으로 주고받음
+				var size [2]byte
+				if _, err := io.ReadFull(server, size[:]); err != nil {
+					return
+				}
+				query := make([]byte, binary.BigEndian.Uint16(size[:]))
+				if _, err := io.ReadFull(server, query); err != nil {
+					return
+				}
+				end := 12
+				for query[end] != 0 {
+					end += int(query[end]) + 1
+				}
+				end += 5 // 이름 끝 0, QTYPE, QCLASS
+				qtype := binary.BigEndian.Uint16(query[end-4:])
+
+				resp := append([]byte{query[0], query[1], 0x81, 0x80, 0, 1, 0, 0, 0, 0, 0, 0}, query[12:end]...)
+				if qtype == 1 {
+					mu.Lock()
+					ip := answers[min(next, len(answers)-1)]
+					next++
+					mu.Unlock()
+					resp[7] = 1
+					resp = append(resp, 0xc0, 12, 0, 1, 0, 1, 0, 0, 0, 0, 0, 4)
+					resp = append(resp, ip.To4()...)
+				}
+				binary.BigEndian.PutUint16(size[:], uint16(len(resp)))
+				server.Write(append(size[:], resp...))
+			}()
+			return client, nil
+		},
+	}
+}
+
+func TestDialContextRejectsReboundAddress(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hits++ }))
+	defer srv.Close()
+	_, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+	target := "http://rebind.test:" + port
+
+	// 허용 대역 안의 주소로 두 번(정책 검사, 첫 연결) 해석된 뒤 대역 밖 주소로 바뀜
+	p := mustPolicy(t, "127.0.0.1", "")
+	p.Resolver = sequenceResolver(net.ParseIP("127.0.0.1"), net.ParseIP("127.0.0.1"), net.ParseIP("127.0.0.2"))
+	if err := p.CheckTarget(context.Background(), target); err != nil {
+		t.Fatalf("CheckTarget = %v, want allowed", err)
+	}
+
+	transport := &http.Transport{DialContext: p.DialContext(nil)}
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get(target)
+	if err != nil {
+		t.Fatalf("first request = %v, want allowed", err)
+	}
+	resp.Body.Close()
+	transport.CloseIdleConnections()
+
+	_, err = client.Get(target)
+	var violation *Violation
+	if !errors.As(err, &violation) {
+		t.Fatalf("request after rebinding = %v, want violation", err)
+	}
+	if hits != 1 {
+		t.Errorf("server received %d requests, want 1", hits)
+	}
+}
+
+func TestDialContextSkipsHostsAllowedByName(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	_, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+
+	// 이름으로 허용된 호스트는 대역 밖 주소로 해석돼도 연결
+	p := mustPolicy(t, "named.test,10.0.0.0/8", "")
+	p.Resolver = sequenceResolver(net.ParseIP("127.0.0.1"))
+	client := &http.Client{Transport: &http.Transport{DialContext: p.DialContext(nil)}}
+	resp, err := client.Get("http://named.test:" + port)
+	if err != nil {
+		t.Fatalf("request to a host allowed by name = %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,140 @@
+package safety
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 소유 확인 위치
+const (
+	TXTPrefix      = "_loadtest-verification."                // DNS TXT 레코드 이름 접두사
+	TXTValuePrefix = "loadtest-verification="                 // TXT 레코드 값 접두사
+	WellKnownPath  = "/.well-known/loadtest-verification.txt" // 토큰 파일 경로
+)
+
+// Instructions는 대상 소유자에게 안내할 소유 확인 방법
+type Instructions struct {
+	Host          string `json:"host"`          // 대상 호스트 (포트 제외)
+	Token         string `json:"token"`         // 확인 토큰
+	TXTRecord     string `json:"txtRecord"`     // DNS TXT 레코드 이름
+	TXTValue      string `json:"txtValue"`      // DNS TXT 레코드 값
+	WellKnownPath string `json:"wellKnownPath"` // 토큰을 내용으로 둘 파일 경로
+}
+
+// Token은 호스트의 소유 확인 토큰을 반환 (비밀값과 호스트로 계산되므로 저장하지 않음)
+func (p *Policy) Token(host string) string {
+	mac := hmac.New(sha256.New, []byte(p.Secret))
+	mac.Write([]byte(hostname(host)))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// InstructionsFor는 호스트의 소유 확인 방법을 반환
+func (p *Policy) InstructionsFor(host string) Instructions {
+	name := hostname(host)
+	token := p.Token(name)
+	return Instructions{
+		Host:          name,
+		Token:         token,
+		TXTRecord:     TXTPrefix + name,
+		TXTValue:      TXTValuePrefix + token,
+		WellKnownPath: WellKnownPath,
+	}
+}
+
+// verify는 대상 소유 확인을 수행 (성공 결과는 VerifyTTL 동안 재사용)
+// DNS TXT 레코드를 먼저 확인하고, 없으면 대상의 /.well-known 파일을 확인합니다.
+func (p *Policy) verify(ctx context.Context, origin string) error {
+	name := hostname(strings.TrimPrefix(strings.TrimPrefix(origin, "https://"), "http://"))
+
+	p.mu.Lock()
+	expires, ok := p.verified[name]
+	p.mu.Unlock()
+	if ok && time.Now().Before(expires) {
+		return nil
+	}
+
+	token := p.Token(name)
+	ctx, cancel := context.WithTimeout(ctx, verifyTimeout)
+	defer cancel()
+
+	if !p.verifyTXT(ctx, name, token) && !p.verifyWellKnown(ctx, origin, token) {
+		how := fmt.Sprintf("DNS TXT %s 에 %s%s 를 추가하거나 %s%s 파일에 토큰 %s 를 두세요",
+			TXTPrefix+name, TXTValuePrefix, token, origin, WellKnownPath, token)
+		if isIP(name) {
+			how = fmt.Sprintf("%s%s 파일에 토큰 %s 를 두세요", origin, WellKnownPath, token)
+		}
+		return &Violation{Host: name, Reason: fmt.Sprintf("RPS %d 초과 테스트는 소유 확인이 필요합니다. %s", p.VerifyAboveRPS, how)}
+	}
+
+	ttl := p.VerifyTTL
+	if ttl <= 0 {
+		ttl = DefaultVerifyTTL
+	}
+	p.mu.Lock()
+	if p.verified == nil {
+		p.verified = make(map[string]time.Time)
+	}
+	p.verified[name] = time.Now().Add(ttl)
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *Policy) verifyTXT(ctx context.Context, name, token string) bool {
+	if isIP(name) {
+		return false
+	}
+	resolver := p.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	records, err := resolver.LookupTXT(ctx, TXTPrefix+name)
+	if err != nil {
+		return false
+	}
+	for _, r := range records {
+		if strings.TrimSpace(r) == TXTValuePrefix+token {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) verifyWellKnown(ctx context.Context, origin, token string) bool {
+	client := p.Client
+	if client == nil {
+		client = &http.Client{
+			// 다른 호스트로 넘겨 확인을 우회하지 못하도록 리다이렉트는 따라가지 않음
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+WellKnownPath, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(body)) == token
+}
+
+// isIP는 호스트 이름이 IP 주소인지 반환 (IP는 DNS TXT로 확인할 수 없음)
+func isIP(name string) bool {
+	return net.ParseIP(strings.Trim(name, "[]")) != nil
+}
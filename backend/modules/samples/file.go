@@ -0,0 +1,220 @@
+// package samples는 요청별 원시 샘플을 실행마다 파일 하나(gzip으로 압축한 JSONL)에 기록하고 다시 읽습니다.
+// 집계 결과만으로는 알 수 없는 이상치를 나중에 조사하거나 결과를 다시 계산할 때 사용합니다.
+package samples
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// Version은 샘플 파일 형식 버전 (헤더에 기록)
+const Version = 1
+
+// ErrNotFound는 실행의 샘플 파일이 없을 때 반환되는 오류
+var ErrNotFound = errors.New("원시 샘플 파일이 없습니다 (recordSamples 옵션으로 실행해야 합니다)")
+
+// Header는 샘플 파일의 첫 줄 (실행 정보)
+type Header struct {
+	Version   int       `json:"version"`   // 파일 형식 버전
+	RunID     string    `json:"runId"`     // 실행 ID
+	Target    string    `json:"target"`    // 테스트 대상 URL
+	StartedAt time.Time `json:"startedAt"` // 측정 시작 시각 (시계열 구간 계산 기준)
+}
+
+// Store는 실행 ID별 샘플 파일을 관리하는 디렉토리
+type Store struct {
+	dir string
+}
+
+// NewStore는 dir 디렉토리를 사용하는 샘플 저장소를 생성
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("샘플 디렉토리 생성 실패: %v", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Path는 실행 ID에 해당하는 샘플 파일 경로를 반환
+func (s *Store) Path(runID string) (string, error) {
+	// 경로 조작 방지: ID에 구분자가 들어가면 거부
+	if runID == "" || strings.ContainsAny(runID, `/\`) || strings.Contains(runID, "..") {
+		return "", fmt.Errorf("잘못된 실행 ID: %q", runID)
+	}
+	return filepath.Join(s.dir, runID+".jsonl.gz"), nil
+}
+
+// Create는 실행의 샘플 파일을 새로 만들고 헤더를 기록 (같은 ID의 파일이 있으면 덮어씀)
+func (s *Store) Create(h Header) (*Writer, error) {
+	path, err := s.Path(h.RunID)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("샘플 파일 생성 실패: %v", err)
+	}
+	w := &Writer{file: f}
+	w.gz = gzip.NewWriter(f)
+	w.buf = bufio.NewWriter(w.gz)
+	w.enc = json.NewEncoder(w.buf)
+
+	h.Version = Version
+	if err := w.enc.Encode(h); err != nil {
+		w.Close()
+		return nil, fmt.Errorf("샘플 파일 헤더 기록 실패: %v", err)
+	}
+	return w, nil
+}
+
+// WriteAll은 메모리에 모인 샘플(분산 실행 결과 등)을 한 번에 파일로 기록
+func (s *Store) WriteAll(h Header, list []config.Sample) error {
+	w, err := s.Create(h)
+	if err != nil {
+		return err
+	}
+	for _, sample := range list {
+		if err := w.Write(sample); err != nil {
+			w.Close()
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// Open은 실행의 샘플 파일을 읽기 위해 엶 (파일이 없으면 ErrNotFound)
+func (s *Store) Open(runID string) (*Reader, error) {
+	path, err := s.Path(runID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("샘플 파일 열기 실패: %v", err)
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("샘플 파일 해석 실패: %v", err)
+	}
+
+	r := &Reader{file: f, gz: gz, dec: json.NewDecoder(gz)}
+	if err := r.dec.Decode(&r.header); err != nil {
+		r.Close()
+		return nil, fmt.Errorf("샘플 파일 헤더 해석 실패: %v", err)
+	}
+	if r.header.Version > Version {
+		r.Close()
+		return nil, fmt.Errorf("지원하지 않는 샘플 파일 버전: %d", r.header.Version)
+	}
+	return r, nil
+}
+
+// Writer는 샘플을 한 줄씩 기록 (여러 고루틴에서 동시에 호출해도 안전)
+type Writer struct {
+	mu    sync.Mutex
+	file  *os.File
+	gz    *gzip.Writer
+	buf   *bufio.Writer
+	enc   *json.Encoder
+	count int
+}
+
+// Write는 샘플 하나를 기록
+func (w *Writer) Write(s config.Sample) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.enc.Encode(s); err != nil {
+		return fmt.Errorf("샘플 기록 실패: %v", err)
+	}
+	w.count++
+	return nil
+}
+
+// Count는 지금까지 기록한 샘플 수
+func (w *Writer) Count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.count
+}
+
+// Close는 버퍼와 압축 스트림을 비우고 파일을 닫음
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.buf.Flush()
+	if cerr := w.gz.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := w.file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("샘플 파일 닫기 실패: %v", err)
+	}
+	return nil
+}
+
+// Reader는 샘플 파일을 처음부터 한 줄씩 읽음
+type Reader struct {
+	file   *os.File
+	gz     *gzip.Reader
+	dec    *json.Decoder
+	header Header
+}
+
+// Header는 파일의 실행 정보를 반환
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// Next는 다음 샘플을 반환 (끝이면 io.EOF)
+func (r *Reader) Next() (config.Sample, error) {
+	var s config.Sample
+	if err := r.dec.Decode(&s); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			// 기록 중에 서버가 죽어 마지막 줄이 잘린 파일도 읽은 데까지는 사용
+			return s, io.EOF
+		}
+		return s, fmt.Errorf("샘플 해석 실패: %v", err)
+	}
+	return s, nil
+}
+
+// Each는 filter를 만족하는 샘플마다 fn을 호출 (fn이 오류를 반환하면 중단)
+func (r *Reader) Each(filter Filter, fn func(config.Sample) error) error {
+	for {
+		s, err := r.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !filter.Match(r.header.StartedAt, s) {
+			continue
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
+}
+
+// Close는 파일을 닫음
+func (r *Reader) Close() error {
+	r.gz.Close()
+	return r.file.Close()
+}
@@ -0,0 +1,198 @@
+package samples
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+var start = time.Date(2025, 4, 12, 21, 0, 0, 0, time.UTC)
+
+func newStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func testSamples() []config.Sample {
+	return []config.Sample{
+		{Time: start, Endpoint: "GET /", Status: 200, LatencyMs: 12.5, TTFBMs: 10},
+		{Time: start.Add(1500 * time.Millisecond), Endpoint: "GET /api/users", Status: 503, LatencyMs: 40, Failed: true, ErrorClass: "status"},
+		{Time: start.Add(2 * time.Second), Endpoint: "GET /", Status: -1, Failed: true, ErrorClass: "timeout", FailedAfterMs: 10000, Error: "timeout"},
+	}
+}
+
+// readAll은 실행의 샘플 파일을 끝까지 읽음
+func readAll(t *testing.T, s *Store, runID string) (Header, []config.Sample) {
+	t.Helper()
+	r, err := s.Open(runID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	var got []config.Sample
+	for {
+		sample, err := r.Next()
+		if err == io.EOF {
+			return r.Header(), got
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, sample)
+	}
+}
+
+func TestWriteAllAndReadBack(t *testing.T) {
+	s := newStore(t)
+	want := testSamples()
+	if err := s.WriteAll(Header{RunID: "run-1", Target: "https://example.com", StartedAt: start}, want); err != nil {
+		t.Fatal(err)
+	}
+
+	header, got := readAll(t, s, "run-1")
+	if header.Version != Version || header.RunID != "run-1" || !header.StartedAt.Equal(start) {
+		t.Errorf("header = %+v", header)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("read %d samples, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Time.Equal(want[i].Time) {
+			t.Errorf("sample %d time = %s, want %s", i, got[i].Time, want[i].Time)
+		}
+		got[i].Time = want[i].Time
+		if got[i] != want[i] {
+			t.Errorf("sample %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWriterIsSafeForConcurrentUse(t *testing.T) {
+	s := newStore(t)
+	w, err := s.Create(Header{RunID: "run-1", StartedAt: start})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				w.Write(config.Sample{Time: start, Endpoint: "GET /", Status: 200, LatencyMs: float64(j)})
+			}
+		}()
+	}
+	wg.Wait()
+	if w.Count() != 800 {
+		t.Errorf("Count = %d, want 800", w.Count())
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, got := readAll(t, s, "run-1"); len(got) != 800 {
+		t.Errorf("read %d samples, want 800", len(got))
+	}
+}
+
+func TestReadTruncatedFile(t *testing.T) {
+	s := newStore(t)
+	w, err := s.Create(Header{RunID: "run-1", StartedAt: start})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 50; i++ {
+		w.Write(config.Sample{Time: start, Endpoint: "GET /", Status: 200, LatencyMs: float64(i)})
+	}
+	// 서버가 기록 도중 죽은 것처럼 압축 스트림을 닫지 않고 버퍼만 비움
+	w.buf.Flush()
+	w.gz.Flush()
+	w.file.Close()
+
+	_, got := readAll(t, s, "run-1")
+	if len(got) != 50 {
+		t.Errorf("read %d samples from an unterminated file, want 50", len(got))
+	}
+}
+
+func TestOpenErrors(t *testing.T) {
+	s := newStore(t)
+	if _, err := s.Open("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open(missing) = %v, want ErrNotFound", err)
+	}
+	for _, id := range []string{"", "../run", `a\b`, "a/b"} {
+		if _, err := s.Path(id); err == nil {
+			t.Errorf("Path(%q) succeeded, want error", id)
+		}
+	}
+
+	// 새 버전의 서버가 기록한 파일은 잘못 해석하지 않도록 거부
+	path, _ := s.Path("future")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	json.NewEncoder(gz).Encode(Header{Version: Version + 1, RunID: "future"})
+	gz.Close()
+	f.Close()
+	if _, err := s.Open("future"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Open(newer version) = %v, want version error", err)
+	}
+}
+
+func TestEachAppliesFilter(t *testing.T) {
+	s := newStore(t)
+	if err := s.WriteAll(Header{RunID: "run-1", StartedAt: start}, testSamples()); err != nil {
+		t.Fatal(err)
+	}
+	query := url.Values{"failed": {"true"}, "from": {"1"}, "to": {"2"}}
+	filter, err := ParseFilter(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := s.Open("run-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	var got []config.Sample
+	if err := r.Each(filter, func(sample config.Sample) error {
+		got = append(got, sample)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Status != 503 {
+		t.Errorf("filtered samples = %+v, want only the 503 in [1s, 2s)", got)
+	}
+	if w := filter.Window(10); w != 1 {
+		t.Errorf("Window = %v, want 1", w)
+	}
+}
+
+func TestParseFilterRejectsBadValues(t *testing.T) {
+	for _, query := range []url.Values{
+		{"status": {"ok"}},
+		{"minLatencyMs": {"-1"}},
+		{"from": {"abc"}},
+		{"from": {"5"}, "to": {"3"}},
+	} {
+		if _, err := ParseFilter(query); err == nil {
+			t.Errorf("ParseFilter(%v) succeeded, want error", query)
+		}
+	}
+}
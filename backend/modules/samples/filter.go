@@ -0,0 +1,96 @@
+package samples
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// Filter는 샘플 조회/재집계 조건 (빈 값은 조건 없음)
+type Filter struct {
+	Endpoint     string  // 엔드포인트 ("GET /api/users")
+	Status       int     // 응답 코드 (-1은 타임아웃)
+	ErrorClass   string  // 오류 분류 (timeout, dns, refused, reset, tls, eof, status, other)
+	FailedOnly   bool    // 실패한 요청만
+	MinLatencyMs float64 // 응답 시간이 이 값 이상인 요청만
+	FromSec      float64 // 측정 시작 후 이 시점(초) 이후에 시작한 요청만
+	ToSec        float64 // 측정 시작 후 이 시점(초) 이전에 시작한 요청만 (0이면 끝까지)
+}
+
+// Match는 샘플이 조건을 만족하는지 검사 (start는 측정 시작 시각)
+func (f Filter) Match(start time.Time, s config.Sample) bool {
+	if f.Endpoint != "" && s.Endpoint != f.Endpoint {
+		return false
+	}
+	if f.Status != 0 && s.Status != f.Status {
+		return false
+	}
+	if f.ErrorClass != "" && s.ErrorClass != f.ErrorClass {
+		return false
+	}
+	if f.FailedOnly && !s.Failed {
+		return false
+	}
+	if f.MinLatencyMs > 0 && s.LatencyMs < f.MinLatencyMs {
+		return false
+	}
+	offset := s.Time.Sub(start).Seconds()
+	if f.FromSec > 0 && offset < f.FromSec {
+		return false
+	}
+	if f.ToSec > 0 && offset >= f.ToSec {
+		return false
+	}
+	return true
+}
+
+// Window는 조건의 측정 구간 길이(초)를 반환 (구간이 없으면 elapsedSec 그대로)
+func (f Filter) Window(elapsedSec float64) float64 {
+	to := elapsedSec
+	if f.ToSec > 0 && f.ToSec < to {
+		to = f.ToSec
+	}
+	if to <= f.FromSec {
+		return 0
+	}
+	return to - f.FromSec
+}
+
+// ParseFilter는 쿼리 파라미터(endpoint, status, errorClass, failed, minLatencyMs, from, to)로 조건을 만듦
+func ParseFilter(query url.Values) (Filter, error) {
+	f := Filter{
+		Endpoint:   query.Get("endpoint"),
+		ErrorClass: strings.ToLower(query.Get("errorClass")),
+		FailedOnly: query.Get("failed") == "true",
+	}
+	var err error
+	if v := query.Get("status"); v != "" {
+		if f.Status, err = strconv.Atoi(v); err != nil {
+			return f, fmt.Errorf("잘못된 status 값: %q", v)
+		}
+	}
+	for _, p := range []struct {
+		name string
+		dst  *float64
+	}{
+		{"minLatencyMs", &f.MinLatencyMs},
+		{"from", &f.FromSec},
+		{"to", &f.ToSec},
+	} {
+		v := query.Get(p.name)
+		if v == "" {
+			continue
+		}
+		if *p.dst, err = strconv.ParseFloat(v, 64); err != nil || *p.dst < 0 {
+			return f, fmt.Errorf("잘못된 %s 값: %q", p.name, v)
+		}
+	}
+	if f.ToSec > 0 && f.ToSec <= f.FromSec {
+		return f, fmt.Errorf("to는 from보다 커야 합니다")
+	}
+	return f, nil
+}
@@ -0,0 +1,108 @@
+// Package schedule은 cron 표현식으로 등록한 반복 테스트를 저장하고 정해진 시각에 실행
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/cron"
+)
+
+// ErrNotFound는 요청한 ID의 스케줄이 없을 때 반환되는 오류
+var ErrNotFound = errors.New("스케줄을 찾을 수 없습니다")
+
+// Schedule은 반복 실행할 테스트 하나
+type Schedule struct {
+	ID        string             `json:"id"`                  // 스케줄 ID
+	Name      string             `json:"name"`                // 이름 (예: nightly-soak-staging)
+	Cron      string             `json:"cron"`                // cron 표현식 (분 시 일 월 요일, @daily 등)
+	Timezone  string             `json:"timezone,omitempty"`  // cron을 해석할 시간대 (예: Asia/Seoul, 기본 서버 시간대)
+	JitterSec int                `json:"jitterSec,omitempty"` // 예정 시각에 더할 0~jitterSec초 무작위 지연 (스케줄끼리 겹치지 않게)
+	Paused    bool               `json:"paused,omitempty"`    // true면 실행하지 않음
+	Request   config.TestRequest `json:"request"`             // 실행할 테스트 설정
+	Owner     string             `json:"owner,omitempty"`     // 등록한 API 키 이름
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	NextRunAt *time.Time `json:"nextRunAt,omitempty"` // 다음 실행 예정 시각 (지연 포함)
+	SlotAt    *time.Time `json:"slotAt,omitempty"`    // NextRunAt의 지연 전 cron 시각 (그다음 회차는 이 시각 이후로 계산)
+	LastRunAt *time.Time `json:"lastRunAt,omitempty"` // 마지막 실행 시작 시각
+	LastRunID string     `json:"lastRunId,omitempty"` // 마지막 실행 기록 ID
+	LastError string     `json:"lastError,omitempty"` // 마지막 실행 오류
+}
+
+// Validate는 스케줄 설정을 검사
+func (s *Schedule) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("name이 필요합니다")
+	}
+	if _, err := s.expr(); err != nil {
+		return err
+	}
+	if _, err := s.location(); err != nil {
+		return err
+	}
+	if s.JitterSec < 0 {
+		return fmt.Errorf("jitterSec는 0 이상이어야 합니다")
+	}
+	req := s.Request
+	if req.Target == "" {
+		return fmt.Errorf("request.target이 필요합니다")
+	}
+	if len(req.Schedule) == 0 && (req.RPS <= 0 || req.Duration <= 0) {
+		return fmt.Errorf("request.rps와 request.duration은 0보다 커야 합니다")
+	}
+	return nil
+}
+
+// NextRun은 after 이후의 다음 실행 시각을 반환 (무작위 지연 포함)
+func (s *Schedule) NextRun(after time.Time) (time.Time, error) {
+	slot, err := s.NextSlot(after)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return slot.Add(s.Delay()), nil
+}
+
+// NextSlot은 after 이후에 cron 표현식과 맞는 다음 시각을 반환 (지연 없음)
+func (s *Schedule) NextSlot(after time.Time) (time.Time, error) {
+	expr, err := s.expr()
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := s.location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := expr.Next(after.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron 표현식 %q 에 맞는 실행 시각이 없습니다", s.Cron)
+	}
+	return next, nil
+}
+
+// Delay는 예정 시각에 더할 0~JitterSec초 무작위 지연을 반환
+func (s *Schedule) Delay() time.Duration {
+	if s.JitterSec <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(s.JitterSec) * int64(time.Second)))
+}
+
+func (s *Schedule) expr() (*cron.Expr, error) {
+	return cron.Parse(s.Cron)
+}
+
+func (s *Schedule) location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("알 수 없는 시간대: %s", s.Timezone)
+	}
+	return loc, nil
+}
@@ -0,0 +1,228 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+func validSchedule() *Schedule {
+	return &Schedule{
+		Name:    "nightly",
+		Cron:    "0 3 * * *",
+		Request: config.TestRequest{Target: "https://staging.example.com", RPS: 10, Duration: 60},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := validSchedule().Validate(); err != nil {
+		t.Fatalf("Validate = %v", err)
+	}
+	for name, mutate := range map[string]func(*Schedule){
+		"no name":         func(s *Schedule) { s.Name = "" },
+		"bad cron":        func(s *Schedule) { s.Cron = "0 3 * *" },
+		"bad timezone":    func(s *Schedule) { s.Timezone = "Mars/Olympus" },
+		"negative jitter": func(s *Schedule) { s.JitterSec = -1 },
+		"no target":       func(s *Schedule) { s.Request.Target = "" },
+		"no rps":          func(s *Schedule) { s.Request.RPS = 0 },
+	} {
+		sc := validSchedule()
+		mutate(sc)
+		if err := sc.Validate(); err == nil {
+			t.Errorf("%s: Validate succeeded, want error", name)
+		}
+	}
+}
+
+func TestNextRunUsesTimezoneAndJitter(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		t.Skip("시간대 데이터가 없습니다")
+	}
+	sc := validSchedule()
+	sc.Timezone = "Asia/Seoul"
+	after := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) // 서울 21:00
+
+	next, err := sc.NextRun(after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 1, 2, 3, 0, 0, 0, seoul); !next.Equal(want) {
+		t.Errorf("NextRun = %s, want %s", next, want)
+	}
+
+	sc.JitterSec = 600
+	base := next
+	for i := 0; i < 50; i++ {
+		next, err := sc.NextRun(after)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next.Before(base) || !next.Before(base.Add(600*time.Second)) {
+			t.Fatalf("NextRun with jitter = %s, want within [%s, +600s)", next, base)
+		}
+	}
+
+	sc.Cron = "0 0 30 2 *"
+	if _, err := sc.NextRun(after); err == nil {
+		t.Error("NextRun for an impossible date succeeded")
+	}
+}
+
+func TestSchedulerUpdateKeepsOwnerAndHistory(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := New(store, func(*Schedule) (string, error) { return "", nil })
+
+	sc := validSchedule()
+	sc.Owner = "team-a"
+	if err := s.Create(sc); err != nil {
+		t.Fatal(err)
+	}
+	if sc.ID == "" || sc.NextRunAt == nil {
+		t.Fatalf("created schedule = %+v", sc)
+	}
+
+	ran := time.Now()
+	stored, _ := store.Get(sc.ID)
+	stored.LastRunAt, stored.LastRunID = &ran, "run-1"
+	store.Save(stored)
+
+	update := validSchedule()
+	update.Owner = "team-b" // 수정 요청으로 소유자를 바꿀 수 없음
+	update.Paused = true
+	if err := s.Update(sc.ID, update); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Get(sc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Owner != "team-a" || got.LastRunID != "run-1" || got.NextRunAt != nil || !got.Paused {
+		t.Errorf("updated schedule = %+v", got)
+	}
+}
+
+func TestTriggerRecordsRunAndRejectsOverlap(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release := make(chan struct{})
+	s := New(store, func(*Schedule) (string, error) {
+		<-release
+		return "run-42", nil
+	})
+	sc := validSchedule()
+	if err := s.Create(sc); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Trigger(sc.ID); err != nil {
+		t.Fatal(err)
+	}
+	// 이전 실행이 끝나지 않았으면 다시 실행하지 않음
+	if err := s.Trigger(sc.ID); err == nil {
+		t.Error("Trigger while running succeeded")
+	}
+	close(release)
+	s.Wait()
+
+	got, err := s.Get(sc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.LastRunID != "run-42" || got.LastRunAt == nil || got.LastError != "" {
+		t.Errorf("schedule after run = %+v", got)
+	}
+}
+
+func TestFireKeepsResultOfFastRun(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs := 0
+	s := New(store, func(*Schedule) (string, error) {
+		// 바로 끝나는 실행 (예산 초과로 거부된 경우 등)
+		runs++
+		return fmt.Sprintf("run-%d", runs), nil
+	})
+	sc := validSchedule()
+	if err := s.Create(sc); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= 200; i++ {
+		s.fire(sc.ID)
+		s.Wait()
+		got, err := s.Get(sc.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 예정 시각 저장이 실행 결과를 덮어쓰면 이전 회차의 ID가 남음
+		if want := fmt.Sprintf("run-%d", i); got.LastRunID != want || got.LastRunAt == nil || got.NextRunAt == nil {
+			t.Fatalf("after fire %d: schedule = %+v, want LastRunID %s", i, got, want)
+		}
+	}
+
+	// 이전 실행이 진행 중이면 건너뛰고 그 사실만 기록
+	if !s.claim(sc.ID) {
+		t.Fatal("claim failed")
+	}
+	s.fire(sc.ID)
+	s.release(sc.ID)
+	got, _ := s.Get(sc.ID)
+	if runs != 200 || got.LastRunID != "run-200" || !strings.Contains(got.LastError, "건너뜀") {
+		t.Errorf("skipped fire: runs = %d, schedule = %+v", runs, got)
+	}
+}
+
+func TestFireWithJitterLongerThanInterval(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := New(store, func(*Schedule) (string, error) { return "run", nil })
+	sc := validSchedule()
+	sc.Cron = "* * * * *"
+	sc.Timezone = "UTC"
+	sc.JitterSec = 90 // cron 간격(60초)보다 긴 지연
+	if err := s.Create(sc); err != nil {
+		t.Fatal(err)
+	}
+
+	slot := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	fireAt := func(now time.Time) *Schedule {
+		t.Helper()
+		stored, _ := store.Get(sc.ID)
+		stored.SlotAt = &slot
+		store.Save(stored)
+		s.now = func() time.Time { return now }
+		s.fire(sc.ID)
+		s.Wait()
+		got, _ := store.Get(sc.ID)
+		return got
+	}
+
+	// 10:00 회차가 80초 늦게 실행되어도 다음 회차는 10:01 (지연된 실행 시각 이후인 10:02가 아님)
+	got := fireAt(slot.Add(80 * time.Second))
+	want := slot.Add(time.Minute)
+	if got.SlotAt == nil || !got.SlotAt.Equal(want) {
+		t.Fatalf("next slot = %v, want %s", got.SlotAt, want)
+	}
+	if got.NextRunAt.Before(want) || !got.NextRunAt.Before(want.Add(90*time.Second)) {
+		t.Errorf("next run = %s, want within [%s, +90s)", got.NextRunAt, want)
+	}
+
+	// 서버가 멈춰 있어 지연으로 설명되지 않을 만큼 지난 회차는 몰아서 실행하지 않음
+	got = fireAt(slot.Add(2 * time.Hour))
+	if want := slot.Add(2*time.Hour - time.Minute); got.SlotAt == nil || !got.SlotAt.Equal(want) {
+		t.Errorf("next slot after a long pause = %v, want %s", got.SlotAt, want)
+	}
+}
@@ -0,0 +1,289 @@
+package schedule
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RunFunc는 스케줄 하나를 실제로 실행하고 저장된 실행 기록 ID를 반환하는 함수
+type RunFunc func(sc *Schedule) (runID string, err error)
+
+// Scheduler는 저장된 스케줄마다 타이머를 걸어 예정 시각에 RunFunc를 호출
+// 같은 스케줄의 이전 실행이 아직 끝나지 않았으면 이번 회차는 건너뜁니다.
+type Scheduler struct {
+	store *Store
+	run   RunFunc
+
+	// record는 스케줄을 읽고 고쳐 저장하는 작업을 한 번에 하나씩 하게 함
+	// (타이머, 실행 결과 기록, 수정/삭제 요청이 서로의 변경을 덮어쓰지 않도록)
+	record sync.Mutex
+
+	mu      sync.Mutex
+	timers  map[string]*time.Timer // 스케줄 ID → 다음 실행 타이머
+	running map[string]bool        // 스케줄 ID → 실행 중 여부
+	active  sync.WaitGroup         // 실행 중인 스케줄 (종료 시 대기용)
+	started bool
+	now     func() time.Time
+}
+
+// New는 스케줄러를 생성 (Start를 호출해야 타이머가 동작)
+func New(store *Store, run RunFunc) *Scheduler {
+	return &Scheduler{
+		store:   store,
+		run:     run,
+		timers:  make(map[string]*time.Timer),
+		running: make(map[string]bool),
+		now:     time.Now,
+	}
+}
+
+// Start는 저장된 모든 스케줄의 타이머를 검
+func (s *Scheduler) Start() error {
+	schedules, err := s.store.List()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.started = true
+	s.mu.Unlock()
+	for _, sc := range schedules {
+		// 재시작 후 지난 예정 시각은 실행하지 않고 다음 회차로 넘김
+		if !sc.Paused && (sc.NextRunAt == nil || sc.NextRunAt.Before(s.now())) {
+			s.setNext(sc, s.now())
+			_ = s.store.Save(sc)
+		}
+		s.arm(sc)
+	}
+	return nil
+}
+
+// Stop은 모든 타이머를 멈춤 (실행 중인 테스트는 끝까지 진행)
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started = false
+	for id, t := range s.timers {
+		t.Stop()
+		delete(s.timers, id)
+	}
+}
+
+// Wait은 실행 중인 스케줄이 모두 끝날 때까지 기다림 (Stop 후 종료 전에 호출)
+func (s *Scheduler) Wait() {
+	s.active.Wait()
+}
+
+// List는 모든 스케줄을 반환
+func (s *Scheduler) List() ([]*Schedule, error) {
+	return s.store.List()
+}
+
+// Get은 ID로 스케줄을 조회
+func (s *Scheduler) Get(id string) (*Schedule, error) {
+	return s.store.Get(id)
+}
+
+// Create는 새 스케줄을 검증하고 저장한 뒤 타이머를 검
+func (s *Scheduler) Create(sc *Schedule) error {
+	if err := sc.Validate(); err != nil {
+		return err
+	}
+	sc.ID = newID()
+	sc.CreatedAt = s.now()
+	sc.UpdatedAt = sc.CreatedAt
+	sc.NextRunAt, sc.SlotAt, sc.LastRunAt, sc.LastRunID, sc.LastError = nil, nil, nil, "", ""
+	return s.save(sc)
+}
+
+// Update는 기존 스케줄의 설정을 바꾸고 타이머를 다시 검
+// 실행 이력(LastRun*)과 생성 정보는 유지합니다.
+func (s *Scheduler) Update(id string, sc *Schedule) error {
+	s.record.Lock()
+	defer s.record.Unlock()
+	old, err := s.store.Get(id)
+	if err != nil {
+		return err
+	}
+	if err := sc.Validate(); err != nil {
+		return err
+	}
+	sc.ID = old.ID
+	sc.Owner = old.Owner
+	sc.CreatedAt = old.CreatedAt
+	sc.UpdatedAt = s.now()
+	sc.LastRunAt, sc.LastRunID, sc.LastError = old.LastRunAt, old.LastRunID, old.LastError
+	return s.save(sc)
+}
+
+// Delete는 스케줄을 삭제하고 타이머를 멈춤
+func (s *Scheduler) Delete(id string) error {
+	s.record.Lock()
+	defer s.record.Unlock()
+	if err := s.store.Delete(id); err != nil {
+		return err
+	}
+	s.disarm(id)
+	return nil
+}
+
+// Trigger는 예정 시각과 관계없이 스케줄을 즉시 한 번 실행 (비동기)
+// 이미 실행 중이면 오류를 반환합니다.
+func (s *Scheduler) Trigger(id string) error {
+	sc, err := s.store.Get(id)
+	if err != nil {
+		return err
+	}
+	if !s.claim(id) {
+		return fmt.Errorf("스케줄 %s 이(가) 이미 실행 중입니다", id)
+	}
+	go s.execute(sc)
+	return nil
+}
+
+// save는 다음 실행 시각을 계산해 저장하고 타이머를 다시 검
+func (s *Scheduler) save(sc *Schedule) error {
+	s.setNext(sc, s.now())
+	if err := s.store.Save(sc); err != nil {
+		return err
+	}
+	s.arm(sc)
+	return nil
+}
+
+// setNext는 일시정지 여부에 따라 after 이후의 다음 cron 시각과 지연을 더한 실행 시각을 기록
+func (s *Scheduler) setNext(sc *Schedule, after time.Time) {
+	sc.NextRunAt, sc.SlotAt = nil, nil
+	if sc.Paused {
+		return
+	}
+	slot, err := sc.NextSlot(after)
+	if err != nil {
+		sc.LastError = err.Error()
+		return
+	}
+	next := slot.Add(sc.Delay())
+	sc.NextRunAt, sc.SlotAt = &next, &slot
+}
+
+// arm은 스케줄의 NextRunAt에 타이머를 검 (기존 타이머는 교체, 이미 지난 시각이면 바로 실행)
+func (s *Scheduler) arm(sc *Schedule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.timers[sc.ID]; ok {
+		t.Stop()
+		delete(s.timers, sc.ID)
+	}
+	if !s.started || sc.Paused || sc.NextRunAt == nil {
+		return
+	}
+	id := sc.ID
+	s.timers[id] = time.AfterFunc(time.Until(*sc.NextRunAt), func() { s.fire(id) })
+}
+
+// disarm은 스케줄의 타이머를 멈춤
+func (s *Scheduler) disarm(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.timers[id]; ok {
+		t.Stop()
+		delete(s.timers, id)
+	}
+}
+
+// fire는 타이머가 만료되면 호출되어 스케줄을 실행하고 다음 타이머를 검
+func (s *Scheduler) fire(id string) {
+	claimed := s.claim(id)
+
+	s.record.Lock()
+	sc, err := s.store.Get(id)
+	if err != nil {
+		// 그 사이 삭제된 스케줄
+		s.record.Unlock()
+		if claimed {
+			s.release(id)
+		}
+		s.disarm(id)
+		return
+	}
+	// 다음 회차 시각과 건너뜀 오류는 실행을 시작하기 전에 저장해,
+	// 빨리 끝난 실행이 기록한 결과를 이 저장이 덮어쓰지 않게 함
+	// 다음 회차는 지연된 실행 시각이 아니라 방금 실행한 cron 시각 이후로 계산해, 지연이 cron 간격보다 길어도 회차를 건너뛰지 않음
+	// (서버가 멈춰 있었던 경우처럼 지연으로 설명되지 않을 만큼 지난 회차는 건너뜀)
+	after := s.now()
+	if sc.SlotAt != nil {
+		if earliest := after.Add(-time.Duration(sc.JitterSec) * time.Second); sc.SlotAt.After(earliest) {
+			after = *sc.SlotAt
+		} else {
+			after = earliest
+		}
+	}
+	s.setNext(sc, after)
+	if !claimed {
+		sc.LastError = fmt.Sprintf("%s 회차 건너뜀: 이전 실행이 아직 진행 중입니다", s.now().Format(time.RFC3339))
+	}
+	_ = s.store.Save(sc)
+	// 다음 회차 타이머를 먼저 걸어 실행 시간이 길어도 일정이 밀리지 않게 함
+	s.arm(sc)
+	s.record.Unlock()
+
+	if claimed {
+		// 실행에는 복사본을 넘겨 이후 타이머 처리와 같은 값을 함께 쓰지 않게 함
+		run := *sc
+		go s.execute(&run)
+	}
+}
+
+// claim은 스케줄을 실행 중으로 표시 (이미 실행 중이면 false)
+func (s *Scheduler) claim(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[id] {
+		return false
+	}
+	s.running[id] = true
+	s.active.Add(1)
+	return true
+}
+
+// release는 claim으로 표시한 실행 중 상태를 해제
+func (s *Scheduler) release(id string) {
+	s.mu.Lock()
+	delete(s.running, id)
+	s.mu.Unlock()
+	s.active.Done()
+}
+
+// execute는 스케줄을 실행하고 결과를 스케줄에 기록
+func (s *Scheduler) execute(sc *Schedule) {
+	defer s.release(sc.ID)
+
+	started := s.now()
+	runID, err := s.run(sc)
+
+	// 실행 중 설정이 바뀌었을 수 있으므로 최신 상태에 실행 결과만 반영
+	s.record.Lock()
+	defer s.record.Unlock()
+	latest, getErr := s.store.Get(sc.ID)
+	if getErr != nil {
+		return
+	}
+	latest.LastRunAt = &started
+	latest.LastRunID = runID
+	latest.LastError = ""
+	if err != nil {
+		latest.LastError = err.Error()
+	}
+	_ = s.store.Save(latest)
+}
+
+// newID는 스케줄 ID를 생성 (예: sch-3fa2c1d9e0b4)
+func newID() string {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("sch-%d", time.Now().UnixNano())
+	}
+	return "sch-" + hex.EncodeToString(buf)
+}
@@ -0,0 +1,128 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Store는 스케줄 하나를 JSON 파일 하나로 저장하는 저장소
+type Store struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+// NewStore는 dir 디렉토리를 사용하는 스케줄 저장소를 생성
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("스케줄 디렉토리 생성 실패: %v", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// path는 ID에 해당하는 파일 경로를 반환
+func (s *Store) path(id string) (string, error) {
+	// 경로 조작 방지: ID에 구분자가 들어가면 거부
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return "", ErrNotFound
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
+
+// Save는 스케줄을 파일로 저장 (같은 ID가 있으면 덮어씀)
+func (s *Store) Save(sc *Schedule) error {
+	path, err := s.path(sc.ID)
+	if err != nil {
+		return fmt.Errorf("잘못된 스케줄 ID: %s", sc.ID)
+	}
+	data, err := json.MarshalIndent(sc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("스케줄 직렬화 실패: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 임시 파일에 쓴 뒤 이름을 바꿔 중간에 끊겨도 파일이 깨지지 않게 함
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("스케줄 저장 실패: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("스케줄 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// Get은 ID로 스케줄을 읽음
+func (s *Store) Get(id string) (*Schedule, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return readSchedule(path)
+}
+
+// Delete는 스케줄을 삭제
+func (s *Store) Delete(id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("스케줄 삭제 실패: %v", err)
+	}
+	return nil
+}
+
+// List는 모든 스케줄을 이름순으로 반환
+func (s *Store) List() ([]*Schedule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("스케줄 디렉토리 읽기 실패: %v", err)
+	}
+	schedules := make([]*Schedule, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		sc, err := readSchedule(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			// 깨진 파일 하나 때문에 전체 조회가 실패하지 않도록 건너뜀
+			continue
+		}
+		schedules = append(schedules, sc)
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Name < schedules[j].Name })
+	return schedules, nil
+}
+
+// readSchedule은 파일 하나를 Schedule로 읽음
+func readSchedule(path string) (*Schedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("스케줄 읽기 실패: %v", err)
+	}
+	var sc Schedule
+	if err := json.Unmarshal(data, &sc); err != nil {
+		return nil, fmt.Errorf("스케줄 파싱 실패: %v", err)
+	}
+	return &sc, nil
+}
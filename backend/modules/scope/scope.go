@@ -0,0 +1,223 @@
+// Package scope는 탐색 결과와 부하 테스트 요청이 허용된 범위 안에 있는지 판단
+package scope
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+// thirdPartyDomains는 웹 페이지가 흔히 호출하는 분석/광고/CDN 서비스 도메인
+// 스크래퍼가 기록한 이런 요청은 대상 서비스의 엔드포인트가 아니므로 제외합니다.
+var thirdPartyDomains = []string{
+	"google-analytics.com",
+	"googletagmanager.com",
+	"googlesyndication.com",
+	"googleadservices.com",
+	"doubleclick.net",
+	"googleapis.com",
+	"gstatic.com",
+	"youtube.com",
+	"facebook.com",
+	"facebook.net",
+	"twitter.com",
+	"hotjar.com",
+	"segment.io",
+	"segment.com",
+	"mixpanel.com",
+	"amplitude.com",
+	"sentry.io",
+	"newrelic.com",
+	"nr-data.net",
+	"cloudflareinsights.com",
+	"jsdelivr.net",
+	"unpkg.com",
+	"cdnjs.cloudflare.com",
+	"intercom.io",
+	"clarity.ms",
+}
+
+// IsThirdParty는 호스트가 알려진 외부 서비스 도메인인지 반환
+func IsThirdParty(host string) bool {
+	host = strings.ToLower(host)
+	for _, d := range thirdPartyDomains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scope는 컴파일된 범위 규칙
+type Scope struct {
+	target     string // 대상 호스트 (포트 포함)
+	hosts      []string
+	include    []*regexp.Regexp
+	exclude    []*regexp.Regexp
+	thirdParty bool
+}
+
+// New는 대상 URL과 범위 설정으로 Scope를 생성
+// cfg가 nil이면 대상 호스트의 모든 경로만 허용합니다.
+func New(targetURL string, cfg *config.Scope) (*Scope, error) {
+	target, err := url.Parse(targetURL)
+	if err != nil || target.Host == "" {
+		return nil, fmt.Errorf("잘못된 대상 URL: %s", targetURL)
+	}
+	s := &Scope{target: strings.ToLower(target.Host)}
+	if cfg == nil {
+		return s, nil
+	}
+
+	s.thirdParty = cfg.AllowThirdParty
+	for _, h := range cfg.AllowedHosts {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h == "" {
+			continue
+		}
+		if strings.Contains(h, "/") {
+			return nil, fmt.Errorf("허용 호스트에는 호스트만 적어야 합니다: %s", h)
+		}
+		s.hosts = append(s.hosts, h)
+	}
+	if s.include, err = compileGlobs(cfg.Include); err != nil {
+		return nil, err
+	}
+	if s.exclude, err = compileGlobs(cfg.Exclude); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// AllowsHost는 호스트(포트 포함 가능)가 범위 안인지 반환
+func (s *Scope) AllowsHost(host string) bool {
+	host = strings.ToLower(host)
+	if host == s.target {
+		return true
+	}
+	name := host
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.HasSuffix(host, "]") {
+		name = host[:i]
+	}
+
+	for _, h := range s.hosts {
+		candidate := name
+		if strings.Contains(h, ":") {
+			candidate = host
+		}
+		if candidate == h {
+			// 정확히 적은 호스트는 외부 서비스라도 허용
+			return true
+		}
+		if strings.HasPrefix(h, "*.") && strings.HasSuffix(candidate, h[1:]) {
+			return s.thirdParty || !IsThirdParty(name)
+		}
+	}
+	return false
+}
+
+// AllowsPath는 경로가 포함/제외 규칙을 만족하는지 반환 (쿼리는 무시)
+func (s *Scope) AllowsPath(p string) bool {
+	p, _, _ = strings.Cut(p, "?")
+	if p == "" {
+		p = "/"
+	}
+	for _, re := range s.exclude {
+		if re.MatchString(p) {
+			return false
+		}
+	}
+	if len(s.include) == 0 {
+		return true
+	}
+	for _, re := range s.include {
+		if re.MatchString(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows는 URL이 범위 안인지 반환
+func (s *Scope) Allows(u *url.URL) bool {
+	return s.AllowsHost(u.Host) && s.AllowsPath(u.Path)
+}
+
+// AllowsURL은 문자열 URL이 범위 안인지 반환 (파싱할 수 없으면 범위 밖)
+func (s *Scope) AllowsURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	return s.Allows(u)
+}
+
+// IsTargetHost는 URL이 대상과 같은 호스트인지 반환
+func (s *Scope) IsTargetHost(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && strings.ToLower(u.Host) == s.target
+}
+
+// Filter는 엔드포인트를 범위 안(in)과 밖(out)으로 나눔
+// URL이 없는 엔드포인트는 대상 호스트의 경로로 판단합니다.
+func (s *Scope) Filter(endpoints []config.Endpoint) (in, out []config.Endpoint) {
+	for _, ep := range endpoints {
+		allowed := s.AllowsPath(ep.Path)
+		if ep.URL != "" {
+			allowed = s.AllowsURL(ep.URL)
+		}
+		if allowed {
+			in = append(in, ep)
+		} else {
+			out = append(out, ep)
+		}
+	}
+	return in, out
+}
+
+// compileGlobs는 경로 glob 목록을 정규식으로 변환
+func compileGlobs(patterns []string) ([]*regexp.Regexp, error) {
+	var res []*regexp.Regexp
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.HasPrefix(p, "/") && !strings.HasPrefix(p, "*") {
+			return nil, fmt.Errorf("경로 glob은 / 로 시작해야 합니다: %s", p)
+		}
+		re, err := regexp.Compile(globToRegexp(p))
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 경로 glob %s: %v", p, err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+// globToRegexp는 glob을 전체 일치 정규식으로 변환
+// ** → 모든 문자열, * → / 를 제외한 문자열, ? → / 를 제외한 한 글자
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
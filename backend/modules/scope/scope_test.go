@@ -0,0 +1,131 @@
+package scope
+
+import (
+	"testing"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+func mustScope(t *testing.T, target string, cfg *config.Scope) *Scope {
+	t.Helper()
+	s, err := New(target, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestDefaultScopeAllowsOnlyTargetHost(t *testing.T) {
+	s := mustScope(t, "https://Example.com:8443/app", nil)
+	cases := map[string]bool{
+		"https://example.com:8443/api/users": true,
+		"https://example.com/api/users":      false, // 포트가 다르면 다른 호스트
+		"https://api.example.com:8443/":      false,
+		"https://www.google-analytics.com/g": false,
+		"/relative":                          false, // 호스트가 없는 URL은 범위 밖
+	}
+	for raw, want := range cases {
+		if got := s.AllowsURL(raw); got != want {
+			t.Errorf("AllowsURL(%s) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestAllowedHosts(t *testing.T) {
+	s := mustScope(t, "https://example.com", &config.Scope{
+		AllowedHosts: []string{"api.example.com", "*.cdn.example.com", "localhost:8080", "*.googleapis.com", "fonts.gstatic.com"},
+	})
+	cases := map[string]bool{
+		"api.example.com":         true,
+		"api.example.com:9000":    true, // 포트 없이 적은 호스트는 모든 포트
+		"img.cdn.example.com":     true,
+		"cdn.example.com":         false, // *. 은 하위 도메인에만 일치
+		"localhost:8080":          true,
+		"localhost:9090":          false, // 포트를 적으면 포트까지 일치
+		"maps.googleapis.com":     false, // 와일드카드는 알려진 외부 서비스를 포함하지 않음
+		"fonts.gstatic.com":       true,  // 정확히 적은 외부 서비스는 허용
+		"evil.com":                false,
+		"api.example.com.evil.io": false,
+	}
+	for host, want := range cases {
+		if got := s.AllowsHost(host); got != want {
+			t.Errorf("AllowsHost(%s) = %v, want %v", host, got, want)
+		}
+	}
+
+	withThirdParty := mustScope(t, "https://example.com", &config.Scope{
+		AllowedHosts:    []string{"*.googleapis.com"},
+		AllowThirdParty: true,
+	})
+	if !withThirdParty.AllowsHost("maps.googleapis.com") {
+		t.Error("AllowThirdParty did not allow a wildcard third-party host")
+	}
+}
+
+func TestIncludeExcludeGlobs(t *testing.T) {
+	s := mustScope(t, "https://example.com", &config.Scope{
+		Include: []string{"/api/**", "/health"},
+		Exclude: []string{"/api/admin/**", "/api/*/delete", "/api/v?/internal"},
+	})
+	cases := map[string]bool{
+		"/api/users":          true,
+		"/api/users/1?x=y":    true,
+		"/health":             true,
+		"/healthz":            false,
+		"/":                   false,
+		"/api/admin/users":    false,
+		"/api/users/delete":   false,
+		"/api/users/1/delete": true, // * 는 / 를 넘지 않음
+		"/api/v1/internal":    false,
+		"/api/v10/internal":   true, // ? 는 한 글자
+		"/static/app.js":      false,
+	}
+	for p, want := range cases {
+		if got := s.AllowsPath(p); got != want {
+			t.Errorf("AllowsPath(%s) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	s := mustScope(t, "https://example.com", &config.Scope{Exclude: []string{"/logout"}})
+	in, out := s.Filter([]config.Endpoint{
+		{Method: "GET", Path: "/a", URL: "https://example.com/a"},
+		{Method: "GET", Path: "/b"}, // URL 없으면 경로만 확인
+		{Method: "POST", Path: "/collect", URL: "https://www.google-analytics.com/collect"},
+		{Method: "GET", Path: "/logout", URL: "https://example.com/logout"},
+	})
+	if len(in) != 2 || in[0].Path != "/a" || in[1].Path != "/b" {
+		t.Errorf("in = %+v", in)
+	}
+	if len(out) != 2 {
+		t.Errorf("out = %+v", out)
+	}
+}
+
+func TestNewRejectsInvalidConfig(t *testing.T) {
+	for name, cfg := range map[string]*config.Scope{
+		"host with path":   {AllowedHosts: []string{"example.com/api"}},
+		"relative include": {Include: []string{"api/**"}},
+	} {
+		if _, err := New("https://example.com", cfg); err == nil {
+			t.Errorf("%s: New succeeded, want error", name)
+		}
+	}
+	if _, err := New("not a url", nil); err == nil {
+		t.Error("New with invalid target succeeded")
+	}
+}
+
+func TestIsThirdParty(t *testing.T) {
+	for host, want := range map[string]bool{
+		"www.google-analytics.com": true,
+		"doubleclick.net":          true,
+		"notdoubleclick.net":       false,
+		"example.com":              false,
+	} {
+		if got := IsThirdParty(host); got != want {
+			t.Errorf("IsThirdParty(%s) = %v, want %v", host, got, want)
+		}
+	}
+}
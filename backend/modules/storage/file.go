@@ -0,0 +1,273 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// indexFile은 실행 기록 색인 파일 이름 (항목 하나당 한 줄인 JSONL, 저장할 때마다 통째로 다시 씀)
+const indexFile = "index.jsonl"
+
+// indexEntry는 목록 조회에 필요한 실행 기록 요약 (필터 조건과 정렬 기준)
+type indexEntry struct {
+	ID         string    `json:"id"`
+	Target     string    `json:"target"`
+	Owner      string    `json:"owner,omitempty"`
+	ScheduleID string    `json:"scheduleId,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+}
+
+// newIndexEntry는 실행 기록의 색인 항목을 만듦
+func newIndexEntry(run *TestRun) indexEntry {
+	return indexEntry{
+		ID:         run.ID,
+		Target:     run.Target,
+		Owner:      run.Owner,
+		ScheduleID: run.ScheduleID,
+		StartedAt:  run.StartedAt,
+	}
+}
+
+// matches는 색인 항목이 필터 조건을 만족하는지 검사 (필터 조건은 모두 색인에 있음)
+func (e indexEntry) matches(filter Filter) bool {
+	return filter.Match(&TestRun{
+		ID:         e.ID,
+		Target:     e.Target,
+		Owner:      e.Owner,
+		ScheduleID: e.ScheduleID,
+		StartedAt:  e.StartedAt,
+	})
+}
+
+// FileStore는 실행 기록 하나를 JSON 파일 하나로 저장하는 기본 저장소
+// SQLite나 BoltDB 대신 파일을 쓰는 것은 cgo나 추가 의존성 없이 디렉토리만 있으면 동작하고,
+// 기록 파일을 그대로 열어 보거나 옮길 수 있게 하기 위해서입니다. 다른 백엔드가 필요하면 Store를 구현하면 됩니다.
+// 목록 조회는 메모리에 올린 색인을 훑어 거르고 정렬한 뒤 결과에 들어가는 파일만 읽습니다.
+// 색인은 파일 내용을 읽지 않는 대신 항목 수만큼 훑으므로, 기록이 수만 건을 넘으면 다른 백엔드를 쓰는 편이 낫습니다.
+// 색인 파일은 임시 파일에 쓰고 fsync한 뒤 이름을 바꿔 교체하므로 중간에 끊겨도 이전 색인이나 새 색인 중 하나가 남고,
+// 어느 쪽이든 저장소를 열 때 기록 파일 목록과 맞춰 복구됩니다.
+type FileStore struct {
+	dir   string
+	mu    sync.RWMutex
+	index map[string]indexEntry
+}
+
+// NewFileStore는 dir 디렉토리를 사용하는 파일 저장소를 생성
+// 색인을 읽은 뒤 디렉토리의 파일 목록과 맞춰 봅니다. 색인이 없거나(예전 저장소) 빠진 기록은 파일을 읽어 채웁니다.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("저장소 디렉토리 생성 실패: %v", err)
+	}
+	s := &FileStore{dir: dir, index: make(map[string]indexEntry)}
+	if err := s.loadIndex(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// path는 ID에 해당하는 파일 경로를 반환
+func (s *FileStore) path(id string) (string, error) {
+	// 경로 조작 방지: ID에 구분자가 들어가면 거부
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return "", ErrNotFound
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
+
+// loadIndex는 색인 파일을 읽고 디렉토리의 기록 파일과 맞춘 뒤, 달라진 점이 있으면 색인을 다시 씀
+func (s *FileStore) loadIndex() error {
+	lines := 0
+	if f, err := os.Open(filepath.Join(s.dir, indexFile)); err == nil {
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			lines++
+			var e indexEntry
+			// 깨진 줄(예전 버전에서 추가하다 끊긴 줄 등)은 건너뛰고 아래에서 파일을 읽어 다시 채움
+			if json.Unmarshal(scanner.Bytes(), &e) == nil && e.ID != "" {
+				s.index[e.ID] = e
+			}
+		}
+		f.Close()
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("실행 기록 색인 읽기 실패: %v", err)
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("저장소 디렉토리 읽기 실패: %v", err)
+	}
+	onDisk := make(map[string]bool, len(entries))
+	changed := false
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		id := strings.TrimSuffix(name, ".json")
+		onDisk[id] = true
+		if _, ok := s.index[id]; ok {
+			continue
+		}
+		run, err := readRun(filepath.Join(s.dir, name))
+		if err != nil {
+			// 깨진 파일 하나 때문에 저장소 전체를 못 쓰지 않도록 건너뜀
+			continue
+		}
+		run.ID = id
+		s.index[id] = newIndexEntry(run)
+		changed = true
+	}
+	for id := range s.index {
+		if !onDisk[id] {
+			delete(s.index, id)
+			changed = true
+		}
+	}
+
+	// 깨진 줄이나 같은 기록이 여러 번 들어간 색인은 항목 하나씩으로 정리
+	if changed || lines != len(s.index) {
+		return s.rewriteIndex()
+	}
+	return nil
+}
+
+// rewriteIndex는 현재 색인 전체를 임시 파일에 쓰고 디스크에 내린 뒤 색인 파일과 바꿈
+func (s *FileStore) rewriteIndex() error {
+	path := filepath.Join(s.dir, indexFile)
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("실행 기록 색인 저장 실패: %v", err)
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, e := range s.sortedEntries() {
+		if err := enc.Encode(e); err != nil {
+			f.Close()
+			return fmt.Errorf("실행 기록 색인 저장 실패: %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("실행 기록 색인 저장 실패: %v", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("실행 기록 색인 저장 실패: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("실행 기록 색인 저장 실패: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("실행 기록 색인 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// sortedEntries는 색인 항목을 최신순으로 반환
+func (s *FileStore) sortedEntries() []indexEntry {
+	list := make([]indexEntry, 0, len(s.index))
+	for _, e := range s.index {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].StartedAt.Equal(list[j].StartedAt) {
+			return list[i].StartedAt.After(list[j].StartedAt)
+		}
+		return list[i].ID > list[j].ID
+	})
+	return list
+}
+
+// Save는 실행 기록을 파일로 저장하고 색인을 다시 씀
+func (s *FileStore) Save(run *TestRun) error {
+	if run.ID == "" {
+		run.ID = NewID()
+	}
+	path, err := s.path(run.ID)
+	if err != nil {
+		return fmt.Errorf("잘못된 실행 ID: %s", run.ID)
+	}
+
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return fmt.Errorf("실행 기록 직렬화 실패: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 임시 파일에 쓴 뒤 이름을 바꿔 중간에 끊겨도 파일이 깨지지 않게 함
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("실행 기록 저장 실패: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("실행 기록 저장 실패: %v", err)
+	}
+
+	// 색인 저장에 실패해도 기록은 저장되었고, 다음에 저장소를 열 때 파일 목록으로 복구됨
+	s.index[run.ID] = newIndexEntry(run)
+	return s.rewriteIndex()
+}
+
+// Get은 ID로 실행 기록을 읽음
+func (s *FileStore) Get(id string) (*TestRun, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return readRun(path)
+}
+
+// List는 색인으로 필터링, 정렬한 뒤 결과에 들어가는 기록만 읽어 최신순으로 반환
+func (s *FileStore) List(filter Filter) ([]*TestRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runs := make([]*TestRun, 0)
+	for _, e := range s.sortedEntries() {
+		if filter.Limit > 0 && len(runs) >= filter.Limit {
+			break
+		}
+		if !e.matches(filter) {
+			continue
+		}
+		run, err := readRun(filepath.Join(s.dir, e.ID+".json"))
+		if err != nil {
+			// 깨진 파일 하나 때문에 전체 조회가 실패하지 않도록 건너뜀
+			continue
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// readRun은 파일 하나를 TestRun으로 읽음
+func readRun(path string) (*TestRun, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("실행 기록 읽기 실패: %v", err)
+	}
+
+	var run TestRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("실행 기록 파싱 실패: %v", err)
+	}
+	return &run, nil
+}
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+)
+
+func newTestRun(id, target string, startedAt time.Time, rps int) *TestRun {
+	return &TestRun{
+		ID:        id,
+		Kind:      KindBasic,
+		Target:    target,
+		StartedAt: startedAt,
+		Request:   config.TestRequest{Target: target, RPS: rps, Duration: 30, Method: "GET"},
+		Result:    &config.TestResult{TotalRequests: rps * 30},
+	}
+}
+
+func TestFileStoreListUsesIndex(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2025, 4, 12, 0, 0, 0, 0, time.UTC)
+	for i, target := range []string{"https://a", "https://b", "https://a", "https://a"} {
+		run := newTestRun("run"+string(rune('0'+i)), target, base.Add(time.Duration(i)*time.Minute), 10)
+		if err := s.Save(run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runs, err := s.List(Filter{Target: "https://a", Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 || runs[0].ID != "run3" || runs[1].ID != "run2" {
+		t.Fatalf("List = %v, want [run3 run2]", ids(runs))
+	}
+
+	// 색인에 없는 파일(예전 저장소)과 사라진 파일은 다시 열 때 맞춰짐
+	legacy := newTestRun("legacy", "https://a", base.Add(time.Hour), 10)
+	other, _ := NewFileStore(t.TempDir())
+	if err := other.Save(legacy); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(other.dir, "legacy.json"))
+	if err := os.WriteFile(filepath.Join(dir, "legacy.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Remove(filepath.Join(dir, "run3.json"))
+
+	reopened, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs, _ = reopened.List(Filter{Target: "https://a"})
+	if got := ids(runs); len(got) != 3 || got[0] != "legacy" || got[1] != "run2" || got[2] != "run0" {
+		t.Fatalf("List after reopen = %v, want [legacy run2 run0]", got)
+	}
+}
+
+func TestFileStoreRecoversDamagedIndex(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2025, 4, 12, 0, 0, 0, 0, time.UTC)
+	for _, id := range []string{"a", "b", "a"} {
+		if err := s.Save(newTestRun(id, "https://a", base, 10)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 저장할 때마다 색인을 통째로 바꾸므로 항목은 기록 하나당 한 줄이고 임시 파일이 남지 않음
+	index := filepath.Join(dir, indexFile)
+	data, err := os.ReadFile(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "\n"); n != 2 {
+		t.Fatalf("index has %d lines, want 2:\n%s", n, data)
+	}
+	if _, err := os.Stat(index + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("temporary index left behind: %v", err)
+	}
+
+	// 끊긴 줄과 빠진 항목은 다시 열 때 기록 파일로 복구
+	if err := os.WriteFile(index, []byte(`{"id":"a","target":"https://a"}`+"\n"+`{"id":"b","tar`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs, _ := reopened.List(Filter{Target: "https://a"})
+	if got := ids(runs); len(got) != 2 || got[0] != "b" || got[1] != "a" {
+		t.Fatalf("List after damaged index = %v, want [b a]", got)
+	}
+	data, _ = os.ReadFile(index)
+	if n := strings.Count(string(data), "\n"); n != 2 {
+		t.Fatalf("index not rewritten after recovery:\n%s", data)
+	}
+}
+
+func TestPreviousSkipsIncomparableRuns(t *testing.T) {
+	s, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2025, 4, 12, 0, 0, 0, 0, time.UTC)
+	s.Save(newTestRun("soak", "https://a", base, 500))
+	for i := 0; i < 30; i++ {
+		// 페이지 크기보다 많은 다른 설정의 실행
+		s.Save(newTestRun("smoke"+time.Duration(i).String(), "https://a", base.Add(time.Duration(i+1)*time.Minute), 1))
+	}
+	candidate := newTestRun("candidate", "https://a", base.Add(time.Hour), 500)
+	s.Save(candidate)
+
+	prev, err := Previous(s, candidate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev.ID != "soak" {
+		t.Fatalf("Previous = %s, want soak", prev.ID)
+	}
+
+	lone := newTestRun("lone", "https://a", base.Add(2*time.Hour), 42)
+	if _, err := Previous(s, lone); err != ErrNotFound {
+		t.Fatalf("Previous for unmatched run = %v, want ErrNotFound", err)
+	}
+}
+
+func TestComparable(t *testing.T) {
+	now := time.Now()
+	a := newTestRun("a", "https://a", now, 10)
+	b := newTestRun("b", "https://a", now, 10)
+	if err := Comparable(a, b); err != nil {
+		t.Fatalf("same settings: %v", err)
+	}
+	b.Request.PathList = []string{"/x"}
+	if Comparable(a, b) == nil {
+		t.Error("different paths should not be comparable")
+	}
+	c := newTestRun("c", "https://a", now, 10)
+	c.Kind = KindScheduled
+	if Comparable(a, c) == nil {
+		t.Error("different kinds should not be comparable")
+	}
+
+	// 부하 모양이 다르면 비교하지 않음 (헤더/본문 차이는 무시)
+	for name, change := range map[string]func(*config.TestRequest){
+		"duration": func(r *config.TestRequest) { r.Duration = 60 },
+		"speed":    func(r *config.TestRequest) { r.Speed = 2 },
+		"schedule": func(r *config.TestRequest) { r.Schedule = []config.ScheduledRequest{{}} },
+		"weights": func(r *config.TestRequest) {
+			r.Requests = []config.RequestSpec{{Method: "GET", Path: "/", Weight: 2}}
+		},
+	} {
+		d := newTestRun("d", "https://a", now, 10)
+		change(&d.Request)
+		if Comparable(a, d) == nil {
+			t.Errorf("different %s should not be comparable", name)
+		}
+	}
+	e, f := newTestRun("e", "https://a", now, 10), newTestRun("f", "https://a", now, 10)
+	e.Request.Requests = []config.RequestSpec{{Method: "post", Path: "/x", Body: "1"}, {Method: "GET", Path: "/y"}}
+	f.Request.Requests = []config.RequestSpec{{Method: "GET", Path: "/y", Headers: map[string]string{"X": "1"}}, {Method: "POST", Path: "/x", Body: "2"}}
+	if err := Comparable(e, f); err != nil {
+		t.Errorf("same request mix with different bodies: %v", err)
+	}
+}
+
+func ids(runs []*TestRun) []string {
+	var out []string
+	for _, r := range runs {
+		out = append(out, r.ID)
+	}
+	return out
+}
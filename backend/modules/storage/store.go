@@ -0,0 +1,165 @@
+// package storage는 테스트 실행 기록을 영구 저장하고 조회하는 계층을 제공합니다.
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Mr-Muji/LoadTest/backend/config"
+	"github.com/Mr-Muji/LoadTest/backend/modules/ai"
+	"github.com/Mr-Muji/LoadTest/backend/modules/compare"
+)
+
+// ErrNotFound는 요청한 ID의 테스트 기록이 없을 때 반환되는 오류
+var ErrNotFound = errors.New("테스트 기록을 찾을 수 없습니다")
+
+// 테스트 종류
+const (
+	KindBasic       = "basic"       // /test 로 실행된 기본 부하 테스트
+	KindAdvanced    = "advanced"    // /advanced-auto-test 로 실행된 자동 테스트
+	KindDistributed = "distributed" // /distributed-test 로 여러 에이전트에서 실행된 테스트
+	KindOpenAPI     = "openapi"     // /openapi-test 로 OpenAPI 명세에서 생성해 실행한 테스트
+	KindReplay      = "replay"      // /replay-test 로 HAR/access log를 재생한 테스트
+	KindScheduled   = "scheduled"   // /schedules 에 등록된 스케줄이 예정 시각에 실행한 테스트
+)
+
+// TestRun은 한 번의 테스트 실행에 대한 전체 기록을 담는 구조체
+type TestRun struct {
+	ID             string                    `json:"id"`                       // 실행 ID
+	Kind           string                    `json:"kind"`                     // 테스트 종류 (basic, advanced)
+	Owner          string                    `json:"owner,omitempty"`          // 실행을 시작한 API 키 이름 (인증이 꺼져 있으면 빈 값)
+	ScheduleID     string                    `json:"scheduleId,omitempty"`     // 예약 실행이면 스케줄 ID
+	Target         string                    `json:"target"`                   // 테스트 대상 URL
+	Request        config.TestRequest        `json:"request"`                  // 실제 실행된 테스트 설정
+	Result         *config.TestResult        `json:"result,omitempty"`         // 부하 테스트 결과
+	Analysis       *ai.WebsiteAnalysisResult `json:"analysis,omitempty"`       // GPT 분석 결과 (advanced 전용)
+	ExtractedPaths []string                  `json:"extractedPaths,omitempty"` // 스크래퍼로 추출한 경로 (advanced 전용)
+	Endpoints      []config.Endpoint         `json:"endpoints,omitempty"`      // 추출한 엔드포인트 상세 (advanced 전용)
+	OutOfScope     []config.Endpoint         `json:"outOfScope,omitempty"`     // 범위 밖이라 제외한 엔드포인트 (advanced 전용)
+	Comparison     *compare.Result           `json:"comparison,omitempty"`     // 같은 대상의 직전 실행과의 비교 결과
+	Error          string                    `json:"error,omitempty"`          // 실행 중 발생한 오류
+	StartedAt      time.Time                 `json:"startedAt"`                // 시작 시각
+	FinishedAt     time.Time                 `json:"finishedAt"`               // 종료 시각
+}
+
+// Filter는 테스트 기록 목록 조회 조건
+type Filter struct {
+	Target   string    // 대상 URL (빈 값이면 전체)
+	Owner    string    // 실행을 시작한 API 키 이름 (빈 값이면 전체)
+	Schedule string    // 스케줄 ID (빈 값이면 전체)
+	From     time.Time // 이 시각 이후 시작된 실행만 (zero면 제한 없음)
+	To       time.Time // 이 시각 이전 시작된 실행만 (zero면 제한 없음)
+	Limit    int       // 최대 개수 (0이면 제한 없음)
+}
+
+// Match는 실행 기록이 필터 조건을 만족하는지 검사
+func (f Filter) Match(run *TestRun) bool {
+	if f.Target != "" && run.Target != f.Target {
+		return false
+	}
+	if f.Owner != "" && run.Owner != f.Owner {
+		return false
+	}
+	if f.Schedule != "" && run.ScheduleID != f.Schedule {
+		return false
+	}
+	if !f.From.IsZero() && run.StartedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && run.StartedAt.After(f.To) {
+		return false
+	}
+	return true
+}
+
+// Store는 테스트 기록 저장소 인터페이스
+// 구현체를 교체해도 API 계층은 그대로 사용할 수 있습니다.
+type Store interface {
+	// Save는 실행 기록을 저장 (같은 ID가 있으면 덮어씀)
+	Save(run *TestRun) error
+	// Get은 ID로 실행 기록을 조회 (없으면 ErrNotFound)
+	Get(id string) (*TestRun, error)
+	// List는 필터에 맞는 실행 기록을 최신순으로 반환
+	List(filter Filter) ([]*TestRun, error)
+}
+
+// Previous는 같은 대상에 대해 run 이전에 완료된 가장 최근의 비교 가능한 실행을 반환 (없으면 ErrNotFound)
+// 예약 실행이면 같은 스케줄의 직전 실행만, 인증이 켜져 있으면 같은 키가 실행한 기록만 비교 대상으로 삼습니다.
+// 종류나 부하 설정이 다른 실행(예: 1 RPS 스모크와 500 RPS 소크)은 건너뜁니다.
+func Previous(s Store, run *TestRun) (*TestRun, error) {
+	// 기록이 많아도 최근 것부터 조금씩 읽다가 찾으면 멈춤
+	const page = 20
+	filter := Filter{Target: run.Target, Owner: run.Owner, Schedule: run.ScheduleID, To: run.StartedAt, Limit: page}
+	for {
+		runs, err := s.List(filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, prev := range runs {
+			if prev.ID != run.ID && prev.Result != nil && Comparable(prev, run) == nil {
+				return prev, nil
+			}
+		}
+		if len(runs) < page {
+			return nil, ErrNotFound
+		}
+		filter.To = runs[len(runs)-1].StartedAt.Add(-time.Nanosecond)
+	}
+}
+
+// Comparable은 두 실행의 결과를 비교할 수 있는지 검사 (종류, 대상, 부하 설정, 요청 구성이 같아야 함)
+// 다르면 무엇이 다른지 설명하는 오류를 반환합니다.
+func Comparable(base, candidate *TestRun) error {
+	a, b := base.Request, candidate.Request
+	switch {
+	case base.Kind != candidate.Kind:
+		return fmt.Errorf("테스트 종류가 다릅니다 (%s, %s)", base.Kind, candidate.Kind)
+	case base.Target != candidate.Target:
+		return fmt.Errorf("대상이 다릅니다 (%s, %s)", base.Target, candidate.Target)
+	case a.RPS != b.RPS:
+		return fmt.Errorf("RPS가 다릅니다 (%d, %d)", a.RPS, b.RPS)
+	case a.Duration != b.Duration:
+		return fmt.Errorf("실행 시간이 다릅니다 (%d초, %d초)", a.Duration, b.Duration)
+	case a.Speed != b.Speed || len(a.Schedule) != len(b.Schedule):
+		return fmt.Errorf("재생 설정이 다릅니다")
+	case requestShape(a) != requestShape(b):
+		return fmt.Errorf("요청 구성(메서드, 경로, 가중치)이 다릅니다")
+	}
+	return nil
+}
+
+// requestShape는 요청 구성(메서드, 경로, 가중치)을 비교용 문자열로 만듦
+// 헤더 값이나 본문 내용처럼 부하 모양과 무관한 값은 제외합니다.
+func requestShape(req config.TestRequest) string {
+	var parts []string
+	if len(req.Requests) > 0 {
+		for _, spec := range req.Requests {
+			parts = append(parts, fmt.Sprintf("%s %s %d", strings.ToUpper(spec.Method), spec.Path, spec.Weight))
+		}
+	} else {
+		for _, p := range req.PathList {
+			parts = append(parts, strings.ToUpper(req.Method)+" "+p)
+		}
+		if len(parts) == 0 {
+			parts = append(parts, strings.ToUpper(req.Method))
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "\n")
+}
+
+// NewID는 시간순 정렬이 가능한 실행 ID를 생성
+// 예: 20250412-210426-3fa2c1d9
+func NewID() string {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		// 난수 생성 실패 시 나노초로 대체
+		return time.Now().Format("20060102-150405.000000000")
+	}
+	return time.Now().Format("20060102-150405") + "-" + hex.EncodeToString(buf)
+}
@@ -0,0 +1,170 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OTLP span 상수
+const (
+	otlpSpanKindClient  = 3 // SPAN_KIND_CLIENT
+	otlpStatusCodeError = 2 // STATUS_CODE_ERROR
+	instrumentationName = "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+)
+
+// OTLPExporter는 OTLP/HTTP JSON 형식으로 span을 수집기에 전송
+// Jaeger, Tempo, OpenTelemetry Collector 등 OTLP/HTTP를 받는 모든 수집기와 호환됩니다.
+type OTLPExporter struct {
+	endpoint    string // 예: http://localhost:4318/v1/traces
+	serviceName string
+	headers     map[string]string
+	client      *http.Client
+}
+
+// NewOTLPExporter는 수집기 주소로 Exporter를 생성
+// endpoint에 경로가 없으면 표준 경로 /v1/traces를 붙입니다.
+func NewOTLPExporter(endpoint, serviceName string, headers map[string]string) *OTLPExporter {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/v1/traces") {
+		endpoint += "/v1/traces"
+	}
+	if serviceName == "" {
+		serviceName = "loadtest"
+	}
+	return &OTLPExporter{
+		endpoint:    endpoint,
+		serviceName: serviceName,
+		headers:     headers,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ParseHeaders는 OTEL_EXPORTER_OTLP_HEADERS 형식(key1=value1,key2=value2)을 파싱
+func ParseHeaders(value string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(pair, "=")
+		if ok && strings.TrimSpace(key) != "" {
+			headers[strings.TrimSpace(key)] = strings.TrimSpace(val)
+		}
+	}
+	return headers
+}
+
+// otlp JSON 인코딩용 구조체 (ExportTraceServiceRequest)
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"` // OTLP JSON에서 int64는 문자열로 인코딩
+}
+
+// toKeyValue는 속성을 OTLP 형식으로 변환
+func toKeyValue(a Attribute) otlpKeyValue {
+	if a.IsNumber {
+		v := strconv.FormatInt(a.Int, 10)
+		return otlpKeyValue{Key: a.Key, Value: otlpValue{IntValue: &v}}
+	}
+	v := a.Str
+	return otlpKeyValue{Key: a.Key, Value: otlpValue{StringValue: &v}}
+}
+
+// Export는 span 배치를 수집기로 전송
+func (e *OTLPExporter) Export(ctx context.Context, spans []Span) error {
+	converted := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		span := otlpSpan{
+			TraceID:           s.Context.TraceID.String(),
+			SpanID:            s.Context.SpanID.String(),
+			Name:              s.Name,
+			Kind:              otlpSpanKindClient,
+			StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+		}
+		for _, a := range s.Attributes {
+			span.Attributes = append(span.Attributes, toKeyValue(a))
+		}
+		// 클라이언트 span은 실패한 경우에만 Error로 표시하고 나머지는 Unset(0)으로 둠 (OpenTelemetry 규약)
+		if s.Error != "" {
+			span.Status = otlpStatus{Code: otlpStatusCodeError, Message: s.Error}
+		}
+		converted = append(converted, span)
+	}
+
+	body, err := json.Marshal(otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: []otlpKeyValue{toKeyValue(String("service.name", e.serviceName))}},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: instrumentationName}, Spans: converted}},
+	}}})
+	if err != nil {
+		return fmt.Errorf("OTLP 요청 직렬화 실패: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("OTLP 요청 생성 실패: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("OTLP 전송 실패: %v", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("OTLP 수집기 응답 오류: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOTLPExporterSendsSpans(t *testing.T) {
+	var (
+		path   string
+		header http.Header
+		body   otlpRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, header = r.URL.Path, r.Header.Clone()
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer srv.Close()
+
+	exp := NewOTLPExporter(srv.URL+"/", "", ParseHeaders("authorization=Bearer abc, x-tenant = team-a,broken"))
+	sc := newSpanContext()
+	start := time.Unix(1700000000, 0)
+	err := exp.Export(context.Background(), []Span{{
+		Name:       "GET /api/users",
+		Context:    sc,
+		Start:      start,
+		End:        start.Add(20 * time.Millisecond),
+		Attributes: []Attribute{String("http.request.method", "GET"), Int("http.response.status_code", 503)},
+		Error:      "HTTP 503",
+	}, {
+		Name:    "GET /health",
+		Context: newSpanContext(),
+		Start:   start,
+		End:     start.Add(time.Millisecond),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if path != "/v1/traces" || header.Get("Content-Type") != "application/json" {
+		t.Errorf("request = %s, content type %q", path, header.Get("Content-Type"))
+	}
+	if header.Get("Authorization") != "Bearer abc" || header.Get("X-Tenant") != "team-a" {
+		t.Errorf("headers = %v", header)
+	}
+
+	rs := body.ResourceSpans
+	if len(rs) != 1 || len(rs[0].ScopeSpans) != 1 || len(rs[0].ScopeSpans[0].Spans) != 2 {
+		t.Fatalf("body = %+v", body)
+	}
+	if attrs := rs[0].Resource.Attributes; len(attrs) != 1 || attrs[0].Value.StringValue == nil || *attrs[0].Value.StringValue != "loadtest" {
+		t.Errorf("resource attributes = %+v, want service.name=loadtest", attrs)
+	}
+	span := rs[0].ScopeSpans[0].Spans[0]
+	if span.TraceID != sc.TraceID.String() || span.SpanID != sc.SpanID.String() || span.Kind != otlpSpanKindClient {
+		t.Errorf("span ids = %+v", span)
+	}
+	if span.StartTimeUnixNano != "1700000000000000000" || span.EndTimeUnixNano != "1700000000020000000" {
+		t.Errorf("span times = %s, %s", span.StartTimeUnixNano, span.EndTimeUnixNano)
+	}
+	if span.Status.Code != otlpStatusCodeError || span.Status.Message != "HTTP 503" || len(span.Attributes) != 2 {
+		t.Errorf("span status = %+v, attributes = %+v", span.Status, span.Attributes)
+	}
+	// 성공한 요청의 span은 OK가 아니라 Unset
+	if ok := rs[0].ScopeSpans[0].Spans[1]; ok.Status.Code != 0 || ok.Status.Message != "" {
+		t.Errorf("successful span status = %+v, want unset", ok.Status)
+	}
+}
+
+func TestOTLPExporterReportsCollectorErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	if err := NewOTLPExporter(srv.URL, "svc", nil).Export(context.Background(), []Span{{Name: "x"}}); err == nil {
+		t.Error("Export succeeded against a failing collector")
+	}
+}
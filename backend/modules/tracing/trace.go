@@ -0,0 +1,86 @@
+// package tracing은 부하 테스트 요청에 W3C traceparent 헤더를 붙이고
+// 요청별 클라이언트 span을 OTLP/HTTP로 내보내는 최소한의 트레이서를 제공합니다.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"time"
+)
+
+// TraceID는 16바이트 trace 식별자
+type TraceID [16]byte
+
+// SpanID는 8바이트 span 식별자
+type SpanID [8]byte
+
+// String은 소문자 16진수 표현을 반환
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+
+// String은 소문자 16진수 표현을 반환
+func (s SpanID) String() string { return hex.EncodeToString(s[:]) }
+
+// SpanContext는 전파되는 trace 정보
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// Traceparent는 W3C traceparent 헤더 값을 생성
+// 예: 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// newSpanContext는 새 trace/span ID를 생성
+func newSpanContext() SpanContext {
+	var sc SpanContext
+	rand.Read(sc.TraceID[:])
+	rand.Read(sc.SpanID[:])
+	return sc
+}
+
+// shouldSample은 trace ID 기반 비율 샘플링 (OpenTelemetry TraceIDRatioBased와 동일한 방식)
+// 같은 trace ID는 항상 같은 결정을 내리므로 하위 시스템과 일관된 샘플링이 됩니다.
+func shouldSample(id TraceID, ratio float64) bool {
+	if ratio >= 1 {
+		return true
+	}
+	if ratio <= 0 {
+		return false
+	}
+	bound := uint64(ratio * math.MaxInt64)
+	return binary.BigEndian.Uint64(id[8:16])>>1 < bound
+}
+
+// Attribute는 span 속성 하나 (문자열 또는 정수)
+type Attribute struct {
+	Key      string
+	Str      string
+	Int      int64
+	IsNumber bool
+}
+
+// String은 문자열 속성을 생성
+func String(key, value string) Attribute { return Attribute{Key: key, Str: value} }
+
+// Int는 정수 속성을 생성
+func Int(key string, value int64) Attribute { return Attribute{Key: key, Int: value, IsNumber: true} }
+
+// Span은 요청 하나에 대한 클라이언트 span
+type Span struct {
+	Name       string
+	Context    SpanContext
+	Start      time.Time
+	End        time.Time
+	Attributes []Attribute
+	Error      string // 비어 있지 않으면 오류 상태
+}
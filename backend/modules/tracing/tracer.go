@@ -0,0 +1,146 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 배치 처리 설정
+const (
+	queueSize     = 4096            // 내보내기 대기열 크기 (가득 차면 span 폐기)
+	batchSize     = 512             // 한 번에 내보낼 최대 span 수
+	flushInterval = 2 * time.Second // 주기적 내보내기 간격
+)
+
+// Exporter는 완료된 span을 외부로 내보내는 인터페이스
+// 테스트에서는 로컬 수집기 대신 메모리 구현으로 교체할 수 있습니다.
+type Exporter interface {
+	Export(ctx context.Context, spans []Span) error
+}
+
+// Tracer는 span을 생성하고 배치로 Exporter에 전달
+type Tracer struct {
+	exporter Exporter
+	ratio    float64
+
+	queue   chan Span
+	done    chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	dropped int
+	errors  int
+}
+
+// NewTracer는 샘플링 비율(0~1)과 Exporter로 트레이서를 생성
+// exporter가 nil이면 헤더 전파만 하고 span은 내보내지 않습니다.
+func NewTracer(exporter Exporter, sampleRatio float64) *Tracer {
+	t := &Tracer{
+		exporter: exporter,
+		ratio:    sampleRatio,
+		queue:    make(chan Span, queueSize),
+		done:     make(chan struct{}),
+	}
+	if exporter != nil {
+		t.wg.Add(1)
+		go t.loop()
+	}
+	return t
+}
+
+// Start는 새 trace의 루트 클라이언트 span 컨텍스트를 생성
+func (t *Tracer) Start() SpanContext {
+	sc := newSpanContext()
+	sc.Sampled = shouldSample(sc.TraceID, t.ratio)
+	return sc
+}
+
+// Finish는 span을 완료 처리하고, 샘플링된 경우 내보내기 대기열에 추가
+func (t *Tracer) Finish(span Span) {
+	if !span.Context.Sampled || t.exporter == nil {
+		return
+	}
+	select {
+	case t.queue <- span:
+	default:
+		// 수집기가 느려도 부하 생성이 막히지 않도록 폐기
+		t.mu.Lock()
+		t.dropped++
+		t.mu.Unlock()
+	}
+}
+
+// Stats는 폐기된 span 수와 내보내기 실패 횟수를 반환
+func (t *Tracer) Stats() (dropped, exportErrors int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.dropped, t.errors
+}
+
+// Shutdown은 대기 중인 span을 모두 내보낸 뒤 종료 (ctx 만료 시 중단)
+func (t *Tracer) Shutdown(ctx context.Context) {
+	if t.exporter == nil {
+		return
+	}
+	close(t.done)
+
+	finished := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	}
+}
+
+// loop는 대기열의 span을 모아 주기적으로 내보냄
+func (t *Tracer) loop() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Span, 0, batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := t.exporter.Export(ctx, batch)
+		cancel()
+		if err != nil {
+			t.mu.Lock()
+			t.errors++
+			t.mu.Unlock()
+		}
+		batch = make([]Span, 0, batchSize)
+	}
+
+	for {
+		select {
+		case span := <-t.queue:
+			batch = append(batch, span)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-t.done:
+			// 남은 span을 모두 비운 뒤 종료
+			for {
+				select {
+				case span := <-t.queue:
+					batch = append(batch, span)
+					if len(batch) >= batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
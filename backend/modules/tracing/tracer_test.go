@@ -0,0 +1,107 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memExporter는 내보낸 span을 메모리에 모으는 Exporter
+type memExporter struct {
+	mu    sync.Mutex
+	spans []Span
+	err   error
+}
+
+func (e *memExporter) Export(ctx context.Context, spans []Span) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return e.err
+}
+
+func (e *memExporter) exported() []Span {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Span(nil), e.spans...)
+}
+
+func TestTraceparentFormat(t *testing.T) {
+	re := regexp.MustCompile(`^00-[0-9a-f]{32}-[0-9a-f]{16}-0[01]$`)
+	sc := newSpanContext()
+	if !re.MatchString(sc.Traceparent()) {
+		t.Errorf("traceparent = %q", sc.Traceparent())
+	}
+	sc.Sampled = true
+	if got := sc.Traceparent(); got[len(got)-2:] != "01" {
+		t.Errorf("sampled traceparent = %q, want flags 01", got)
+	}
+}
+
+func TestShouldSampleRatio(t *testing.T) {
+	sampled := 0
+	for i := 0; i < 10000; i++ {
+		id := newSpanContext().TraceID
+		if !shouldSample(id, 1) || shouldSample(id, 0) {
+			t.Fatal("ratio 1 must always sample and ratio 0 never")
+		}
+		if shouldSample(id, 0.25) {
+			sampled++
+			// 같은 trace ID는 항상 같은 결정
+			if !shouldSample(id, 0.25) {
+				t.Fatal("sampling decision is not stable for a trace ID")
+			}
+		}
+	}
+	if sampled < 2200 || sampled > 2800 {
+		t.Errorf("sampled %d of 10000 at ratio 0.25", sampled)
+	}
+}
+
+func TestTracerExportsOnlySampledSpans(t *testing.T) {
+	exp := &memExporter{}
+	tracer := NewTracer(exp, 1)
+	for i := 0; i < 3; i++ {
+		sc := tracer.Start()
+		tracer.Finish(Span{Name: "GET /", Context: sc, Start: time.Now(), End: time.Now()})
+	}
+	tracer.Finish(Span{Name: "unsampled", Context: SpanContext{Sampled: false}})
+
+	// Shutdown은 대기 중인 span을 모두 내보냄
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	tracer.Shutdown(ctx)
+
+	spans := exp.exported()
+	if len(spans) != 3 {
+		t.Fatalf("exported %d spans, want 3", len(spans))
+	}
+	for _, s := range spans {
+		if s.Name != "GET /" || !s.Context.Sampled {
+			t.Errorf("exported span = %+v", s)
+		}
+	}
+}
+
+func TestTracerCountsExportErrors(t *testing.T) {
+	exp := &memExporter{err: errors.New("collector down")}
+	tracer := NewTracer(exp, 1)
+	tracer.Finish(Span{Name: "GET /", Context: tracer.Start()})
+	tracer.Shutdown(context.Background())
+	if dropped, exportErrors := tracer.Stats(); dropped != 0 || exportErrors != 1 {
+		t.Errorf("Stats = %d dropped, %d export errors, want 0 and 1", dropped, exportErrors)
+	}
+}
+
+func TestTracerWithoutExporterOnlyPropagates(t *testing.T) {
+	tracer := NewTracer(nil, 1)
+	sc := tracer.Start()
+	if !sc.Sampled {
+		t.Error("span is not sampled at ratio 1")
+	}
+	tracer.Finish(Span{Context: sc})
+	tracer.Shutdown(context.Background())
+}
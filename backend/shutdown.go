@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	api "github.com/Mr-Muji/LoadTest/backend/api/load-test"
+	loadtest "github.com/Mr-Muji/LoadTest/backend/modules/load-test"
+)
+
+// 종료 설정값 (실행 중인 테스트를 기다리는 시간은 shutdownTimeout 설정)
+const (
+	cancelGrace = 15 * time.Second // 테스트 중단 후 응답 수집과 결과 저장에 주는 시간
+	notifyGrace = 5 * time.Second  // 남은 웹훅 전송을 기다리는 시간
+)
+
+// serve는 종료 신호(SIGINT, SIGTERM)를 받을 때까지 서버를 실행한 뒤 단계적으로 종료
+// certFile과 keyFile이 있으면 HTTPS로 실행합니다. 서버를 시작하지 못하면 오류를 반환합니다.
+func serve(srv *http.Server, certFile, keyFile string) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		if certFile != "" && keyFile != "" {
+			serveErr <- srv.ListenAndServeTLS(certFile, keyFile)
+			return
+		}
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	select {
+	case err := <-serveErr:
+		return err
+	case sig := <-signals:
+		log.Infow("종료 신호 수신", "signal", sig.String())
+	}
+
+	// 정리 중에 신호를 한 번 더 받으면 기다리지 않고 바로 종료
+	go func() {
+		<-signals
+		log.Warn("종료 신호를 다시 받아 즉시 종료합니다")
+		log.Sync()
+		os.Exit(1)
+	}()
+	shutdown(srv, time.Duration(cfg.ShutdownTimeout)*time.Second)
+	return nil
+}
+
+// shutdown은 서버를 단계적으로 종료
+//  1. 새 연결과 새 테스트를 받지 않고, 스케줄과 대기 중인 테스트를 정리
+//  2. 실행 중인 테스트(HTTP 요청, 비동기 실행, 예약 실행)가 끝나기를 drain 동안 기다림
+//  3. 그래도 남은 테스트는 중단시켜 부분 결과를 저장하게 함
+//  4. 남은 웹훅을 잠시 기다린 뒤 로그 버퍼를 비움
+func shutdown(srv *http.Server, drain time.Duration) {
+	log.Infow("새 테스트를 받지 않고 실행 중인 테스트를 기다립니다", "drainTimeout", drain.String())
+	loadtest.Drain()
+	if scheduler != nil {
+		scheduler.Stop()
+	}
+	if runQueue != nil {
+		runQueue.Close()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), drain)
+	drained := waitRuns(ctx, srv)
+	cancel()
+
+	if !drained {
+		log.Warnw("대기 시간 초과, 실행 중인 테스트를 중단하고 부분 결과를 저장합니다")
+		loadtest.CancelRuns()
+		ctx, cancel := context.WithTimeout(context.Background(), cancelGrace)
+		if !waitRuns(ctx, srv) {
+			log.Errorw("테스트 결과 저장을 기다리지 못하고 종료합니다", "grace", cancelGrace.String())
+			srv.Close()
+		}
+		cancel()
+	}
+
+	if notifier != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyGrace)
+		if !waitFor(ctx, notifier.Wait) {
+			log.Warnw("전송하지 못한 웹훅 알림이 있습니다")
+		}
+		cancel()
+	}
+
+	log.Info("서버 종료 완료")
+	log.Sync()
+}
+
+// waitRuns는 처리 중인 HTTP 요청, 비동기로 시작한 테스트, 예약 실행이 모두 끝날 때까지 기다림 (ctx 만료 시 false)
+func waitRuns(ctx context.Context, srv *http.Server) bool {
+	if err := srv.Shutdown(ctx); err != nil && err != http.ErrServerClosed {
+		return false
+	}
+	if !waitFor(ctx, api.Wait) {
+		return false
+	}
+	if scheduler != nil {
+		return waitFor(ctx, scheduler.Wait)
+	}
+	return true
+}
+
+// waitFor는 wait가 끝나거나 ctx가 만료될 때까지 기다림 (끝났으면 true)
+func waitFor(ctx context.Context, wait func()) bool {
+	done := make(chan struct{})
+	go func() {
+		wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
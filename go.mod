@@ -5,9 +5,7 @@ go 1.24.1
 require (
 	github.com/joho/godotenv v1.5.1
 	github.com/sashabaranov/go-openai v1.38.1
+	go.uber.org/zap v1.27.0
 )
 
-require (
-	go.uber.org/multierr v1.11.0 // indirect
-	go.uber.org/zap v1.27.0 // indirect
-)
+require go.uber.org/multierr v1.11.0 // indirect
@@ -1,19 +1,39 @@
 // package logger는 애플리케이션 전체에서 사용할 로깅 유틸리티를 제공합니다.
+// 로거는 main에서 New로 한 번 만들고, 생성자 인자나 WithContext로 요청 컨텍스트에 담아 각 모듈에 넘깁니다.
 package logger
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"strings"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-// Logger는 전역적으로 사용할 로거 인스턴스입니다.
-var Logger *zap.SugaredLogger
+// Config는 로거 설정
+type Config struct {
+	Level      string // 최소 로그 레벨 (debug, info, warn, error, 기본 info)
+	Format     string // 출력 형식 (console 또는 json, 기본 console)
+	File       string // 로그 파일 경로 (빈 값이면 콘솔에만 출력)
+	MaxSizeMB  int    // 파일이 이 크기(MB)를 넘으면 새 파일로 교체 (0이면 크기로 교체하지 않음)
+	MaxAgeDays int    // 교체된 파일을 보관할 기간(일) (0이면 기간으로 지우지 않음)
+	MaxBackups int    // 보관할 교체 파일 수 (0이면 개수로 지우지 않음)
+}
+
+// New는 설정대로 콘솔(과 로그 파일)에 함께 출력하는 로거를 생성
+// 로그 파일은 크기를 넘거나 날짜가 바뀌면 교체되고, 오래된 파일은 보관 설정에 따라 지워집니다.
+func New(cfg Config) (*zap.SugaredLogger, error) {
+	level := zapcore.InfoLevel
+	if cfg.Level != "" {
+		l, err := zapcore.ParseLevel(cfg.Level)
+		if err != nil {
+			return nil, fmt.Errorf("알 수 없는 로그 레벨: %q (debug, info, warn, error)", cfg.Level)
+		}
+		level = l
+	}
 
-// Init은 로거를 초기화합니다.
-func Init() {
-	// 로거 설정
 	encoderConfig := zapcore.EncoderConfig{
 		TimeKey:        "time",
 		LevelKey:       "level",
@@ -22,22 +42,59 @@ func Init() {
 		MessageKey:     "msg",
 		StacktraceKey:  "stacktrace",
 		LineEnding:     zapcore.DefaultLineEnding,
-		EncodeLevel:    zapcore.CapitalLevelEncoder,
-		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeLevel:    zapcore.CapitalLevelEncoder, // 대문자로 로그 레벨 표시 (INFO, ERROR 등)
+		EncodeTime:     zapcore.ISO8601TimeEncoder,  // ISO8601 시간 포맷 사용
 		EncodeDuration: zapcore.StringDurationEncoder,
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 	}
+	var encoder zapcore.Encoder
+	switch strings.ToLower(cfg.Format) {
+	case "", "console":
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	case "json":
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	default:
+		return nil, fmt.Errorf("알 수 없는 로그 형식: %q (console, json)", cfg.Format)
+	}
 
-	// 개발 환경에서는 콘솔에 컬러 출력
-	core := zapcore.NewCore(
-		zapcore.NewConsoleEncoder(encoderConfig),
-		zapcore.AddSync(os.Stdout),
-		zap.InfoLevel,
-	)
+	writer := zapcore.AddSync(os.Stdout)
+	if cfg.File != "" {
+		file, err := OpenRotatingFile(cfg.File, cfg.MaxSizeMB, cfg.MaxAgeDays, cfg.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		writer = zapcore.NewMultiWriteSyncer(file, writer)
+	}
+
+	core := zapcore.NewCore(encoder, writer, level)
+	return zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)).Sugar(), nil
+}
 
-	// 로거 생성
-	zapLogger := zap.New(core, zap.AddCaller(), zap.AddStacktrace(zap.ErrorLevel))
-	Logger = zapLogger.Sugar()
+// Nop은 아무것도 출력하지 않는 로거 (로거를 넘겨받지 못했을 때의 기본값)
+func Nop() *zap.SugaredLogger {
+	return zap.NewNop().Sugar()
+}
 
-	Logger.Info("로거 초기화 완료")
+// ForTest는 로그마다 테스트 ID가 붙는 하위 로거를 반환 (ID가 없으면 l 그대로)
+func ForTest(l *zap.SugaredLogger, testID string) *zap.SugaredLogger {
+	if testID == "" {
+		return l
+	}
+	return l.With("testId", testID)
+}
+
+// contextKey는 컨텍스트에 로거를 담는 키
+type contextKey struct{}
+
+// WithContext는 l을 담은 하위 컨텍스트를 반환
+func WithContext(ctx context.Context, l *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext는 WithContext로 담은 로거를 반환 (없으면 Nop)
+func FromContext(ctx context.Context) *zap.SugaredLogger {
+	if l, ok := ctx.Value(contextKey{}).(*zap.SugaredLogger); ok && l != nil {
+		return l
+	}
+	return Nop()
 }
@@ -0,0 +1,25 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestContextLogger(t *testing.T) {
+	// 로거를 담지 않은 컨텍스트에서도 쓸 수 있는 로거를 반환
+	if l := FromContext(context.Background()); l == nil {
+		t.Fatal("FromContext without a logger = nil")
+	}
+
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := WithContext(context.Background(), zap.New(core).Sugar())
+	ForTest(FromContext(ctx), "run-1").Infow("부하 테스트 시작")
+
+	entries := logs.All()
+	if len(entries) != 1 || entries[0].ContextMap()["testId"] != "run-1" {
+		t.Errorf("entries = %+v, want one entry with testId", entries)
+	}
+}
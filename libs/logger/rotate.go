@@ -0,0 +1,195 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat은 교체된 로그 파일 이름에 붙는 시각 (파일 이름에 쓸 수 있도록 :를 -로)
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFile은 크기와 날짜 기준으로 교체되는 로그 파일 (zapcore.WriteSyncer)
+// 교체된 파일은 loadtest-2006-01-02T15-04-05.000.log 처럼 교체 시각이 붙은 이름으로 같은 디렉토리에 남습니다.
+type RotatingFile struct {
+	path       string
+	maxSize    int64                               // 0이면 크기로 교체하지 않음
+	maxAge     time.Duration                       // 0이면 기간으로 지우지 않음
+	maxBackups int                                 // 0이면 개수로 지우지 않음
+	rename     func(oldpath, newpath string) error // 파일 교체 (테스트에서 실패를 흉내 낼 때 바꿈)
+
+	mu           sync.Mutex
+	file         *os.File // nil이면 다음 기록 때 다시 엶
+	size         int64
+	sizeBase     int64  // 크기 기준을 잴 시작점 (교체에 실패하면 그때 크기부터 다시 maxSize만큼 쌓인 뒤 재시도)
+	day          string // 현재 파일에 기록을 시작한 날짜 (YYYY-MM-DD)
+	renameFailed bool   // 직전 교체가 실패했는지 (실패를 한 번만 알리기 위함)
+}
+
+// OpenRotatingFile은 path에 로그 파일을 열거나 새로 만듦 (디렉토리가 없으면 생성)
+func OpenRotatingFile(path string, maxSizeMB, maxAgeDays, maxBackups int) (*RotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("로그 디렉토리 생성 실패: %v", err)
+	}
+	f := &RotatingFile{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxAge:     time.Duration(maxAgeDays) * 24 * time.Hour,
+		maxBackups: maxBackups,
+		rename:     os.Rename,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	f.cleanup()
+	return f, nil
+}
+
+// Write는 로그 한 줄을 기록 (기록 전에 크기나 날짜가 넘었으면 파일을 교체)
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+	if f.size > 0 && (time.Now().Format("2006-01-02") != f.day || (f.maxSize > 0 && f.size-f.sizeBase+int64(len(p)) > f.maxSize)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Sync는 파일 버퍼를 디스크에 기록
+func (f *RotatingFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Sync()
+}
+
+// Close는 로그 파일을 닫음
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// open은 현재 로그 파일을 이어 쓰기로 엶
+// 기존 파일의 수정 날짜를 기록 시작 날짜로 보므로 어제 쓰던 파일은 첫 기록 때 교체됩니다.
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("로그 파일 열기 실패: %v", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("로그 파일 정보 조회 실패: %v", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	f.sizeBase = 0
+	f.day = time.Now().Format("2006-01-02")
+	if f.size > 0 {
+		f.day = info.ModTime().Format("2006-01-02")
+	}
+	return nil
+}
+
+// rotate는 현재 파일을 교체 시각이 붙은 이름으로 옮기고 새 파일을 엶
+// 옮기지 못하면 원래 파일을 다시 열어 이어 쓰고, 기록마다 다시 시도하지 않도록
+// 다음 교체는 다음 날짜나 지금부터 maxSize만큼 더 쌓였을 때 시도합니다.
+func (f *RotatingFile) rotate() error {
+	f.file.Close()
+	f.file = nil
+	if err := f.rename(f.path, f.nextBackupName(time.Now())); err != nil {
+		if !f.renameFailed {
+			fmt.Fprintf(os.Stderr, "로그 파일 교체 실패, 기존 파일에 계속 기록합니다: %v\n", err)
+		}
+		f.renameFailed = true
+		if err := f.open(); err != nil {
+			return err
+		}
+		f.day = time.Now().Format("2006-01-02")
+		f.sizeBase = f.size
+		return nil
+	}
+	f.renameFailed = false
+	if err := f.open(); err != nil {
+		return err
+	}
+	go f.cleanup()
+	return nil
+}
+
+// backupName은 교체된 파일 이름 (loadtest.log → loadtest-2006-01-02T15-04-05.000.log)
+func (f *RotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-" + t.Format(backupTimeFormat) + ext
+}
+
+// nextBackupName은 아직 없는 교체 파일 이름을 반환
+// 같은 밀리초에 두 번 교체되면 이전 교체 파일을 덮어쓰지 않도록 시각을 1ms씩 늘립니다.
+func (f *RotatingFile) nextBackupName(t time.Time) string {
+	for {
+		name := f.backupName(t)
+		if _, err := os.Lstat(name); os.IsNotExist(err) {
+			return name
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// cleanup은 보관 기간이 지났거나 보관 개수를 넘은 교체 파일을 지움 (실패는 무시)
+func (f *RotatingFile) cleanup() {
+	if f.maxAge <= 0 && f.maxBackups <= 0 {
+		return
+	}
+	ext := filepath.Ext(f.path)
+	prefix := strings.TrimSuffix(filepath.Base(f.path), ext) + "-"
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return
+	}
+
+	type backup struct {
+		path string
+		at   time.Time
+	}
+	var backups []backup
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		at, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext), time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{filepath.Join(filepath.Dir(f.path), name), at})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].at.After(backups[j].at) })
+
+	for i, b := range backups {
+		if (f.maxBackups > 0 && i >= f.maxBackups) || (f.maxAge > 0 && time.Since(b.at) > f.maxAge) {
+			os.Remove(b.path)
+		}
+	}
+}
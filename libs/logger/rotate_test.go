@@ -0,0 +1,163 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// openTiny는 maxSize 바이트마다 교체되는 로그 파일을 엶
+func openTiny(t *testing.T, maxSize int64, maxBackups int) (*RotatingFile, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "loadtest.log")
+	f, err := OpenRotatingFile(path, 0, 0, maxBackups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.maxSize = maxSize
+	t.Cleanup(func() { f.Close() })
+	return f, path
+}
+
+// backups는 교체된 파일 목록을 반환
+func backups(t *testing.T, path string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(strings.TrimSuffix(path, ".log") + "-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return matches
+}
+
+// readLogs는 현재 파일과 교체된 파일의 내용을 모두 이어 붙여 반환
+func readLogs(t *testing.T, path string) string {
+	t.Helper()
+	var all strings.Builder
+	for _, name := range append(backups(t, path), path) {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		all.Write(data)
+	}
+	return all.String()
+}
+
+func TestRotateBySize(t *testing.T) {
+	f, path := openTiny(t, 20, 0)
+	line := "0123456789\n" // 11바이트, 파일 하나에 한 줄씩
+	for i := 0; i < 5; i++ {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 같은 밀리초에 여러 번 교체돼도 이전 교체 파일을 덮어쓰지 않음
+	if got := backups(t, path); len(got) != 4 {
+		t.Errorf("backups = %v, want 4", got)
+	}
+	if got := readLogs(t, path); got != strings.Repeat(line, 5) {
+		t.Errorf("logs = %q, want every line exactly once", got)
+	}
+}
+
+func TestRotateByDay(t *testing.T) {
+	f, path := openTiny(t, 0, 0)
+	f.Write([]byte("yesterday\n"))
+	f.day = "2000-01-01"
+	f.Write([]byte("today\n"))
+
+	if got := backups(t, path); len(got) != 1 {
+		t.Fatalf("backups = %v, want 1", got)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "today\n" {
+		t.Errorf("current file = %q", data)
+	}
+}
+
+func TestRotateKeepsWritingWhenRenameFails(t *testing.T) {
+	f, path := openTiny(t, 10, 0)
+	f.Write([]byte("first line\n"))
+	// 로그 파일이 밖에서 지워지면 교체(rename)가 실패함
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"second\n", "third\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatalf("Write after a failed rotation = %v", err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "third\n") {
+		t.Errorf("current file = %q, want later lines to keep being written", data)
+	}
+}
+
+func TestFailedRotationWaitsForNextBoundary(t *testing.T) {
+	f, path := openTiny(t, 20, 0)
+	// 다른 프로세스가 파일을 잡고 있는 경우처럼 교체가 계속 실패
+	attempts := 0
+	f.rename = func(string, string) error {
+		attempts++
+		return os.ErrPermission
+	}
+	line := "0123456789\n"
+	f.Write([]byte(line))
+	f.Write([]byte(line)) // 크기를 넘어 교체 시도, 실패
+	if attempts != 1 {
+		t.Fatalf("rename attempts = %d, want 1", attempts)
+	}
+
+	// 실패한 뒤에는 기록마다 재시도하지 않고 지금부터 maxSize만큼 더 쌓이면 다시 시도
+	f.Write([]byte("a\n"))
+	f.Write([]byte("b\n"))
+	if attempts != 1 {
+		t.Errorf("rename attempts after small writes = %d, want 1", attempts)
+	}
+	f.Write([]byte(line))
+	if attempts != 2 {
+		t.Errorf("rename attempts after another maxSize = %d, want 2", attempts)
+	}
+
+	// 날짜가 바뀌어도 다시 시도하고, 성공하면 평소처럼 교체
+	f.rename = os.Rename
+	f.day = "2000-01-01"
+	f.Write([]byte("c\n"))
+	if got := backups(t, path); len(got) != 1 {
+		t.Errorf("backups = %v, want 1 after the next day boundary", got)
+	}
+	if got := readLogs(t, path); got != line+line+"a\nb\n"+line+"c\n" {
+		t.Errorf("logs = %q, want every line exactly once", got)
+	}
+}
+
+func TestCleanupRemovesOldAndExtraBackups(t *testing.T) {
+	f, path := openTiny(t, 0, 2)
+	f.maxAge = 24 * time.Hour
+	now := time.Now()
+	for _, at := range []time.Time{
+		now.Add(-time.Minute),
+		now.Add(-2 * time.Minute),
+		now.Add(-3 * time.Minute), // 개수 초과
+		now.Add(-48 * time.Hour),  // 기간 초과
+	} {
+		os.WriteFile(f.backupName(at), []byte("x"), 0644)
+	}
+	other := filepath.Join(filepath.Dir(path), "other.log")
+	os.WriteFile(other, []byte("x"), 0644)
+
+	f.cleanup()
+	got := backups(t, path)
+	if len(got) != 2 || got[0] != f.backupName(now.Add(-2*time.Minute)) || got[1] != f.backupName(now.Add(-time.Minute)) {
+		t.Errorf("backups after cleanup = %v, want the two newest", got)
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("cleanup removed an unrelated file: %v", err)
+	}
+}
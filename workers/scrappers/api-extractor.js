@@ -1,13 +1,59 @@
 // api-extractor.js
 const puppeteer = require('puppeteer');
 
+// JSON 출력 프로토콜 버전 (Go 쪽 orchestrator.ScraperProtocolVersion 과 일치해야 함)
+const PROTOCOL_VERSION = 1;
+
+// 엔드포인트당 보관할 최대 샘플 본문 수와 본문 길이
+const MAX_SAMPLE_BODIES = 3;
+const MAX_BODY_LENGTH = 2048;
+
+// 결과에 포함하지 않는 민감한 요청 헤더
+const SENSITIVE_HEADERS = new Set(['cookie', 'authorization', 'proxy-authorization']);
+
 /**
- * 대상 URL에서 API 요청 경로를 추출하는 함수
+ * 대상 URL에서 API 요청을 추출하는 함수
  * @param {string} targetUrl - 분석할 웹사이트 URL
- * @returns {Promise<Array>} - 추출된 API 경로 배열
+ * @returns {Promise<Array>} - 추출된 엔드포인트 배열 (method, url, path, queryParams, headers, 콘텐츠 타입, 샘플 본문, page)
  */
 async function extractApiEndpoints(targetUrl) {
-  const apiEndpoints = new Set();
+  // "METHOD URL" → 엔드포인트 정보
+  const apiEndpoints = new Map();
+
+  // 요청 정보를 엔드포인트로 병합
+  const record = (request) => {
+    const url = new URL(request.url());
+    const key = `${request.method()} ${url.origin}${url.pathname}`;
+    let endpoint = apiEndpoints.get(key);
+    if (!endpoint) {
+      endpoint = {
+        method: request.method(),
+        url: `${url.origin}${url.pathname}`,
+        path: url.pathname,
+        queryParams: [],
+        headers: {},
+        source: request.resourceType(),
+        page: request.frame() ? request.frame().url() : '',
+      };
+      apiEndpoints.set(key, endpoint);
+    }
+    for (const name of url.searchParams.keys()) {
+      if (!endpoint.queryParams.includes(name)) endpoint.queryParams.push(name);
+    }
+    const headers = request.headers();
+    for (const [name, value] of Object.entries(headers)) {
+      if (!SENSITIVE_HEADERS.has(name.toLowerCase())) endpoint.headers[name] = value;
+    }
+    if (headers['content-type']) endpoint.requestContentType = headers['content-type'];
+    const body = request.postData();
+    if (body) {
+      endpoint.sampleBodies = endpoint.sampleBodies || [];
+      if (endpoint.sampleBodies.length < MAX_SAMPLE_BODIES) {
+        endpoint.sampleBodies.push(body.slice(0, MAX_BODY_LENGTH));
+      }
+    }
+    return endpoint;
+  };
   
   // 더 많은 옵션으로 브라우저 시작
   const browser = await puppeteer.launch({
@@ -42,7 +88,7 @@ async function extractApiEndpoints(targetUrl) {
            path !== '/' && 
            path.length > 1)
         ) {
-          apiEndpoints.add(`${request.method()} ${path}`);
+          record(request);
         }
       } catch (error) {
         console.log(`요청 처리 오류: ${error.message}`);
@@ -52,12 +98,15 @@ async function extractApiEndpoints(targetUrl) {
     // 네트워크 응답도 모니터링
     page.on('response', async (response) => {
       try {
-        const url = new URL(response.url());
         const contentType = response.headers()['content-type'] || '';
         
         // JSON 응답은 API일 가능성이 높음
         if (contentType.includes('application/json')) {
-          apiEndpoints.add(`${response.request().method()} ${url.pathname}`);
+          record(response.request()).responseContentType = contentType;
+        } else {
+          const url = new URL(response.url());
+          const endpoint = apiEndpoints.get(`${response.request().method()} ${url.origin}${url.pathname}`);
+          if (endpoint && contentType) endpoint.responseContentType = contentType;
         }
       } catch (e) {}
     });
@@ -105,7 +154,7 @@ async function extractApiEndpoints(targetUrl) {
     await simulateUserInteraction(page);
     
     // 결과 반환
-    return Array.from(apiEndpoints);
+    return Array.from(apiEndpoints.values());
   } finally {
     await browser.close();
   }
@@ -147,24 +196,38 @@ async function simulateUserInteraction(page) {
 }
 
 // 명령줄에서 실행 시 사용 예제
+// --json 옵션을 주면 진행 로그는 stderr로 보내고, stdout에는 버전이 있는 JSON 문서만 출력합니다.
 async function main() {
-  if (process.argv.length < 3) {
-    console.log('사용법: node api-extractor.js https://example.com');
-    process.exit(1);
+  const args = process.argv.slice(2);
+  const jsonMode = args.includes('--json');
+  const targetUrl = args.find(arg => !arg.startsWith('--'));
+
+  if (!targetUrl) {
+    console.error('사용법: node api-extractor.js [--json] https://example.com');
+    process.exit(2);
   }
-  
-  const targetUrl = process.argv[2];
+
+  if (jsonMode) {
+    // 진행 로그가 JSON 출력에 섞이지 않도록 stderr로 전환
+    console.log = console.error;
+  }
+
   try {
     const endpoints = await extractApiEndpoints(targetUrl);
+
+    if (jsonMode) {
+      process.stdout.write(JSON.stringify({ version: PROTOCOL_VERSION, target: targetUrl, endpoints }) + '\n');
+      return;
+    }
     
     console.log('\n🎯 발견된 API 엔드포인트:');
     if (endpoints.length > 0) {
-      endpoints.forEach(endpoint => console.log(`- ${endpoint}`));
+      endpoints.forEach(endpoint => console.log(`- ${endpoint.method} ${endpoint.path}`));
       
       // GET 요청 경로만 필터링하여 부하테스트용으로 준비
       const pathList = endpoints
-        .filter(endpoint => endpoint.startsWith('GET'))
-        .map(endpoint => endpoint.substring(4)); // "GET " 접두사 제거
+        .filter(endpoint => endpoint.method === 'GET')
+        .map(endpoint => endpoint.path);
       
       // JSON 형식으로 저장
       const result = {
@@ -181,7 +244,11 @@ async function main() {
       console.log('API 엔드포인트를 찾지 못했습니다.');
     }
   } catch (error) {
+    if (jsonMode) {
+      process.stdout.write(JSON.stringify({ version: PROTOCOL_VERSION, target: targetUrl, endpoints: [], error: error.message }) + '\n');
+    }
     console.error('🚨 오류 발생:', error);
+    process.exitCode = 1;
   }
 }
 